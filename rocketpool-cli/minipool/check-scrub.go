@@ -0,0 +1,76 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func checkScrub(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Run the checks
+	response, err := rp.CheckScrub()
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	if len(response.Results) == 0 {
+		fmt.Println("The node does not have any prelaunch minipools.")
+		return nil
+	}
+	fmt.Printf("Checked %d prelaunch minipool(s) against the state at %s.\n", len(response.Results), response.StateBlockTime.Local().Format(time.RFC1123))
+	fmt.Printf("The scrub period is %s and the safety scrub period is %s.\n\n", response.ScrubPeriod, response.SafetyPeriod)
+	for _, result := range response.Results {
+		printScrubResult(result)
+	}
+	return nil
+
+}
+
+// Print the verdict for a minipool, along with the evidence behind it
+func printScrubResult(result scrub.Result) {
+	evidence := result.Evidence
+
+	fmt.Printf("Minipool %s (%s):\n", result.Minipool.Hex(), result.Pubkey.Hex())
+	switch result.Verdict {
+	case scrub.Verdict_Pass:
+		fmt.Printf("\t%sPASS: the minipool passed the %s check.%s\n", colorGreen, evidence.Check, colorReset)
+		fmt.Printf("\tIt can be staked after %s.\n", result.ScrubPeriodEnd.Local().Format(time.RFC1123))
+	case scrub.Verdict_Scrub:
+		fmt.Printf("\t%sSCRUB: the minipool failed the %s check and will be scrubbed by the Oracle DAO.%s\n", colorRed, evidence.Check, colorReset)
+		fmt.Printf("\tThe Oracle DAO can scrub it until %s.\n", result.ScrubPeriodEnd.Local().Format(time.RFC1123))
+	case scrub.Verdict_Pending:
+		fmt.Printf("\t%sPENDING: no valid deposit could be found for the minipool yet.%s\n", colorYellow, colorReset)
+		fmt.Printf("\tIf none is found, it will be scrubbed for safety after %s.\n", result.SafetyScrubTime.Local().Format(time.RFC1123))
+	}
+	if evidence.Reason != "" {
+		fmt.Printf("\tReason: %s\n", evidence.Reason)
+	}
+	if evidence.ExpectedWithdrawalCredentials != "" {
+		fmt.Printf("\tExpected withdrawal credentials: %s\n", evidence.ExpectedWithdrawalCredentials)
+		fmt.Printf("\tActual withdrawal credentials:   %s\n", evidence.ActualWithdrawalCredentials)
+	}
+	if evidence.DepositTxHash != "" {
+		fmt.Printf("\tDeposit: transaction %s in block %d (index %d)\n", evidence.DepositTxHash, evidence.DepositBlock, evidence.DepositIndex)
+	}
+	fmt.Println()
+}
@@ -38,6 +38,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "check-scrub",
+				Aliases:   []string{"cs"},
+				Usage:     "Run the Oracle DAO's scrub checks against the node's prelaunch minipools and explain the results.",
+				UsageText: "rocketpool minipool check-scrub",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return checkScrub(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -110,6 +128,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "no-restart",
 						Usage: "Don't restart the Validator Client after importing the key. Note that the key won't be loaded (and won't attest) until you restart the VC to load it.",
 					},
+					cli.StringFlag{
+						Name:  "slashing-protection",
+						Usage: "An EIP-3076 slashing protection file for the validator key, exported from the Validator Client that ran it before",
+					},
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Automatically confirm all interactive questions",
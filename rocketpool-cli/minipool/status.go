@@ -19,6 +19,7 @@ import (
 const colorReset string = "\033[0m"
 const colorRed string = "\033[31m"
 const colorYellow string = "\033[33m"
+const colorGreen string = "\033[32m"
 
 func getStatus(c *cli.Context) error {
 
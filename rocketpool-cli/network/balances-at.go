@@ -0,0 +1,122 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getBalancesAt(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// The per-minipool breakdown is always shown for a single block, but only on request for a range
+	block := c.Uint64("block")
+	includeMinipools := (block != 0 || c.Bool("minipools"))
+
+	// Rebuild the balances
+	fmt.Println("Rebuilding the network balances; this requires historical states from your clients and may take a while.")
+	response, err := rp.NetworkBalancesAt(block, c.Uint64("start-block"), c.Uint64("end-block"), includeMinipools)
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	if len(response.Snapshots) == 0 {
+		fmt.Println("The Oracle DAO didn't report balances for any blocks in that range.")
+		return nil
+	}
+	if block == 0 {
+		fmt.Printf("Rebuilt the balances for %d reported blocks.\n\n", len(response.Snapshots))
+	}
+	for _, snapshot := range response.Snapshots {
+		printBalancesSnapshot(snapshot)
+	}
+	return nil
+
+}
+
+// Print the rebuilt balances for a block and how they compare to the Oracle DAO's submissions
+func printBalancesSnapshot(snapshot api.NetworkBalancesSnapshot) {
+	balances := snapshot.Balances
+
+	fmt.Printf("%s=== Block %d (slot %d, %s) ===%s\n", colorGreen, snapshot.Block, snapshot.Slot, snapshot.Time.Local().Format("2006-01-02 15:04:05 MST"), colorReset)
+	if snapshot.UsesLegacyCalculation {
+		fmt.Printf("%sNOTE: This block was reported with the legacy balance calculation, so the rebuilt values may not match the submission.%s\n", colorYellow, colorReset)
+	}
+	fmt.Printf("Deposit pool balance:          %.6f ETH\n", eth.WeiToEth(balances.DepositPool))
+	fmt.Printf("Node credit balance:           %.6f ETH\n", eth.WeiToEth(balances.NodeCreditBalance))
+	fmt.Printf("Total minipool user balance:   %.6f ETH\n", eth.WeiToEth(balances.MinipoolsTotal))
+	fmt.Printf("Staking minipool user balance: %.6f ETH\n", eth.WeiToEth(balances.MinipoolsStaking))
+	fmt.Printf("Fee distributor user balance:  %.6f ETH\n", eth.WeiToEth(balances.DistributorShareTotal))
+	fmt.Printf("Smoothing pool user balance:   %.6f ETH\n", eth.WeiToEth(balances.SmoothingPoolShare))
+	fmt.Printf("rETH contract balance:         %.6f ETH\n", eth.WeiToEth(balances.RETHContract))
+	fmt.Printf("rETH token supply:             %.6f rETH\n", eth.WeiToEth(balances.RETHSupply))
+	fmt.Printf("Total ETH:                     %.6f ETH\n", eth.WeiToEth(snapshot.TotalEth))
+	if balances.RETHSupply.Sign() > 0 {
+		fmt.Printf("Exchange rate:                 %.6f ETH per rETH\n", eth.WeiToEth(snapshot.TotalEth)/eth.WeiToEth(balances.RETHSupply))
+	}
+	fmt.Println()
+
+	// Print the comparison
+	if snapshot.Consensus == nil {
+		fmt.Println("The Oracle DAO didn't reach consensus on balances for this block.")
+	} else {
+		consensus := snapshot.Consensus
+		if snapshot.Matches {
+			fmt.Printf("%sThe rebuilt balances match the Oracle DAO's consensus from block %d.%s\n", colorGreen, consensus.SubmittedBlock, colorReset)
+		} else {
+			fmt.Printf("%sThe rebuilt balances do NOT match the Oracle DAO's consensus from block %d:%s\n", colorRed, consensus.SubmittedBlock, colorReset)
+			printBalancesDifference("Total ETH", snapshot.TotalEth, consensus.TotalEth)
+			printBalancesDifference("Staking ETH", balances.MinipoolsStaking, consensus.StakingEth)
+			printBalancesDifference("rETH supply", balances.RETHSupply, consensus.RethSupply)
+		}
+	}
+	for _, submission := range snapshot.MemberSubmissions {
+		matches := (submission.TotalEth.Cmp(snapshot.TotalEth) == 0 &&
+			submission.StakingEth.Cmp(balances.MinipoolsStaking) == 0 &&
+			submission.RethSupply.Cmp(balances.RETHSupply) == 0)
+		if matches {
+			fmt.Printf("\tMember %s submitted matching balances in block %d.\n", submission.Member.Hex(), submission.SubmittedBlock)
+		} else {
+			fmt.Printf("\t%sMember %s submitted different balances in block %d (total ETH %.6f, staking ETH %.6f, rETH supply %.6f).%s\n", colorYellow, submission.Member.Hex(), submission.SubmittedBlock, eth.WeiToEth(submission.TotalEth), eth.WeiToEth(submission.StakingEth), eth.WeiToEth(submission.RethSupply), colorReset)
+		}
+	}
+	fmt.Println()
+
+	// Print the minipool contributions
+	if len(snapshot.Minipools) > 0 {
+		fmt.Printf("Minipool contributions (%d minipools):\n", len(snapshot.Minipools))
+		for _, minipool := range snapshot.Minipools {
+			staking := ""
+			if minipool.IsStaking {
+				staking = " (staking)"
+			}
+			fmt.Printf("\t%s  %-12s %.6f ETH%s\n", minipool.Address.Hex(), minipool.Status.String(), eth.WeiToEth(minipool.UserBalance), staking)
+		}
+		fmt.Println()
+	}
+}
+
+// Print how far a rebuilt value is from the submitted one
+func printBalancesDifference(name string, rebuilt *big.Int, submitted *big.Int) {
+	difference := big.NewInt(0).Sub(rebuilt, submitted)
+	fmt.Printf("\t%-12s rebuilt %.6f, submitted %.6f (difference %s wei)\n", name+":", eth.WeiToEth(rebuilt), eth.WeiToEth(submitted), difference.String())
+}
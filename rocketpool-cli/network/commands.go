@@ -1,6 +1,8 @@
 package network
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -118,6 +120,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "balances-at",
+				Aliases:   []string{"b"},
+				Usage:     "Rebuild the network balances at a historical block, or at every block the Oracle DAO reported balances for in a range, and compare them with what the Oracle DAO submitted.\nThis requires an archive execution client (see the Archive-Mode EC URL setting) and a Beacon node that can serve historical states.",
+				UsageText: "rocketpool network balances-at [--block N | --start-block N [--end-block N]] [--minipools]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "block, b",
+						Usage: "The block to rebuild the balances at",
+					},
+					cli.Uint64Flag{
+						Name:  "start-block, s",
+						Usage: "The first block of the range to rebuild the reported balances for",
+					},
+					cli.Uint64Flag{
+						Name:  "end-block, e",
+						Usage: "The last block of the range to rebuild the reported balances for (defaults to the latest block)",
+					},
+					cli.BoolFlag{
+						Name:  "minipools, m",
+						Usage: "Show the contribution of each minipool in range mode (it's always shown for a single block)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.IsSet("block") == c.IsSet("start-block") {
+						return fmt.Errorf("Exactly one of the --block and --start-block flags is required")
+					}
+					if c.IsSet("block") && c.Uint64("block") == 0 {
+						return fmt.Errorf("The block must be greater than 0")
+					}
+
+					// Run
+					return getBalancesAt(c)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -13,6 +13,7 @@ const (
 	colorReset  string = "\033[0m"
 	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
+	colorRed    string = "\033[31m"
 )
 
 func generateRewardsTree(c *cli.Context) error {
@@ -99,6 +99,46 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Usage:     "Export a ledger of every rewards claim, fee distributor distribution and minipool distribution paid to your node",
+				UsageText: "rocketpool node rewards-history [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The format of the ledger ('csv' or 'json')",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path of the file to write the ledger to (defaults to the terminal)",
+					},
+					cli.StringFlag{
+						Name:  "price-file, p",
+						Usage: "The path of a CSV file with 'date,asset,price' rows (dates as YYYY-MM-DD in UTC) used to fill in the fiat price of each entry",
+					},
+					cli.Uint64Flag{
+						Name:  "start-block",
+						Usage: "The first block to include (defaults to the start of the first rewards interval)",
+					},
+					cli.Uint64Flag{
+						Name:  "end-block",
+						Usage: "The last block to include (defaults to the latest block)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewardsHistory(c)
+
+				},
+			},
+
 			{
 				Name:      "set-withdrawal-address",
 				Aliases:   []string{"w"},
@@ -404,6 +444,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "no-restart",
 						Usage: "Don't restart the Validator Client after importing the key. Note that the key won't be loaded (and won't attest) until you restart the VC to load it.",
 					},
+					cli.StringFlag{
+						Name:  "slashing-protection",
+						Usage: "An EIP-3076 slashing protection file for the validator key, exported from the Validator Client that ran it before",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
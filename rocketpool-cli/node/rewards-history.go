@@ -0,0 +1,221 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The layout of the dates in a price file
+const priceFileDateLayout string = "2006-01-02"
+
+// A single row of the exported rewards ledger
+type rewardsLedgerRow struct {
+	Timestamp string  `json:"timestamp"`
+	Block     uint64  `json:"block"`
+	TxHash    string  `json:"txHash"`
+	Asset     string  `json:"asset"`
+	Amount    string  `json:"amount"`
+	Source    string  `json:"source"`
+	Interval  *uint64 `json:"interval,omitempty"`
+	FiatPrice string  `json:"fiatPrice"`
+	FiatValue string  `json:"fiatValue"`
+}
+
+func getRewardsHistory(c *cli.Context) error {
+
+	// Validate the format before doing any work
+	format := c.String("format")
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s' - must be 'csv' or 'json'", format)
+	}
+
+	// Load the prices first so a bad file doesn't waste a full scan
+	var prices map[string]*big.Float
+	if c.String("price-file") != "" {
+		var err error
+		prices, err = loadPriceFile(c.String("price-file"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the ledger
+	response, err := rp.NodeRewardsHistory(c.Uint64("start-block"), c.Uint64("end-block"))
+	if err != nil {
+		return err
+	}
+	if !response.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+
+	// Build the rows
+	rows := make([]rewardsLedgerRow, 0, len(response.Entries))
+	for _, entry := range response.Entries {
+		rows = append(rows, getRewardsLedgerRow(entry, prices))
+	}
+
+	// Get the output
+	var output io.Writer = os.Stdout
+	outputPath := c.String("output")
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", outputPath, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	// Write the ledger
+	switch format {
+	case "csv":
+		err = writeRewardsLedgerCsv(output, rows)
+	case "json":
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(rows)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing rewards ledger: %w", err)
+	}
+
+	if outputPath != "" {
+		fmt.Printf("Wrote %d entries (blocks %d to %d) to %s.\n", len(rows), response.StartBlock, response.EndBlock, outputPath)
+	}
+	return nil
+
+}
+
+// Convert a ledger entry into an exportable row, adding its fiat value if there's a price for it
+func getRewardsLedgerRow(entry api.RewardsLedgerEntry, prices map[string]*big.Float) rewardsLedgerRow {
+	row := rewardsLedgerRow{
+		Timestamp: entry.Timestamp.UTC().Format(time.RFC3339),
+		Block:     entry.Block,
+		TxHash:    entry.TxHash.Hex(),
+		Asset:     entry.Asset,
+		Amount:    formatWeiAmount(entry.Amount),
+		Source:    entry.Source,
+		Interval:  entry.Interval,
+	}
+
+	price, exists := prices[getPriceKey(entry.Timestamp, entry.Asset)]
+	if exists {
+		amount := new(big.Float).SetPrec(256).SetInt(entry.Amount)
+		amount.Quo(amount, big.NewFloat(1e18))
+		value := new(big.Float).SetPrec(256).Mul(amount, price)
+		row.FiatPrice = price.Text('f', -1)
+		row.FiatValue = value.Text('f', 2)
+	}
+	return row
+}
+
+// Write the ledger rows as CSV
+func writeRewardsLedgerCsv(output io.Writer, rows []rewardsLedgerRow) error {
+	writer := csv.NewWriter(output)
+	err := writer.Write([]string{"timestamp", "block", "txHash", "asset", "amount", "source", "interval", "fiatPrice", "fiatValue"})
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		interval := ""
+		if row.Interval != nil {
+			interval = strconv.FormatUint(*row.Interval, 10)
+		}
+		err = writer.Write([]string{
+			row.Timestamp,
+			strconv.FormatUint(row.Block, 10),
+			row.TxHash,
+			row.Asset,
+			row.Amount,
+			row.Source,
+			interval,
+			row.FiatPrice,
+			row.FiatValue,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Load a price file with 'date,asset,price' rows, keyed by date and asset. A header row is allowed.
+func loadPriceFile(path string) (map[string]*big.Float, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening price file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading price file %s: %w", path, err)
+	}
+
+	prices := map[string]*big.Float{}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		date, err := time.Parse(priceFileDateLayout, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("price file %s line %d has an invalid date '%s' (expected YYYY-MM-DD)", path, i+1, record[0])
+		}
+		price, ok := new(big.Float).SetPrec(256).SetString(record[2])
+		if !ok || price.Sign() < 0 {
+			return nil, fmt.Errorf("price file %s line %d has an invalid price '%s'", path, i+1, record[2])
+		}
+		prices[getPriceKey(date, record[1])] = price
+	}
+	return prices, nil
+}
+
+// Get the key of the price for an asset on the day of the given time
+func getPriceKey(timestamp time.Time, asset string) string {
+	return timestamp.UTC().Format(priceFileDateLayout) + "/" + strings.ToUpper(strings.TrimSpace(asset))
+}
+
+// Format a wei amount as an exact decimal string
+func formatWeiAmount(wei *big.Int) string {
+	if wei == nil {
+		return "0"
+	}
+	quotient, remainder := new(big.Int).QuoRem(wei, big.NewInt(1e18), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient.String()
+	}
+	fraction := strings.TrimRight(fmt.Sprintf("%018s", new(big.Int).Abs(remainder).String()), "0")
+	return fmt.Sprintf("%s.%s", quotient.String(), fraction)
+}
@@ -0,0 +1,80 @@
+package odao
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getAuditLog(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the audit log
+	response, err := rp.TNDAOAuditLog(c.String("task"), c.Uint64("since-block"), c.Int("limit"))
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	if len(response.MalformedLines) > 0 {
+		lines := make([]string, len(response.MalformedLines))
+		for i, line := range response.MalformedLines {
+			lines[i] = fmt.Sprint(line)
+		}
+		fmt.Printf("WARNING: Skipped %d line(s) of %s that couldn't be read (line numbers: %s). The watchtower may have been stopped while writing them; check the file for records that are missing below.\n\n", len(lines), response.Path, strings.Join(lines, ", "))
+	}
+	if len(response.Records) == 0 {
+		fmt.Printf("The watchtower hasn't recorded any matching transactions in %s.\n", response.Path)
+		return nil
+	}
+	fmt.Printf("Showing %d watchtower transactions from %s:\n\n", len(response.Records), response.Path)
+	for _, record := range response.Records {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("Time:        %s\n", record.Time.Local().Format("2006-01-02 15:04:05 MST"))
+		fmt.Printf("Task:        %s\n", record.Task)
+		fmt.Printf("Action:      %s\n", record.Action)
+		if record.Target != "" {
+			fmt.Printf("Target:      %s\n", record.Target)
+		}
+		if record.Slot != 0 {
+			fmt.Printf("Slot:        %d\n", record.Slot)
+		}
+		if record.Block != 0 {
+			fmt.Printf("Block:       %d\n", record.Block)
+		}
+		if record.Reason != "" {
+			fmt.Printf("Reason:      %s\n", record.Reason)
+		}
+		fmt.Printf("TX hash:     %s\n", record.TxHash.Hex())
+		if record.GasPaid != nil {
+			fmt.Printf("Gas paid:    %.6f ETH (%d gas)\n", eth.WeiToEth(record.GasPaid), record.GasUsed)
+		}
+		if record.Error != "" {
+			fmt.Printf("Error:       %s\n", record.Error)
+		}
+		if len(record.Inputs) > 0 {
+			var inputs bytes.Buffer
+			if err := json.Indent(&inputs, record.Inputs, "             ", "  "); err != nil {
+				inputs.Reset()
+				inputs.Write(record.Inputs)
+			}
+			fmt.Printf("Inputs:      %s\n", strings.TrimSpace(inputs.String()))
+		}
+		fmt.Printf("\n")
+	}
+	return nil
+
+}
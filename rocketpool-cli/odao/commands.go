@@ -50,6 +50,39 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit-log",
+				Aliases:   []string{"g"},
+				Usage:     "Show the transactions the watchtower has made, along with the evidence they were based on",
+				UsageText: "rocketpool odao audit-log [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "task, t",
+						Usage: "Only show transactions made by this watchtower task (e.g. submit-rpl-price, submit-network-balances, submit-scrub-minipools)",
+					},
+					cli.Uint64Flag{
+						Name:  "since-block, b",
+						Usage: "Only show transactions based on this EL block or later",
+					},
+					cli.IntFlag{
+						Name:  "limit, n",
+						Usage: "The number of the latest transactions to show (0 for all of them)",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getAuditLog(c)
+
+				},
+			},
+
 			{
 				Name:      "member-settings",
 				Aliases:   []string{"b"},
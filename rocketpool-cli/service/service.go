@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -19,6 +20,8 @@ import (
 	cliconfig "github.com/rocket-pool/smartnode/rocketpool-cli/service/config"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -215,10 +218,50 @@ func serviceStatus(c *cli.Context) error {
 	}
 
 	// Print service status
-	return rp.PrintServiceStatus(getComposeFiles(c))
+	err = rp.PrintServiceStatus(getComposeFiles(c))
+	if err != nil {
+		return err
+	}
+
+	// Print the watchtower leader if coordination is enabled
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew || cfg.Smartnode.WatchtowerHaEnabled.Value != true {
+		return nil
+	}
+	printWatchtowerLeader(cfg)
+	return nil
 
 }
 
+// Print which watchtower instance holds the lease
+func printWatchtowerLeader(cfg *config.RocketPoolConfig) {
+	fmt.Println()
+	leasePath := cfg.Smartnode.GetWatchtowerLeasePath(false)
+	record, err := lease.ReadLease(leasePath)
+	if err != nil {
+		fmt.Printf("%sCouldn't read the watchtower lease: %s%s\n", colorYellow, err.Error(), colorReset)
+		return
+	}
+	if record == nil {
+		fmt.Printf("%sNo watchtower instance has taken the lease in %s yet.%s\n", colorYellow, leasePath, colorReset)
+		return
+	}
+
+	instanceID := cfg.Smartnode.WatchtowerHaInstanceID.Value.(string)
+	thisInstance := ""
+	if instanceID != "" && instanceID == record.Holder {
+		thisInstance = " (this machine)"
+	}
+	if record.IsExpired() {
+		fmt.Printf("%sWatchtower leader: none; the lease held by %s expired at %s.%s\n", colorYellow, record.Holder, record.ExpiresAt.Local().Format(time.RFC1123), colorReset)
+		return
+	}
+	fmt.Printf("Watchtower leader: %s%s%s%s, since %s (lease renewed %s ago).\n", colorGreen, record.Holder, colorReset, thisInstance, record.AcquiredAt.Local().Format(time.RFC1123), time.Since(record.RenewedAt).Round(time.Second))
+}
+
 // Configure the service
 func configureService(c *cli.Context) error {
 
@@ -502,9 +545,19 @@ func changeNetworks(c *cli.Context, rp *rocketpool.Client, apiContainerName stri
 		return fmt.Errorf("error recreating data folder: %w", err)
 	}
 
+	// Get the passphrase for the encrypted password file if there is one
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	passwordUnlockKey, err := promptPasswordUnlockKey(cfg)
+	if err != nil {
+		return err
+	}
+
 	// Start the service
 	fmt.Print("Starting Rocket Pool... ")
-	err = rp.StartService(getComposeFiles(c))
+	err = rp.StartService(getComposeFiles(c), passwordUnlockKey)
 	if err != nil {
 		return fmt.Errorf("error starting service: %w", err)
 	}
@@ -514,6 +567,55 @@ func changeNetworks(c *cli.Context, rp *rocketpool.Client, apiContainerName stri
 
 }
 
+// Prompt for the passphrase that unlocks the encrypted wallet password file, if the node uses one, so it can be handed to the daemons.
+// If the file doesn't exist yet, this is the passphrase it'll be encrypted with when the wallet password is set.
+func promptPasswordUnlockKey(cfg *config.RocketPoolConfig) (string, error) {
+	if cfg.Smartnode.PasswordStoreType.Value.(cfgtypes.PasswordStoreType) != cfgtypes.PasswordStoreType_Encrypted {
+		return "", nil
+	}
+
+	// Check for an existing password file
+	passwordPath, err := homedir.Expand(cfg.Smartnode.GetEncryptedPasswordPathInCLI())
+	if err != nil {
+		return "", fmt.Errorf("error expanding encrypted password path: %w", err)
+	}
+	_, err = os.Stat(passwordPath)
+	if os.IsNotExist(err) {
+		fmt.Println("Your node wallet's password will be kept in a file encrypted with a passphrase, which you'll have to enter every time you start the Smartnode.")
+		for {
+			unlockKey := cliutils.PromptPassword(
+				"Please enter a passphrase to encrypt your wallet password with:",
+				fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+				fmt.Sprintf("Your passphrase must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+			)
+			confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+			if unlockKey == confirmation {
+				fmt.Println()
+				return unlockKey, nil
+			}
+			fmt.Println("Passphrase confirmation does not match.")
+			fmt.Println("")
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("error checking encrypted password file: %w", err)
+	}
+
+	// Prompt for the passphrase, checking it against the file if the CLI can read it
+	for {
+		unlockKey := cliutils.PromptPassword("Please enter the passphrase for your wallet password:", "^.+$", "Please enter your passphrase:")
+		_, err := passwords.NewEncryptedFileStore(passwordPath, unlockKey).Get()
+		if errors.Is(err, passwords.ErrIncorrectUnlockKey) {
+			fmt.Printf("%sThat passphrase is incorrect. Please try again.%s\n\n", colorRed, colorReset)
+			continue
+		}
+		if err != nil && !errors.Is(err, os.ErrPermission) {
+			return "", err
+		}
+		fmt.Println()
+		return unlockKey, nil
+	}
+}
+
 // Start the Rocket Pool service
 func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 
@@ -665,6 +767,14 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		}
 	}
 
+	// Let the user know about keys that are still being held back from the VC
+	heldKeys, err := cliutils.GetHeldValidatorKeys(cfg)
+	if err != nil {
+		fmt.Printf("%sCouldn't check for validator keys waiting for slashing protection data: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else if len(heldKeys) > 0 {
+		fmt.Printf("%sNOTE: %d validator key(s) are waiting for their slashing protection data, so your Validator client won't load them yet.\nImport it with `rocketpool wallet import-slashing-protection`, or release them without it with `rocketpool wallet import-slashing-protection --accept-doppelganger-wait`.%s\n\n", colorYellow, len(heldKeys), colorReset)
+	}
+
 	// Write a note on doppelganger protection
 	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
 	if err != nil {
@@ -673,8 +783,14 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		fmt.Printf("%sNOTE: You currently have Doppelganger Protection enabled.\nYour validator will miss up to 3 attestations when it starts.\nThis is *intentional* and does not indicate a problem with your node.%s\n\n", colorYellow, colorReset)
 	}
 
+	// Get the passphrase for the encrypted password file if there is one
+	passwordUnlockKey, err := promptPasswordUnlockKey(cfg)
+	if err != nil {
+		return err
+	}
+
 	// Start service
-	err = rp.StartService(getComposeFiles(c))
+	err = rp.StartService(getComposeFiles(c), passwordUnlockKey)
 	if err != nil {
 		return err
 	}
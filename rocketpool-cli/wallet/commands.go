@@ -104,6 +104,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.StringFlag{
+						Name:  "slashing-protection",
+						Usage: "An EIP-3076 slashing protection file for the validator keys being written, exported from the machine that ran them before",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -134,7 +138,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "rebuild",
 				Aliases:   []string{"b"},
 				Usage:     "Rebuild validator keystores from derived keys",
-				UsageText: "rocketpool wallet rebuild",
+				UsageText: "rocketpool wallet rebuild [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "slashing-protection",
+						Usage: "An EIP-3076 slashing protection file for the validator keys being written, exported from the machine that ran them before",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -213,6 +223,67 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Export your Validator client's slashing protection data in the EIP-3076 interchange format, so your validators can be moved to another machine safely",
+				UsageText: "rocketpool wallet export-slashing-protection [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the slashing protection data to",
+						Value: "slashing-protection.json",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator client during the export",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportSlashingProtection(c)
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import EIP-3076 slashing protection data into your Validator client before it loads validator keys that ran on another machine",
+				UsageText: "rocketpool wallet import-slashing-protection [options] file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "accept-doppelganger-wait",
+						Usage: "Don't import any data, and release keys that are waiting for it to your Validator client after confirming they haven't attested anywhere for at least 15 minutes",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm restarting the Validator client so it loads the released keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.Bool("accept-doppelganger-wait") {
+						if err := cliutils.ValidateArgCount(c, 0); err != nil {
+							return err
+						}
+						return importSlashingProtection(c, "")
+					}
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return importSlashingProtection(c, c.Args().Get(0))
+
+				},
+			},
 			{
 				Name:      "set-ens-name",
 				Aliases:   []string{"ens"},
@@ -51,10 +51,15 @@ func exportWallet(c *cli.Context) error {
 	}
 
 	// Print wallet & return
-	fmt.Println("Node account private key:")
-	fmt.Println("")
-	fmt.Println(export.AccountPrivateKey)
-	fmt.Println("")
+	if export.AccountPrivateKey != "" {
+		fmt.Println("Node account private key:")
+		fmt.Println("")
+		fmt.Println(export.AccountPrivateKey)
+		fmt.Println("")
+	} else {
+		fmt.Println("Your node account's private key is held by an external signer, so it isn't part of this export.")
+		fmt.Println("")
+	}
 	fmt.Println("Wallet password:")
 	fmt.Println("")
 	fmt.Println(export.Password)
@@ -41,6 +41,17 @@ func rebuildWallet(c *cli.Context) error {
 		return nil
 	}
 
+	// Check for slashing protection data
+	protectionImport, proceed, err := PrepareSlashingProtection(c, cfg)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	defer protectionImport.Cleanup()
+
 	// Check for custom keys
 	customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, false)
 	if err != nil {
@@ -80,6 +91,8 @@ func rebuildWallet(c *cli.Context) error {
 	} else {
 		fmt.Println("No validator keys were found.")
 	}
-	return nil
+
+	// Import the slashing protection data for the rebuilt keys
+	return protectionImport.Finish(rp, cfg)
 
 }
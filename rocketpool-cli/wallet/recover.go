@@ -65,8 +65,20 @@ func recoverWallet(c *cli.Context) error {
 	// Handle validator key recovery skipping
 	skipValidatorKeyRecovery := c.Bool("skip-validator-key-recovery")
 
-	// Check for custom keys
+	// Check for slashing protection data and custom keys
+	protectionImport := &SlashingProtectionImport{}
 	if !skipValidatorKeyRecovery {
+		var proceed bool
+		protectionImport, proceed, err = PrepareSlashingProtection(c, cfg)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		defer protectionImport.Cleanup()
+
 		customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, false)
 		if err != nil {
 			return err
@@ -177,6 +189,7 @@ func recoverWallet(c *cli.Context) error {
 		}
 	}
 
-	return nil
+	// Import the slashing protection data for the recovered keys
+	return protectionImport.Finish(rp, cfg)
 
 }
@@ -0,0 +1,378 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	slashingProtectionToolContainerSuffix string = "_slashing_protection"
+	validatorContainerSuffix              string = "_validator"
+)
+
+// Slashing protection data given to a command that writes validator keys
+type SlashingProtectionImport struct {
+	data               []byte
+	interchange        *slashing.Interchange
+	stagedPath         string
+	validatorRestarted bool
+}
+
+// Export the VC's slashing protection database
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("this function is not supported in Native Mode; please use your Validator client's own tool to export its slashing protection data")
+	}
+	if cfg.Smartnode.IsWeb3SignerEnabled() {
+		return fmt.Errorf("your validators sign through Web3Signer, which keeps their slashing protection data in its own database; please export it with Web3Signer's own tool")
+	}
+
+	// Get the export command
+	client, _ := cfg.GetSelectedConsensusClient()
+	command, err := slashing.GetExportCommand(client, cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	fmt.Println("Your Validator client will be stopped while its slashing protection data is exported, and started again afterwards.")
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run the tool
+	workDir, err := createSlashingProtectionWorkDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+	if err := runSlashingProtectionTool(rp, cfg, command, workDir); err != nil {
+		return fmt.Errorf("error exporting slashing protection data: %w", err)
+	}
+
+	// Check and save the exported data
+	data, err := os.ReadFile(filepath.Join(workDir, command.Filename))
+	if err != nil {
+		return fmt.Errorf("error reading exported slashing protection data: %w", err)
+	}
+	interchange, err := slashing.ParseInterchange(data)
+	if err != nil {
+		return err
+	}
+	outputPath := c.String("output")
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("error saving slashing protection data: %w", err)
+	}
+
+	// Log & return
+	fmt.Printf("Exported the slashing protection data for %d validator(s) to %s.\n", len(interchange.Data), outputPath)
+	fmt.Println("Import it on the machine that will run these validators next with `rocketpool wallet import-slashing-protection`, **before** its Validator client loads their keys.")
+	return nil
+
+}
+
+// Import slashing protection data into the VC
+func importSlashingProtection(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Release the held keys without any data if requested
+	if c.Bool("accept-doppelganger-wait") {
+		released, err := cliutils.ConfirmReleaseHeldKeys(rp, cfg)
+		if err != nil {
+			return err
+		}
+		if released == nil {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		if len(released) == 0 {
+			fmt.Println("There aren't any validator keys waiting for slashing protection data.")
+			return nil
+		}
+		fmt.Printf("Released %d validator key(s) to your Validator client.\n", len(released))
+		return restartValidatorForReleasedKeys(rp, cfg, c.Bool("yes"))
+	}
+	if path == "" {
+		return fmt.Errorf("Please provide the slashing protection file to import, or use --accept-doppelganger-wait to go without one")
+	}
+
+	// Load the data
+	protectionImport := &SlashingProtectionImport{}
+	if err := protectionImport.load(path); err != nil {
+		return err
+	}
+	defer protectionImport.Cleanup()
+
+	// With a remote signer or the Keymanager API, the data is imported along with the keys, so rebuild them
+	if isKeymanagerManaged(cfg) {
+		if err := protectionImport.stage(cfg); err != nil {
+			return err
+		}
+
+		// Check for custom keys
+		customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, false)
+		if err != nil {
+			return err
+		}
+		if customKeyPasswordFile != "" {
+			defer func(customKeyPasswordFile string) {
+				err := deleteCustomKeyPasswordFile(customKeyPasswordFile)
+				if err != nil {
+					fmt.Printf("*** WARNING ***\nAn error occurred while removing the custom keystore password file: %s\n\nThis file contains the passwords to your custom validator keys.\nYou *must* delete it manually as soon as possible so nobody can read it.\n\nThe file is located here:\n\n\t%s\n\n", err.Error(), customKeyPasswordFile)
+				}
+			}(customKeyPasswordFile)
+		}
+
+		fmt.Println("Importing slashing protection data along with your validator keys...")
+		response, err := rp.RebuildWallet()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported the slashing protection data with %d validator key(s).\n", len(response.ValidatorKeys))
+		return nil
+	}
+
+	// Otherwise run the VC's own tool
+	if err := protectionImport.Finish(rp, cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Imported the slashing protection data for %d validator(s).\n", len(protectionImport.interchange.Data))
+	return nil
+
+}
+
+// Load the slashing protection data from the command's --slashing-protection flag before it writes validator keys.
+// With a remote signer or the Keymanager API, keys are loaded as soon as they're written, so the data is staged for the daemon to import along with them;
+// if there isn't any, the user has to accept relying on the doppelganger wait up front.
+// Returns false if the user cancelled.
+func PrepareSlashingProtection(c *cli.Context, cfg *config.RocketPoolConfig) (*SlashingProtectionImport, bool, error) {
+	protectionImport := &SlashingProtectionImport{}
+	path := c.String("slashing-protection")
+	if path != "" {
+		if err := protectionImport.load(path); err != nil {
+			return nil, false, err
+		}
+	}
+	if !isKeymanagerManaged(cfg) {
+		return protectionImport, true, nil
+	}
+
+	if protectionImport.interchange == nil {
+		fmt.Printf("%sYour validator keys will be loaded into your Validator client as soon as they're written, and you haven't provided any slashing protection data for them with --slashing-protection.%s\n", colorYellow, colorReset)
+		return protectionImport, cliutils.ConfirmDoppelgangerWait(cfg), nil
+	}
+	if err := protectionImport.stage(cfg); err != nil {
+		return nil, false, err
+	}
+	return protectionImport, true, nil
+}
+
+// Finish importing the slashing protection data once the keys are written, and remove the staged copy.
+// Without a remote signer or the Keymanager API, this runs the VC's own tool, which restarts the VC if it was running.
+func (i *SlashingProtectionImport) Finish(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	defer i.Cleanup()
+	if i.interchange == nil || isKeymanagerManaged(cfg) {
+		return nil
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("the Smartnode can't import slashing protection data in Native Mode; please import it with your Validator client's own tool, then run `rocketpool wallet import-slashing-protection --accept-doppelganger-wait` so the Smartnode lets it load the keys")
+	}
+
+	// Get the import command
+	client, _ := cfg.GetSelectedConsensusClient()
+	command, err := slashing.GetImportCommand(client, cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	if err != nil {
+		return err
+	}
+
+	// Run the tool
+	workDir, err := createSlashingProtectionWorkDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+	if err := os.WriteFile(filepath.Join(workDir, command.Filename), i.data, 0644); err != nil {
+		return fmt.Errorf("error writing slashing protection data for the import tool: %w", err)
+	}
+	fmt.Println("Importing slashing protection data into your Validator client...")
+	if err := runSlashingProtectionTool(rp, cfg, command, workDir, i.interchange.Pubkeys()...); err != nil {
+		return fmt.Errorf("error importing slashing protection data: %w", err)
+	}
+	i.validatorRestarted = true
+	return nil
+}
+
+// Check if the VC was restarted while importing the data
+func (i *SlashingProtectionImport) ValidatorRestarted() bool {
+	return i.validatorRestarted
+}
+
+// Remove the staged copy of the data, if there is one
+func (i *SlashingProtectionImport) Cleanup() {
+	if i.stagedPath == "" {
+		return
+	}
+	err := os.Remove(i.stagedPath)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("%sWARNING: couldn't remove the staged slashing protection data at %s: %s\nPlease remove it manually so it isn't imported with any other keys.%s\n", colorYellow, i.stagedPath, err.Error(), colorReset)
+		return
+	}
+	i.stagedPath = ""
+}
+
+// Load and check the data
+func (i *SlashingProtectionImport) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading slashing protection file: %w", err)
+	}
+	interchange, err := slashing.ParseInterchange(data)
+	if err != nil {
+		return err
+	}
+	i.data = data
+	i.interchange = interchange
+	fmt.Printf("Loaded slashing protection data for %d validator(s).\n", len(interchange.Data))
+	return nil
+}
+
+// Stage the data for the daemon to import along with the keys it writes
+func (i *SlashingProtectionImport) stage(cfg *config.RocketPoolConfig) error {
+	stagedPath, err := homedir.Expand(cfg.Smartnode.GetSlashingProtectionImportPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding slashing protection import path: %w", err)
+	}
+	if err := os.WriteFile(stagedPath, i.data, 0600); err != nil {
+		return fmt.Errorf("error staging slashing protection data: %w", err)
+	}
+	i.stagedPath = stagedPath
+	return nil
+}
+
+// Restart the VC so it loads the validator keys that were just released to it
+func restartValidatorForReleasedKeys(rp *rocketpool.Client, cfg *config.RocketPoolConfig, yes bool) error {
+	if cfg.IsNativeMode {
+		fmt.Println("Please restart your Validator client so it loads them.")
+		return nil
+	}
+	validatorContainerName := cfg.Smartnode.ProjectName.Value.(string) + validatorContainerSuffix
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	if err != nil || status != "running" {
+		fmt.Println("Your Validator client will load them the next time it starts.")
+		return nil
+	}
+	if !(yes || cliutils.Confirm("Would you like to restart your Validator client now so it loads them?")) {
+		fmt.Println("Your Validator client will load them the next time it restarts.")
+		return nil
+	}
+	fmt.Printf("Restarting %s...\n", validatorContainerName)
+	if _, err := rp.RestartContainer(validatorContainerName); err != nil {
+		return fmt.Errorf("error restarting %s: %w", validatorContainerName, err)
+	}
+	return nil
+}
+
+// Check if validator keys are loaded through the Keymanager API of a remote signer or the VC, which takes slashing protection data along with them
+func isKeymanagerManaged(cfg *config.RocketPoolConfig) bool {
+	return cfg.Smartnode.IsWeb3SignerEnabled() || cfg.Smartnode.IsKeymanagerApiEnabled()
+}
+
+// Create a working folder for the slashing protection tool; the tool may run as a different user, so it has to be able to write to it
+func createSlashingProtectionWorkDir() (string, error) {
+	workDir, err := os.MkdirTemp("", "rocketpool-slashing-protection-")
+	if err != nil {
+		return "", fmt.Errorf("error creating slashing protection working folder: %w", err)
+	}
+	if err := os.Chmod(workDir, 0777); err != nil {
+		os.RemoveAll(workDir)
+		return "", fmt.Errorf("error setting slashing protection working folder permissions: %w", err)
+	}
+	return workDir, nil
+}
+
+// Run the VC's slashing protection tool with the VC stopped, then start the VC again if it was running.
+// Any keys given are released to the VC once the tool succeeds, if they were being held for their slashing protection data.
+func runSlashingProtectionTool(rp *rocketpool.Client, cfg *config.RocketPoolConfig, command slashing.ToolCommand, workDir string, importedKeys ...types.ValidatorPubkey) error {
+
+	// Get the VC's image and folders
+	ccConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return err
+	}
+	validatorsPath, err := homedir.Expand(cfg.Smartnode.GetValidatorKeychainPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding validators folder path: %w", err)
+	}
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	validatorContainerName := prefix + validatorContainerSuffix
+
+	// Stop the VC, since the tools need exclusive access to its database
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	wasRunning := err == nil && status == "running"
+	if wasRunning {
+		fmt.Printf("Stopping %s...\n", validatorContainerName)
+		if _, err := rp.StopContainer(validatorContainerName); err != nil {
+			return fmt.Errorf("error stopping %s: %w", validatorContainerName, err)
+		}
+	}
+
+	// Run the tool
+	toolErr := rp.RunSlashingProtectionTool(prefix+slashingProtectionToolContainerSuffix, ccConfig.GetValidatorImage(), validatorsPath, workDir, command.Entrypoint, command.Args)
+	if toolErr == nil && len(importedKeys) > 0 {
+		// Release the held keys the data was imported for so the VC loads them when it starts
+		response, err := rp.ReleaseHeldKeys(importedKeys)
+		if err != nil {
+			return fmt.Errorf("error releasing validator keys to the Validator client: %w", err)
+		}
+		if len(response.ValidatorKeys) > 0 {
+			fmt.Printf("Released %d validator key(s) to your Validator client.\n", len(response.ValidatorKeys))
+		}
+	}
+
+	// Start the VC again
+	if wasRunning {
+		fmt.Printf("Starting %s...\n", validatorContainerName)
+		if _, err := rp.StartContainer(validatorContainerName); err != nil {
+			return fmt.Errorf("error starting %s: %w", validatorContainerName, err)
+		}
+	}
+	return toolErr
+
+}
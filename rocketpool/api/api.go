@@ -1,11 +1,12 @@
 package api
 
 import (
+	"os"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/rocketpool/api/debug"
 	"github.com/urfave/cli"
 
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/smartnode/rocketpool/api/auction"
 	"github.com/rocket-pool/smartnode/rocketpool/api/faucet"
 	"github.com/rocket-pool/smartnode/rocketpool/api/minipool"
@@ -16,6 +17,7 @@ import (
 	apiservice "github.com/rocket-pool/smartnode/rocketpool/api/service"
 	"github.com/rocket-pool/smartnode/rocketpool/api/wallet"
 	"github.com/rocket-pool/smartnode/shared/services"
+	rpwallet "github.com/rocket-pool/smartnode/shared/services/wallet"
 	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -28,10 +30,14 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := apitypes.APIResponse{}
-	_, err = utils.WaitForTransaction(rp.Client, hash)
+	_, err = rpwallet.WaitForTransaction(rp.Client, os.ExpandEnv(cfg.Smartnode.GetPendingTransactionsPath()), hash)
 	if err != nil {
 		return nil, err
 	}
@@ -2,9 +2,11 @@ package debug
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -36,6 +38,71 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "record-rewards-fixture",
+				Usage:     "Generate the rewards tree for the given interval while recording every Beacon and Execution client response into a replay fixture",
+				UsageText: "rocketpool api debug record-rewards-fixture --index N --output path",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index, i",
+						Usage: "The index of the rewards interval to record",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the compressed fixture to",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("index") {
+						return fmt.Errorf("The --index flag is required")
+					}
+					if c.String("output") == "" {
+						return fmt.Errorf("The --output flag is required")
+					}
+
+					// Run
+					api.PrintResponse(recordRewardsFixture(c, c.Uint64("index"), c.String("output")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "replay-rewards-fixture",
+				Usage:     "Regenerate the rewards tree offline from a recorded fixture and compare it with the fixture's golden Merkle root. Exits with a non-zero code if they differ.",
+				UsageText: "rocketpool api debug replay-rewards-fixture --fixture path",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "fixture, f",
+						Usage: "The path of the compressed fixture to replay",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.String("fixture") == "" {
+						return fmt.Errorf("The --fixture flag is required")
+					}
+
+					// Run
+					response, err := replayRewardsFixture(c, c.String("fixture"))
+					api.PrintResponse(response, err)
+					if err != nil || !response.Matches {
+						os.Exit(1)
+					}
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,226 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/replay"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/urfave/cli"
+)
+
+// Records every Beacon and Execution client response used while generating the tree for an interval into a fixture
+func recordRewardsFixture(c *cli.Context, index uint64, path string) (*api.RecordRewardsFixtureResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the interval has been finalized
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if currentIndexBig.Uint64() <= index {
+		return nil, fmt.Errorf("The current active rewards period is interval %d. You cannot record a fixture for interval %d until the active interval is past it.", currentIndexBig.Uint64(), index)
+	}
+
+	// Wrap the clients with recorders
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	fixture := replay.NewFixture(string(network), index)
+	recordingBc := replay.NewBeaconClientRecorder(bc, fixture)
+	recordingRp, err := rocketpool.NewRocketPool(replay.NewExecutionClientRecorder(rp.Client, fixture), common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating recording Rocket Pool binding: %w", err)
+	}
+
+	// Logs go to stderr so they don't interfere with the JSON response
+	logger := log.NewColorLogger(color.FgWhite)
+	logPrefix := fmt.Sprintf("[Interval %d Recording]", index)
+
+	// Find the event and execution block for this interval
+	rewardsEvent, elBlockHeader, err := getRewardsFixtureEvent(recordingRp, cfg, index)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get an EC that can serve the state for the snapshot block, and record it too
+	client, err := eth1.GetBestApiClient(recordingRp, cfg, func(message string) {
+		logger.Printlnf("%s %s", logPrefix, message)
+	}, elBlockHeader.Number)
+	if err != nil {
+		return nil, err
+	}
+	if client != recordingRp {
+		client, err = rocketpool.NewRocketPool(replay.NewExecutionClientRecorder(client.Client, fixture), common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			return nil, fmt.Errorf("error creating recording Rocket Pool binding: %w", err)
+		}
+	}
+
+	// Generate the tree
+	rulesetVersion, rewardsFile, err := generateFixtureTree(logger, logPrefix, client, cfg, recordingBc, index, rewardsEvent, elBlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// A fixture is only useful as a regression test if the tree it produces is the one the Oracle DAO agreed on
+	generatedRoot := common.HexToHash(rewardsFile.MerkleRoot)
+	if generatedRoot != rewardsEvent.MerkleRoot {
+		return nil, fmt.Errorf("the generated root %s does not match the canonical root %s for interval %d, so the fixture was not saved", generatedRoot.Hex(), rewardsEvent.MerkleRoot.Hex(), index)
+	}
+
+	// Save the fixture
+	fixture.RulesetVersion = rulesetVersion
+	fixture.CanonicalMerkleRoot = rewardsEvent.MerkleRoot
+	fixture.GoldenMerkleRoot = rewardsEvent.MerkleRoot
+	err = fixture.Save(path)
+	if err != nil {
+		return nil, err
+	}
+	logger.Printlnf("%s Saved fixture to %s.", logPrefix, path)
+
+	// Return response
+	return &api.RecordRewardsFixtureResponse{
+		Index:                  index,
+		RulesetVersion:         rulesetVersion,
+		CanonicalMerkleRoot:    fixture.CanonicalMerkleRoot,
+		GoldenMerkleRoot:       fixture.GoldenMerkleRoot,
+		BeaconResponseCount:    len(fixture.BeaconResponses),
+		ExecutionResponseCount: len(fixture.ExecutionResponses),
+		Path:                   path,
+	}, nil
+
+}
+
+// Regenerates the tree for a recorded fixture offline and compares it with the golden Merkle root
+func replayRewardsFixture(c *cli.Context, path string) (*api.ReplayRewardsFixtureResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the fixture
+	fixture, err := replay.LoadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if fixture.Network != string(network) {
+		return nil, fmt.Errorf("fixture %s was recorded on %s but the Smartnode is configured for %s", path, fixture.Network, string(network))
+	}
+
+	return runRewardsFixtureReplay(cfg, fixture)
+
+}
+
+// Regenerates the tree for a loaded fixture using only its recorded responses
+func runRewardsFixtureReplay(cfg *config.RocketPoolConfig, fixture *replay.Fixture) (*api.ReplayRewardsFixtureResponse, error) {
+
+	// Create the replay clients
+	replayBc := replay.NewBeaconClientReplayer(fixture)
+	replayRp, err := rocketpool.NewRocketPool(replay.NewExecutionClientReplayer(fixture), common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating replay Rocket Pool binding: %w", err)
+	}
+
+	// Logs go to stderr so they don't interfere with the JSON response
+	logger := log.NewColorLogger(color.FgWhite)
+	logPrefix := fmt.Sprintf("[Interval %d Replay]", fixture.Index)
+
+	// Find the event and execution block for this interval
+	rewardsEvent, elBlockHeader, err := getRewardsFixtureEvent(replayRp, cfg, fixture.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the tree
+	rulesetVersion, rewardsFile, err := generateFixtureTree(logger, logPrefix, replayRp, cfg, replayBc, fixture.Index, rewardsEvent, elBlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare against the golden root
+	response := api.ReplayRewardsFixtureResponse{
+		Index:               fixture.Index,
+		RulesetVersion:      rulesetVersion,
+		GoldenMerkleRoot:    fixture.GoldenMerkleRoot,
+		GeneratedMerkleRoot: common.HexToHash(rewardsFile.MerkleRoot),
+	}
+	response.Matches = (response.GeneratedMerkleRoot == response.GoldenMerkleRoot)
+	if response.Matches {
+		logger.Printlnf("%s The replayed tree matches the golden root %s.", logPrefix, response.GoldenMerkleRoot.Hex())
+	} else {
+		logger.Printlnf("%s The replayed tree root %s does NOT match the golden root %s.", logPrefix, response.GeneratedMerkleRoot.Hex(), response.GoldenMerkleRoot.Hex())
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Gets the rewards snapshot event for an interval along with the header of its execution block
+func getRewardsFixtureEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, index uint64) (rewards.RewardsEvent, *types.Header, error) {
+
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, index)
+	if err != nil {
+		return rewards.RewardsEvent{}, nil, fmt.Errorf("error getting event for interval %d: %w", index, err)
+	}
+	elBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return rewards.RewardsEvent{}, nil, fmt.Errorf("error getting execution block %s: %w", rewardsEvent.ExecutionBlock.String(), err)
+	}
+	return rewardsEvent, elBlockHeader, nil
+
+}
+
+// Runs the full tree generation pipeline for an interval
+func generateFixtureTree(logger log.ColorLogger, logPrefix string, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header) (uint64, *rprewards.RewardsFile, error) {
+
+	// Get the state for the target slot
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := mgr.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting state for beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	// Generate the tree
+	treegen, err := rprewards.NewTreeGenerator(logger, logPrefix, rp, cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		return 0, nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+	return treegen.GetGeneratorRulesetVersion(), rewardsFile, nil
+
+}
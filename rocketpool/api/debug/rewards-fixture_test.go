@@ -0,0 +1,54 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/replay"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The folder replay fixtures are read from, which can be overridden so large fixtures can live outside the repository
+const rewardsFixtureDirEnvVar string = "ROCKETPOOL_REWARDS_FIXTURE_DIR"
+
+// Regenerates the tree for every recorded fixture and checks it against the fixture's golden root.
+// Fixtures are recorded with `rocketpool api debug record-rewards-fixture`.
+func TestReplayRewardsFixtures(t *testing.T) {
+	fixtureDir := os.Getenv(rewardsFixtureDirEnvVar)
+	if fixtureDir == "" {
+		fixtureDir = filepath.Join("testdata", "rewards-fixtures")
+	}
+	paths, err := filepath.Glob(filepath.Join(fixtureDir, "*.zst"))
+	if err != nil {
+		t.Fatalf("error finding fixtures in %s: %s", fixtureDir, err.Error())
+	}
+	if len(paths) == 0 {
+		t.Skipf("no rewards fixtures in %s; set %s to replay them from somewhere else", fixtureDir, rewardsFixtureDirEnvVar)
+	}
+
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			fixture, err := replay.LoadFixture(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := config.NewRocketPoolConfig("", false)
+			cfg.ChangeNetwork(cfgtypes.Network(fixture.Network))
+
+			response, err := runRewardsFixtureReplay(cfg, fixture)
+			if err != nil {
+				t.Fatalf("error replaying interval %d: %s", fixture.Index, err.Error())
+			}
+			if response.RulesetVersion != fixture.RulesetVersion {
+				t.Errorf("interval %d was generated with ruleset v%d but was recorded with v%d", fixture.Index, response.RulesetVersion, fixture.RulesetVersion)
+			}
+			if !response.Matches {
+				t.Errorf("interval %d produced root %s instead of the golden root %s", fixture.Index, response.GeneratedMerkleRoot.Hex(), response.GoldenMerkleRoot.Hex())
+			}
+		})
+	}
+}
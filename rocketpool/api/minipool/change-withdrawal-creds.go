@@ -211,6 +211,8 @@ func changeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mnemo
 	}
 
 	// Get signed withdrawal creds change message
+	// This is always signed locally, even with Web3Signer: the withdrawal key comes from the mnemonic provided for this change and is never
+	// imported into the signer, and Web3Signer's signing API has no BLS-to-execution change message type.
 	signature, err := validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, validatorIndex, minipoolAddress, signatureDomain)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,80 @@
+package minipool
+
+import (
+	"fmt"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func checkScrub(c *cli.Context) (*api.MinipoolCheckScrubResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolCheckScrubResponse{}
+
+	// Get the network state for the node
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, _, err := mgr.GetHeadStateForNode(nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Get prelaunch minipools
+	prelaunchMinipools := []*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Status == rptypes.Prelaunch {
+			prelaunchMinipools = append(prelaunchMinipools, mpd)
+		}
+	}
+
+	// Run the checks
+	report, err := scrub.CheckMinipools(rp, cfg, networkState, prelaunchMinipools, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error running scrub checks: %w", err)
+	}
+	response.StateBlockTime = report.StateBlockTime
+	response.ScrubPeriod = report.ScrubPeriod
+	response.SafetyPeriod = report.SafetyPeriod
+	response.Results = report.Results
+
+	// Return response
+	return &response, nil
+
+}
@@ -1,6 +1,8 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -8,6 +10,8 @@ import (
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -67,6 +71,10 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	if err := services.RequireBeaconClientSynced(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -89,24 +97,12 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 		return nil, err
 	}
 
-	// Get validator private key
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
-	if err != nil {
-		return nil, err
-	}
-
 	// Get beacon head
 	head, err := bc.GetBeaconHead()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get voluntary exit signature domain
-	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch, false)
-	if err != nil {
-		return nil, err
-	}
-
 	// Get validator index
 	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
 	if err != nil {
@@ -114,7 +110,14 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	}
 
 	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	var signature types.ValidatorSignature
+	if cfg.Smartnode.IsWeb3SignerEnabled() {
+		signature, err = getRemoteSignedExitMessage(c, bc, validatorPubkey, validatorIndex, head.Epoch)
+	} else if cfg.Smartnode.IsKeymanagerApiEnabled() {
+		signature, err = getKeymanagerSignedExitMessage(c, validatorPubkey, validatorIndex, head.Epoch)
+	} else {
+		signature, err = getLocalSignedExitMessage(w, bc, validatorPubkey, validatorIndex, head.Epoch)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -128,3 +131,68 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	return &response, nil
 
 }
+
+// Sign a voluntary exit message with the validator key from the wallet's keystores
+func getLocalSignedExitMessage(w *wallet.Wallet, bc beacon.Client, validatorPubkey types.ValidatorPubkey, validatorIndex uint64, epoch uint64) (types.ValidatorSignature, error) {
+
+	// Get validator private key
+	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Get voluntary exit signature domain
+	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], epoch, false)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Get signed voluntary exit message
+	return validator.GetSignedExitMessage(validatorKey, validatorIndex, epoch, signatureDomain)
+
+}
+
+// Have the remote signer holding the validator key sign a voluntary exit message
+func getRemoteSignedExitMessage(c *cli.Context, bc beacon.Client, validatorPubkey types.ValidatorPubkey, validatorIndex uint64, epoch uint64) (types.ValidatorSignature, error) {
+
+	// Get the signer
+	signer, err := services.GetWeb3Signer(c)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Get the fork info the signer needs to compute the signature domain
+	fork, err := bc.GetFork("head")
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Get signed voluntary exit message
+	return signer.SignVoluntaryExit(validatorPubkey, validatorIndex, epoch, fork, eth2Config.GenesisValidatorsRoot)
+
+}
+
+// Have the validator client holding the validator key sign a voluntary exit message through its Keymanager API
+func getKeymanagerSignedExitMessage(c *cli.Context, validatorPubkey types.ValidatorPubkey, validatorIndex uint64, epoch uint64) (types.ValidatorSignature, error) {
+
+	// Get the Keymanager API client
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Get signed voluntary exit message
+	exit, err := km.SignVoluntaryExit(validatorPubkey, epoch)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+	if exit.ValidatorIndex != validatorIndex || exit.Epoch != epoch {
+		return types.ValidatorSignature{}, fmt.Errorf("the validator client signed an exit for validator %d at epoch %d instead of validator %d at epoch %d", exit.ValidatorIndex, exit.Epoch, validatorIndex, epoch)
+	}
+	return exit.Signature, nil
+
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
 func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string) (*api.ImportKeyResponse, error) {
@@ -24,6 +25,10 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 	if err := services.RequireBeaconClientSynced(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -81,12 +86,20 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 		return nil, fmt.Errorf("couldn't find the validator key for this mnemonic after %d tries", validatorLimit)
 	}
 
-	// Save the keystore to disk
+	// Save the keystore to disk, holding it back from the VC until its slashing protection data is imported
+	err = walletutils.HoldNewValidatorKeys(cfg, w)
+	if err != nil {
+		return nil, err
+	}
 	derivationPath := fmt.Sprintf(validatorKeyPath, index)
 	err = w.StoreValidatorKey(validatorKey, derivationPath)
 	if err != nil {
 		return nil, fmt.Errorf("error saving keystore: %w", err)
 	}
+	err = walletutils.RecordHeldValidatorKeys(cfg, w)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return response
 	return &response, nil
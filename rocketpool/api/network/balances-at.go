@@ -0,0 +1,311 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/balances"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How many blocks after a reported block to look for the Oracle DAO's submissions of it (about a day)
+	balancesSubmissionSearchWindow uint64 = 7200
+)
+
+// A balances submission event, along with the block the balances were for
+type balancesEvent struct {
+	forBlock   uint64
+	submission api.NetworkBalancesSubmission
+}
+
+// Rebuild the network balances at a single block, or at every block the Oracle DAO reported balances for in a range
+func getBalancesAt(c *cli.Context, block uint64, startBlock uint64, endBlock uint64, includeMinipools bool) (*api.NetworkBalancesAtResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkBalancesAtResponse{
+		Snapshots: []api.NetworkBalancesSnapshot{},
+	}
+
+	// Logs go to stderr so they don't interfere with the JSON response
+	logger := log.NewColorLogger(NormalLogger)
+
+	// Get the range to search for submissions in
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	rangeMode := (block == 0)
+	if !rangeMode {
+		startBlock = block
+		endBlock = block
+	}
+	if endBlock == 0 || endBlock > latestBlock {
+		endBlock = latestBlock
+	}
+	if startBlock > endBlock {
+		return nil, fmt.Errorf("start block %d is after end block %d", startBlock, endBlock)
+	}
+	searchEnd := endBlock + balancesSubmissionSearchWindow
+	if searchEnd > latestBlock {
+		searchEnd = latestBlock
+	}
+
+	// Get the Oracle DAO submissions
+	events, err := getBalancesEvents(rp, cfg, startBlock, endBlock, searchEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// In range mode, rebuild every block that was reported
+	blocks := []uint64{block}
+	if rangeMode {
+		blocks = []uint64{}
+		for reportedBlock := range events {
+			blocks = append(blocks, reportedBlock)
+		}
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i] < blocks[j]
+		})
+		logger.Printlnf("Found %d reported balance blocks between blocks %d and %d.", len(blocks), startBlock, endBlock)
+	}
+
+	// Get the Beacon config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+
+	for _, targetBlock := range blocks {
+		snapshot, err := getBalancesSnapshot(logger, rp, cfg, bc, eth2Config, targetBlock, includeMinipools)
+		if err != nil {
+			return nil, err
+		}
+
+		// Compare with the submissions
+		for _, event := range events[targetBlock] {
+			if event.submission.Member == (common.Address{}) {
+				consensus := event.submission
+				snapshot.Consensus = &consensus
+			} else {
+				snapshot.MemberSubmissions = append(snapshot.MemberSubmissions, event.submission)
+			}
+		}
+		if snapshot.Consensus != nil {
+			snapshot.Matches = (snapshot.TotalEth.Cmp(snapshot.Consensus.TotalEth) == 0 &&
+				snapshot.Balances.MinipoolsStaking.Cmp(snapshot.Consensus.StakingEth) == 0 &&
+				snapshot.Balances.RETHSupply.Cmp(snapshot.Consensus.RethSupply) == 0)
+		}
+		response.Snapshots = append(response.Snapshots, snapshot)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Rebuild the network balances at a block
+func getBalancesSnapshot(logger log.ColorLogger, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, eth2Config beacon.Eth2Config, block uint64, includeMinipools bool) (api.NetworkBalancesSnapshot, error) {
+
+	logPrefix := fmt.Sprintf("[Block %d]", block)
+	logger.Printlnf("%s Rebuilding network balances...", logPrefix)
+
+	// Get the Beacon slot for the block
+	blockNumber := big.NewInt(0).SetUint64(block)
+	header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return api.NetworkBalancesSnapshot{}, fmt.Errorf("error getting header for block %d: %w", block, err)
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+	slot := balances.GetSlotForBlock(header, eth2Config)
+
+	// Get an EC that can serve the state for the block
+	client, err := eth1.GetBestApiClient(rp, cfg, func(message string) {
+		logger.Printlnf("%s %s", logPrefix, message)
+	}, blockNumber)
+	if err != nil {
+		return api.NetworkBalancesSnapshot{}, err
+	}
+
+	// Get the state for the slot
+	mgr, err := state.NewNetworkStateManager(client, cfg, client.Client, bc, &logger)
+	if err != nil {
+		return api.NetworkBalancesSnapshot{}, fmt.Errorf("error creating network state manager for block %d: %w", block, err)
+	}
+	networkState, err := mgr.GetStateForSlot(slot)
+	if err != nil {
+		return api.NetworkBalancesSnapshot{}, fmt.Errorf("error getting network state for block %d, Beacon slot %d: %w", block, slot, err)
+	}
+
+	// Calculate the balances
+	networkBalances, minipools, err := balances.GetNetworkBalances(logger, client, cfg, bc, networkState, header, blockTime, networkState.IsAtlasDeployed)
+	if err != nil {
+		return api.NetworkBalancesSnapshot{}, fmt.Errorf("error calculating network balances for block %d: %w", block, err)
+	}
+	totalEth := balances.GetTotalEthBalance(networkBalances)
+	logger.Printlnf("%s Total ETH = %.6f, staking ETH = %.6f, rETH supply = %.6f", logPrefix, eth.WeiToEth(totalEth), eth.WeiToEth(networkBalances.MinipoolsStaking), eth.WeiToEth(networkBalances.RETHSupply))
+
+	snapshot := api.NetworkBalancesSnapshot{
+		Block:                 block,
+		Slot:                  slot,
+		Time:                  blockTime.UTC(),
+		UsesLegacyCalculation: (slot / eth2Config.SlotsPerEpoch) < cfg.Smartnode.BalancesModernizationEpoch.Value.(uint64),
+		Balances:              networkBalances,
+		TotalEth:              totalEth,
+		MemberSubmissions:     []api.NetworkBalancesSubmission{},
+	}
+	if includeMinipools {
+		snapshot.Minipools = minipools
+	}
+	return snapshot, nil
+
+}
+
+// Get the Oracle DAO's balance submissions and consensus updates for blocks in a range, keyed by the block they were for.
+// Consensus updates have a blank member address.
+func getBalancesEvents(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, startBlock uint64, endBlock uint64, searchEnd uint64) (map[uint64][]balancesEvent, error) {
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// The contract may have been upgraded during the range, so check it at both ends
+	contracts := []*rocketpool.Contract{}
+	for _, contractBlock := range []uint64{startBlock, searchEnd} {
+		client, err := eth1.GetBestApiClient(rp, cfg, func(string) {}, big.NewInt(0).SetUint64(contractBlock))
+		if err != nil {
+			return nil, err
+		}
+		contract, err := client.GetContract("rocketNetworkBalances", &bind.CallOpts{BlockNumber: big.NewInt(0).SetUint64(contractBlock)})
+		if err != nil {
+			return nil, fmt.Errorf("error getting network balances contract at block %d: %w", contractBlock, err)
+		}
+		if len(contracts) > 0 && *contracts[0].Address == *contract.Address {
+			continue
+		}
+		contracts = append(contracts, contract)
+	}
+
+	events := map[uint64][]balancesEvent{}
+	for _, contract := range contracts {
+		for _, eventName := range []string{"BalancesSubmitted", "BalancesUpdated"} {
+			event, exists := contract.ABI.Events[eventName]
+			if !exists {
+				return nil, fmt.Errorf("network balances ABI does not have a %s event", eventName)
+			}
+
+			addressFilter := []common.Address{*contract.Address}
+			topicFilter := [][]common.Hash{{event.ID}}
+			logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, big.NewInt(0).SetUint64(startBlock), big.NewInt(0).SetUint64(searchEnd), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting %s events: %w", eventName, err)
+			}
+
+			for _, log := range logs {
+				if log.Removed {
+					continue
+				}
+				balancesEvent, err := decodeBalancesEvent(event, log)
+				if err != nil {
+					return nil, err
+				}
+				if balancesEvent.forBlock < startBlock || balancesEvent.forBlock > endBlock {
+					continue
+				}
+				events[balancesEvent.forBlock] = append(events[balancesEvent.forBlock], balancesEvent)
+			}
+		}
+	}
+
+	return events, nil
+
+}
+
+// Decode a balances event; older and newer versions of the contract index different fields, so both the topics and data are read
+func decodeBalancesEvent(event abi.Event, log types.Log) (balancesEvent, error) {
+	values := map[string]interface{}{}
+	err := event.Inputs.UnpackIntoMap(values, log.Data)
+	if err != nil {
+		return balancesEvent{}, fmt.Errorf("error decoding %s event in transaction %s: %w", event.Name, log.TxHash.Hex(), err)
+	}
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(log.Topics) > 0 {
+		err = abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:])
+		if err != nil {
+			return balancesEvent{}, fmt.Errorf("error decoding topics of %s event in transaction %s: %w", event.Name, log.TxHash.Hex(), err)
+		}
+	}
+
+	forBlock, ok := values["block"].(*big.Int)
+	if !ok {
+		return balancesEvent{}, fmt.Errorf("%s event in transaction %s has an invalid block", event.Name, log.TxHash.Hex())
+	}
+	totalEth, ok := values["totalEth"].(*big.Int)
+	if !ok {
+		return balancesEvent{}, fmt.Errorf("%s event in transaction %s has an invalid total ETH balance", event.Name, log.TxHash.Hex())
+	}
+	stakingEth, ok := values["stakingEth"].(*big.Int)
+	if !ok {
+		return balancesEvent{}, fmt.Errorf("%s event in transaction %s has an invalid staking ETH balance", event.Name, log.TxHash.Hex())
+	}
+	rethSupply, ok := values["rethSupply"].(*big.Int)
+	if !ok {
+		return balancesEvent{}, fmt.Errorf("%s event in transaction %s has an invalid rETH supply", event.Name, log.TxHash.Hex())
+	}
+	member, _ := values["from"].(common.Address)
+
+	return balancesEvent{
+		forBlock: forBlock.Uint64(),
+		submission: api.NetworkBalancesSubmission{
+			Member:         member,
+			SubmittedBlock: log.BlockNumber,
+			TxHash:         log.TxHash,
+			TotalEth:       totalEth,
+			StakingEth:     stakingEth,
+			RethSupply:     rethSupply,
+		},
+	}, nil
+}
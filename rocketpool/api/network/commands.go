@@ -1,6 +1,9 @@
 package network
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -137,6 +140,79 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-rewards-tree",
+				Usage:     "Independently regenerate the rewards tree for the given interval and compare it with the published one. Exits with a non-zero code if they differ.",
+				UsageText: "rocketpool api network verify-rewards-tree --index N",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index, i",
+						Usage: "The index of the rewards interval to verify",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("index") {
+						return fmt.Errorf("The --index flag is required")
+					}
+
+					// Run
+					response, err := verifyRewardsTree(c, c.Uint64("index"))
+					api.PrintResponse(response, err)
+					if err != nil || !response.Matches {
+						os.Exit(1)
+					}
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "balances-at",
+				Usage:     "Rebuild the network balances at a historical block, or at every block the Oracle DAO reported balances for in a range, and compare them with the Oracle DAO's submissions",
+				UsageText: "rocketpool api network balances-at [--block N | --start-block N --end-block N] [--minipools]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "block, b",
+						Usage: "The block to rebuild the balances at",
+					},
+					cli.Uint64Flag{
+						Name:  "start-block, s",
+						Usage: "The first block of the range to rebuild reported balances for",
+					},
+					cli.Uint64Flag{
+						Name:  "end-block, e",
+						Usage: "The last block of the range to rebuild reported balances for (defaults to the latest block)",
+					},
+					cli.BoolFlag{
+						Name:  "minipools, m",
+						Usage: "Include the contribution of each minipool in the response",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.IsSet("block") == c.IsSet("start-block") {
+						return fmt.Errorf("Exactly one of the --block and --start-block flags is required")
+					}
+					if c.IsSet("block") && c.Uint64("block") == 0 {
+						return fmt.Errorf("The block must be greater than 0")
+					}
+
+					// Run
+					api.PrintResponse(getBalancesAt(c, c.Uint64("block"), c.Uint64("start-block"), c.Uint64("end-block"), c.Bool("minipools")))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
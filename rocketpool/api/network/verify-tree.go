@@ -0,0 +1,175 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/urfave/cli"
+)
+
+func verifyRewardsTree(c *cli.Context, index uint64) (*api.NetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkVerifyRewardsTreeResponse{
+		Index: index,
+	}
+
+	// Make sure the interval has been finalized
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if currentIndexBig.Uint64() <= index {
+		return nil, fmt.Errorf("The current active rewards period is interval %d. You cannot verify the tree for interval %d until the active interval is past it.", currentIndexBig.Uint64(), index)
+	}
+
+	// Logs go to stderr so they don't interfere with the JSON response
+	logger := log.NewColorLogger(NormalLogger)
+	logPrefix := fmt.Sprintf("[Interval %d Verification]", index)
+
+	// Find the event for this interval
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, index)
+	if err != nil {
+		return nil, fmt.Errorf("error getting event for interval %d: %w", index, err)
+	}
+	logger.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", logPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
+
+	// Get the published files
+	canonicalFile, err := getCanonicalRewardsFile(cfg, index, rewardsEvent.MerkleTreeCID, rewardsEvent.MerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	canonicalPerformance, err := getCanonicalMinipoolPerformanceFile(cfg, index, canonicalFile.MinipoolPerformanceFileCID)
+	if err != nil {
+		return nil, err
+	}
+	if canonicalPerformance == nil {
+		logger.Printlnf("%s Interval %d does not have a minipool performance file, so attestation counts will not be compared.", logPrefix, index)
+	}
+
+	// Get an EC that can serve the state for the snapshot block
+	elBlockHeader, err := ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %s: %w", rewardsEvent.ExecutionBlock.String(), err)
+	}
+	client, err := eth1.GetBestApiClient(rp, cfg, func(message string) {
+		logger.Printlnf("%s %s", logPrefix, message)
+	}, elBlockHeader.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the state for the target slot
+	mgr, err := state.NewNetworkStateManager(client, cfg, client.Client, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := mgr.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	// Regenerate the tree independently
+	treegen, err := rprewards.NewTreeGenerator(logger, logPrefix, client, cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	response.RulesetVersion = treegen.GetGeneratorRulesetVersion()
+	generatedFile, err := treegen.GenerateTree()
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+
+	// Compare the results
+	diff := rprewards.CompareRewardsFiles(canonicalFile, canonicalPerformance, generatedFile)
+	response.Matches = diff.Matches()
+	response.CanonicalMerkleRoot = diff.CanonicalMerkleRoot
+	response.GeneratedMerkleRoot = diff.GeneratedMerkleRoot
+	response.MinipoolPerformanceChecked = diff.MinipoolPerformanceChecked
+	response.NodeDiffs = diff.NodeDiffs
+	response.MinipoolDiffs = diff.MinipoolDiffs
+	if response.Matches {
+		logger.Printlnf("%s The generated tree matches the published tree for interval %d.", logPrefix, index)
+	} else {
+		logger.Printlnf("%s The generated tree does NOT match the published tree for interval %d (%d node differences, %d minipool differences).", logPrefix, index, len(diff.NodeDiffs), len(diff.MinipoolDiffs))
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Gets the published rewards file for an interval, downloading it if a valid copy isn't already on disk
+func getCanonicalRewardsFile(cfg *config.RocketPoolConfig, index uint64, cid string, merkleRoot common.Hash) (*rprewards.RewardsFile, error) {
+
+	path := cfg.Smartnode.GetRewardsTreePath(index, true)
+	_, err := os.Stat(path)
+	if err == nil {
+		rewardsFile, err := rprewards.LoadRewardsFile(path)
+		if err == nil && common.HexToHash(rewardsFile.MerkleRoot) == merkleRoot {
+			return rewardsFile, nil
+		}
+	}
+
+	// Download it since the local copy is missing or invalid; the download is checked against the canonical root
+	_, err = rprewards.DownloadRewardsFile(cfg, index, cid, merkleRoot, true)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading rewards file for interval %d: %w", index, err)
+	}
+	return rprewards.LoadRewardsFile(path)
+
+}
+
+// Gets the published minipool performance file for an interval, or nil if the interval doesn't have one
+func getCanonicalMinipoolPerformanceFile(cfg *config.RocketPoolConfig, index uint64, cid string) (*rprewards.MinipoolPerformanceFile, error) {
+
+	path := cfg.Smartnode.GetMinipoolPerformancePath(index, true)
+	_, err := os.Stat(path)
+	if err == nil {
+		performanceFile, err := rprewards.LoadMinipoolPerformanceFile(path)
+		if err == nil && performanceFile.Index == index {
+			return performanceFile, nil
+		}
+	}
+
+	// Locally generated trees use a placeholder instead of a real CID
+	if cid == "" || cid == "---" {
+		return nil, nil
+	}
+
+	// Download it since the local copy is missing or invalid
+	err = rprewards.DownloadMinipoolPerformanceFile(cfg, index, cid, true)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading minipool performance file for interval %d: %w", index, err)
+	}
+	return rprewards.LoadMinipoolPerformanceFile(path)
+
+}
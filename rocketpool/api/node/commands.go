@@ -766,6 +766,32 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Usage:     "Get a ledger of every rewards claim and distribution paid to the node between two blocks (0 uses the defaults)",
+				UsageText: "rocketpool api node rewards-history start-block end-block",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					startBlock, err := cliutils.ValidateUint("start block", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					endBlock, err := cliutils.ValidateUint("end block", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsHistory(c, startBlock, endBlock))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "deposit-contract-info",
 				Usage:     "Get information about the deposit contract specified by Rocket Pool and the Beacon Chain client",
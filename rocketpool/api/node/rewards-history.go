@@ -0,0 +1,363 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Assets that can appear in the rewards ledger
+const (
+	ledgerAssetEth string = "ETH"
+	ledgerAssetRpl string = "RPL"
+)
+
+// A ledger entry along with the position of the log that produced it, used for ordering
+type ledgerLogEntry struct {
+	api.RewardsLedgerEntry
+	logIndex uint
+}
+
+func getRewardsHistory(c *cli.Context, startBlock uint64, endBlock uint64) (*api.NodeRewardsHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardsHistoryResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.Registered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Registered {
+		return &response, nil
+	}
+
+	// Get the block range to scan
+	if endBlock == 0 {
+		endBlock, err = rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block number: %w", err)
+		}
+	}
+	if startBlock == 0 {
+		startBlock, err = getRewardsHistoryStartBlock(rp, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if startBlock > endBlock {
+		return nil, fmt.Errorf("start block %d is after end block %d", startBlock, endBlock)
+	}
+	response.StartBlock = startBlock
+	response.EndBlock = endBlock
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+	startBlockBig := big.NewInt(0).SetUint64(startBlock)
+	endBlockBig := big.NewInt(0).SetUint64(endBlock)
+
+	// Get the claimed rewards
+	claimEntries, err := getRewardsClaimedEntries(rp, cfg, nodeAccount.Address, intervalSize, startBlockBig, endBlockBig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the fee distributor and minipool distributions
+	distributionEntries, err := getDistributionEntries(rp, nodeAccount.Address, intervalSize, startBlockBig, endBlockBig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort the entries into chain order
+	entries := append(claimEntries, distributionEntries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Block != entries[j].Block {
+			return entries[i].Block < entries[j].Block
+		}
+		return entries[i].logIndex < entries[j].logIndex
+	})
+
+	// Add the block timestamps
+	blockTimes := map[uint64]time.Time{}
+	response.Entries = make([]api.RewardsLedgerEntry, 0, len(entries))
+	for _, entry := range entries {
+		blockTime, exists := blockTimes[entry.Block]
+		if !exists {
+			header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(entry.Block))
+			if err != nil {
+				return nil, fmt.Errorf("error getting header for block %d: %w", entry.Block, err)
+			}
+			blockTime = time.Unix(int64(header.Time), 0).UTC()
+			blockTimes[entry.Block] = blockTime
+		}
+		entry.Timestamp = blockTime
+		response.Entries = append(response.Entries, entry.RewardsLedgerEntry)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the first block of the first rewards interval, since no claims or distributions can happen before it
+func getRewardsHistoryStartBlock(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig) (uint64, error) {
+	currentIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting current rewards index: %w", err)
+	}
+	if currentIndex.Uint64() == 0 {
+		// Nothing has been submitted yet, so fall back to the protocol deployment block
+		deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+		if err != nil {
+			return 0, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+		}
+		return deployBlock.Uint64(), nil
+	}
+
+	event, err := rprewards.GetRewardSnapshotEvent(rp, cfg, 0)
+	if err != nil {
+		return 0, fmt.Errorf("error getting event for the first rewards interval: %w", err)
+	}
+	header, err := rprewards.GetELBlockHeaderForTime(event.IntervalStartTime, rp)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the start block of the first rewards interval: %w", err)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// Get a ledger entry for each asset claimed from each interval by the node
+func getRewardsClaimedEntries(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, intervalSize *big.Int, startBlock *big.Int, endBlock *big.Int) ([]ledgerLogEntry, error) {
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Merkle distributor contract: %w", err)
+	}
+	claimEvent, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return nil, fmt.Errorf("Merkle distributor ABI does not have a RewardsClaimed event")
+	}
+
+	// The claimer is indexed, so only the node's own claims are returned
+	addressFilter := []common.Address{*distributor.Address}
+	topicFilter := [][]common.Hash{{claimEvent.ID}, {common.BytesToHash(nodeAddress.Bytes())}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, startBlock, endBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting RewardsClaimed events: %w", err)
+	}
+
+	entries := []ledgerLogEntry{}
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		values := map[string]interface{}{}
+		err = claimEvent.Inputs.UnpackIntoMap(values, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding RewardsClaimed event in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		indices, ok := values["rewardIndex"].([]*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("RewardsClaimed event in transaction %s has an invalid reward index list", log.TxHash.Hex())
+		}
+		amountsRpl, ok := values["amountRPL"].([]*big.Int)
+		if !ok || len(amountsRpl) != len(indices) {
+			return nil, fmt.Errorf("RewardsClaimed event in transaction %s has an invalid RPL amount list", log.TxHash.Hex())
+		}
+		amountsEth, ok := values["amountETH"].([]*big.Int)
+		if !ok || len(amountsEth) != len(indices) {
+			return nil, fmt.Errorf("RewardsClaimed event in transaction %s has an invalid ETH amount list", log.TxHash.Hex())
+		}
+
+		for i, indexBig := range indices {
+			index := indexBig.Uint64()
+			source := fmt.Sprintf("Rewards interval %d", index)
+
+			// Use the local rewards tree to break the RPL down into staking and Oracle DAO rewards if possible
+			amountRpl := amountsRpl[i]
+			if amountRpl.Sign() > 0 {
+				collateralRpl, oDaoRpl := getClaimedRplBreakdown(cfg, nodeAddress, index, amountRpl)
+				if collateralRpl == nil {
+					entries = append(entries, newLedgerLogEntry(log, ledgerAssetRpl, amountRpl, source, &index))
+				} else {
+					if collateralRpl.Sign() > 0 {
+						entries = append(entries, newLedgerLogEntry(log, ledgerAssetRpl, collateralRpl, source+": RPL staking rewards", &index))
+					}
+					if oDaoRpl.Sign() > 0 {
+						entries = append(entries, newLedgerLogEntry(log, ledgerAssetRpl, oDaoRpl, source+": Oracle DAO rewards", &index))
+					}
+				}
+			}
+
+			// All ETH in the rewards tree comes from the Smoothing Pool
+			amountEth := amountsEth[i]
+			if amountEth.Sign() > 0 {
+				entries = append(entries, newLedgerLogEntry(log, ledgerAssetEth, amountEth, source+": Smoothing Pool", &index))
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// Split a claimed RPL amount into collateral and Oracle DAO rewards using the local rewards tree.
+// Returns nils if the tree isn't available or doesn't match the claimed amount.
+func getClaimedRplBreakdown(cfg *config.RocketPoolConfig, nodeAddress common.Address, interval uint64, claimedRpl *big.Int) (*big.Int, *big.Int) {
+	rewardsFile, err := rprewards.LoadRewardsFile(cfg.Smartnode.GetRewardsTreePath(interval, true))
+	if err != nil {
+		return nil, nil
+	}
+	nodeRewards, exists := rewardsFile.NodeRewards[nodeAddress]
+	if !exists || nodeRewards == nil || nodeRewards.CollateralRpl == nil || nodeRewards.OracleDaoRpl == nil {
+		return nil, nil
+	}
+
+	collateralRpl := big.NewInt(0).Set(&nodeRewards.CollateralRpl.Int)
+	oDaoRpl := big.NewInt(0).Set(&nodeRewards.OracleDaoRpl.Int)
+	total := big.NewInt(0).Add(collateralRpl, oDaoRpl)
+	if total.Cmp(claimedRpl) != 0 {
+		return nil, nil
+	}
+	return collateralRpl, oDaoRpl
+}
+
+// Get a ledger entry for each fee distributor and minipool distribution that paid the node
+func getDistributionEntries(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int, startBlock *big.Int, endBlock *big.Int) ([]ledgerLogEntry, error) {
+	// Get the fee distributor event
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor address: %w", err)
+	}
+	distributorAbi, err := rp.GetABI("rocketNodeDistributorDelegate", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor ABI: %w", err)
+	}
+	feesDistributedEvent, exists := distributorAbi.Events["FeesDistributed"]
+	if !exists {
+		return nil, fmt.Errorf("fee distributor ABI does not have a FeesDistributed event")
+	}
+
+	// Get the minipool event
+	minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool addresses: %w", err)
+	}
+	minipoolAbi, err := rp.GetABI("rocketMinipool", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool ABI: %w", err)
+	}
+	withdrawalEvent, exists := minipoolAbi.Events["EtherWithdrawalProcessed"]
+	if !exists {
+		return nil, fmt.Errorf("minipool ABI does not have an EtherWithdrawalProcessed event")
+	}
+
+	// Get the logs for the distributor and every minipool in one pass
+	addressFilter := append([]common.Address{distributorAddress}, minipoolAddresses...)
+	topicFilter := [][]common.Hash{{feesDistributedEvent.ID, withdrawalEvent.ID}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, startBlock, endBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting distribution events: %w", err)
+	}
+
+	entries := []ledgerLogEntry{}
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) == 0 {
+			continue
+		}
+
+		switch {
+		case log.Address == distributorAddress && log.Topics[0] == feesDistributedEvent.ID:
+			nodeAmount, err := getEventAmount(feesDistributedEvent, log, "_nodeAmount")
+			if err != nil {
+				return nil, err
+			}
+			if nodeAmount.Sign() > 0 {
+				entries = append(entries, newLedgerLogEntry(log, ledgerAssetEth, nodeAmount, "Fee distributor", nil))
+			}
+
+		case log.Topics[0] == withdrawalEvent.ID:
+			nodeAmount, err := getEventAmount(withdrawalEvent, log, "nodeAmount")
+			if err != nil {
+				return nil, err
+			}
+			if nodeAmount.Sign() > 0 {
+				entries = append(entries, newLedgerLogEntry(log, ledgerAssetEth, nodeAmount, fmt.Sprintf("Minipool %s distribution", log.Address.Hex()), nil))
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// Decode an amount field from an event log
+func getEventAmount(event abi.Event, log types.Log, field string) (*big.Int, error) {
+	values := map[string]interface{}{}
+	err := event.Inputs.UnpackIntoMap(values, log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s event in transaction %s: %w", event.Name, log.TxHash.Hex(), err)
+	}
+	amount, ok := values[field].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%s event in transaction %s does not have a valid %s", event.Name, log.TxHash.Hex(), field)
+	}
+	return amount, nil
+}
+
+// Create a ledger entry for an event log; the timestamp is filled in later
+func newLedgerLogEntry(log types.Log, asset string, amount *big.Int, source string, interval *uint64) ledgerLogEntry {
+	return ledgerLogEntry{
+		RewardsLedgerEntry: api.RewardsLedgerEntry{
+			Block:    log.BlockNumber,
+			TxHash:   log.TxHash,
+			Asset:    asset,
+			Amount:   amount,
+			Source:   source,
+			Interval: interval,
+		},
+		logIndex: log.Index,
+	}
+}
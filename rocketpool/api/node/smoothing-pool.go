@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -181,19 +185,19 @@ func setSmoothingPoolStatus(c *cli.Context, status bool) (*api.SetSmoothingPoolR
 			return nil, err
 		}
 
-		// Restart the VC
-		err = validator.RestartValidator(cfg, bc, nil, d)
+		// Apply it to the VC
+		err = applyFeeRecipient(c, cfg, bc, d, *smoothingPoolContract.Address)
 		if err != nil {
 			// Set the fee recipient back to the node distributor
 			err2 := rocketpool.UpdateFeeRecipientFile(distributor, cfg)
 			if err2 != nil {
-				return nil, fmt.Errorf("***WARNING***\nError restarting validator: [%s]\nError setting fee recipient back to your node's distributor: [%w]\nYour node now has the Smoothing Pool as its fee recipient, even though you aren't opted in!\nPlease visit the Rocket Pool Discord server for help with these errors, so it can be set back to your node's distributor.", err.Error(), err2)
+				return nil, fmt.Errorf("***WARNING***\nError updating validator: [%s]\nError setting fee recipient back to your node's distributor: [%w]\nYour node now has the Smoothing Pool as its fee recipient, even though you aren't opted in!\nPlease visit the Rocket Pool Discord server for help with these errors, so it can be set back to your node's distributor.", err.Error(), err2)
 			}
 
-			// Restart the VC but don't pay attention to the errors, since a restart error got us here in the first place
-			validator.RestartValidator(cfg, bc, nil, d)
+			// Apply it to the VC but don't pay attention to the errors, since an update error got us here in the first place
+			applyFeeRecipient(c, cfg, bc, d, distributor)
 
-			return nil, fmt.Errorf("Error restarting validator after updating the fee recipient to the Smoothing Pool: [%w]\nYour fee recipient has been set back to your node's distributor contract.\nYou have not been opted into the Smoothing Pool.", err)
+			return nil, fmt.Errorf("Error updating validator after updating the fee recipient to the Smoothing Pool: [%w]\nYour fee recipient has been set back to your node's distributor contract.\nYou have not been opted into the Smoothing Pool.", err)
 		}
 	}
 
@@ -226,3 +230,16 @@ func GetSmoothingPoolBalance(rp *rocketpoolapi.RocketPool, ec *services.Executio
 
 	return &response, nil
 }
+
+// Make the validator client use a new fee recipient, through its Keymanager API if that's enabled or by restarting it otherwise
+func applyFeeRecipient(c *cli.Context, cfg *config.RocketPoolConfig, bc beacon.Client, d *client.Client, feeRecipient common.Address) error {
+	if cfg.Smartnode.IsKeymanagerApiEnabled() {
+		km, err := services.GetKeymanager(c)
+		if err != nil {
+			return err
+		}
+		_, err = validator.SetValidatorFeeRecipients(km, feeRecipient)
+		return err
+	}
+	return validator.RestartValidator(cfg, bc, nil, d)
+}
@@ -3,13 +3,14 @@ package node
 import (
 	"fmt"
 	"math/big"
+	"os"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/tokens"
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -206,8 +207,13 @@ func waitForApprovalAndSwapFsRpl(c *cli.Context, amountWei *big.Int, hash common
 		return nil, err
 	}
 
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Wait for the fixed-supply RPL approval TX to successfully get included in a block
-	_, err = utils.WaitForTransaction(rp.Client, hash)
+	_, err = wallet.WaitForTransaction(rp.Client, os.ExpandEnv(cfg.Smartnode.GetPendingTransactionsPath()), hash)
 	if err != nil {
 		return nil, err
 	}
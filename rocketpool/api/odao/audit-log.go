@@ -0,0 +1,40 @@
+package odao
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getAuditLog(c *cli.Context, task string, minBlock uint64, limit int) (*api.TNDAOAuditLogResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOAuditLogResponse{}
+	response.Path = os.ExpandEnv(cfg.Smartnode.GetWatchtowerAuditLogPath())
+
+	// Read the records
+	records, malformedLines, err := audit.ReadRecords(response.Path, audit.Filter{
+		Task:     task,
+		MinBlock: minBlock,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Records = records
+	response.MalformedLines = malformedLines
+
+	// Return response
+	return &response, nil
+
+}
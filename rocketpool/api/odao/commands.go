@@ -53,6 +53,38 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit-log",
+				Usage:     "Get the transactions the watchtower has made, along with the evidence they were based on",
+				UsageText: "rocketpool api odao audit-log [--task name] [--min-block number] [--limit count]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "task, t",
+						Usage: "Only show transactions made by this watchtower task",
+					},
+					cli.Uint64Flag{
+						Name:  "min-block, b",
+						Usage: "Only show transactions based on this block or later",
+					},
+					cli.IntFlag{
+						Name:  "limit, l",
+						Usage: "Only show this many of the latest transactions (0 for all of them)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getAuditLog(c, c.String("task"), c.Uint64("min-block"), c.Int("limit")))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "proposals",
 				Aliases:   []string{"p"},
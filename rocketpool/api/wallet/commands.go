@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -172,6 +173,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "release-held-keys",
+				Usage:     "Move validator keys held back for their slashing protection data into the Validator client's keystores, or every held key if none are given",
+				UsageText: "rocketpool api wallet release-held-keys [pubkey...]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					pubkeys := make([]types.ValidatorPubkey, c.NArg())
+					for i, arg := range c.Args() {
+						pubkey, err := cliutils.ValidatePubkey("pubkey", arg)
+						if err != nil {
+							return err
+						}
+						pubkeys[i] = pubkey
+					}
+
+					// Run
+					api.PrintResponse(releaseHeldKeys(c, pubkeys))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"r"},
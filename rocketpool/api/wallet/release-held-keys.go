@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+func releaseHeldKeys(c *cli.Context, pubkeys []types.ValidatorPubkey) (*api.ReleaseHeldKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReleaseHeldKeysResponse{}
+
+	// Move the keys into the keystores
+	response.ValidatorKeys, err = walletutils.ReleaseHeldValidatorKeys(cfg, w, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,110 @@
+package node
+
+import (
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Check scrub minipools task
+type checkScrubMinipools struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+}
+
+// Create check scrub minipools task
+func newCheckScrubMinipools(c *cli.Context, logger log.ColorLogger) (*checkScrubMinipools, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkScrubMinipools{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Run the Oracle DAO's scrub checks against the node's prelaunch minipools and warn about any that would be scrubbed
+func (t *checkScrubMinipools) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get prelaunch minipools
+	prelaunchMinipools := []*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Status == rptypes.Prelaunch {
+			prelaunchMinipools = append(prelaunchMinipools, mpd)
+		}
+	}
+	if len(prelaunchMinipools) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Running scrub checks on %d prelaunch minipool(s)...", len(prelaunchMinipools))
+
+	// Run the checks
+	report, err := scrub.CheckMinipools(t.rp, t.cfg, state, prelaunchMinipools, nil)
+	for _, result := range report.Results {
+		switch result.Verdict {
+		case scrub.Verdict_Scrub:
+			t.log.Println("=== WARNING: MINIPOOL WILL BE SCRUBBED ===")
+			t.log.Printlnf("\tMinipool: %s", result.Minipool.Hex())
+			t.log.Printlnf("\tFailed check: %s", result.Evidence.Check)
+			t.log.Printlnf("\tReason: %s", result.Evidence.Reason)
+			if result.Evidence.ExpectedWithdrawalCredentials != "" {
+				t.log.Printlnf("\tExpected creds: %s", result.Evidence.ExpectedWithdrawalCredentials)
+				t.log.Printlnf("\tActual creds: %s", result.Evidence.ActualWithdrawalCredentials)
+			}
+			if result.Evidence.DepositTxHash != "" {
+				t.log.Printlnf("\tDeposit TX hash: %s", result.Evidence.DepositTxHash)
+			}
+			t.log.Printlnf("\tThe Oracle DAO can scrub it until %s.", result.ScrubPeriodEnd.Format(time.RFC1123))
+			t.log.Println("==========================================")
+		case scrub.Verdict_Pending:
+			t.log.Printlnf("WARNING: No valid deposit has been found for minipool %s yet. It will be scrubbed for safety after %s if none is found by then.", result.Minipool.Hex(), result.SafetyScrubTime.Format(time.RFC1123))
+		case scrub.Verdict_Pass:
+			t.log.Printlnf("Minipool %s passed the scrub check (%s).", result.Minipool.Hex(), result.Evidence.Check)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Return
+	return nil
+
+}
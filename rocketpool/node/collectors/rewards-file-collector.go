@@ -0,0 +1,89 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for rewards file download metrics
+type RewardsFileCollector struct {
+	// The number of rewards files that were downloaded and verified
+	downloadedFiles *prometheus.Desc
+
+	// The number of downloaded rewards files that were rejected, per source
+	rejectedFiles *prometheus.Desc
+
+	// The index of the last interval that had a file rejected
+	lastRejectedInterval *prometheus.Desc
+
+	// Counters updated by the download task
+	downloadCount       float64
+	rejectionCounts     map[string]float64
+	lastRejectedIndex   float64
+	hasRejectedAnyFiles bool
+
+	// Internal fields
+	lock *sync.Mutex
+}
+
+// Create a new RewardsFileCollector instance
+func NewRewardsFileCollector() *RewardsFileCollector {
+	subsystem := "rewards_file"
+	return &RewardsFileCollector{
+		downloadedFiles: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "downloads_total"),
+			"The number of rewards files that were downloaded and passed verification",
+			nil, nil,
+		),
+		rejectedFiles: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rejections_total"),
+			"The number of downloaded rewards files that were rejected because they didn't match the canonical rewards event",
+			[]string{"source"}, nil,
+		),
+		lastRejectedInterval: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_rejected_interval"),
+			"The index of the last rewards interval that had a downloaded file rejected",
+			nil, nil,
+		),
+		rejectionCounts: map[string]float64{},
+		lock:            &sync.Mutex{},
+	}
+}
+
+// Record a successful rewards file download
+func (collector *RewardsFileCollector) AddDownload() {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.downloadCount++
+}
+
+// Record a rejected rewards file from the given source
+func (collector *RewardsFileCollector) AddRejection(interval uint64, source string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.rejectionCounts[source]++
+	collector.lastRejectedIndex = float64(interval)
+	collector.hasRejectedAnyFiles = true
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RewardsFileCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.downloadedFiles
+	channel <- collector.rejectedFiles
+	channel <- collector.lastRejectedInterval
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RewardsFileCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.downloadedFiles, prometheus.CounterValue, collector.downloadCount)
+	for source, count := range collector.rejectionCounts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.rejectedFiles, prometheus.CounterValue, count, source)
+	}
+	if collector.hasRejectedAnyFiles {
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastRejectedInterval, prometheus.GaugeValue, collector.lastRejectedIndex)
+	}
+}
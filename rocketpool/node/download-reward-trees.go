@@ -9,6 +9,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -28,10 +29,11 @@ type downloadRewardsTrees struct {
 	rp  *rocketpool.RocketPool
 	d   *client.Client
 	bc  beacon.Client
+	rfc *collectors.RewardsFileCollector
 }
 
 // Create manage fee recipient task
-func newDownloadRewardsTrees(c *cli.Context, logger log.ColorLogger) (*downloadRewardsTrees, error) {
+func newDownloadRewardsTrees(c *cli.Context, logger log.ColorLogger, rewardsFileCollector *collectors.RewardsFileCollector) (*downloadRewardsTrees, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -64,6 +66,7 @@ func newDownloadRewardsTrees(c *cli.Context, logger log.ColorLogger) (*downloadR
 		rp:  rp,
 		d:   d,
 		bc:  bc,
+		rfc: rewardsFileCollector,
 	}, nil
 
 }
@@ -127,11 +130,16 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 		if err != nil {
 			return fmt.Errorf("error getting interval %d info: %w", missingInterval, err)
 		}
-		err = rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, true)
+		rejections, err := rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, intervalInfo.MerkleRoot, true)
+		for _, rejection := range rejections {
+			d.log.Printlnf("WARNING: rejected the interval %d file from %s: %s", missingInterval, rejection.Source, rejection.Reason)
+			d.rfc.AddRejection(missingInterval, rejection.Source)
+		}
 		if err != nil {
 			fmt.Println()
 			return err
 		}
+		d.rfc.AddDownload()
 		fmt.Println("done!")
 	}
 
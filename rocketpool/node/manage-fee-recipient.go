@@ -110,26 +110,50 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return fmt.Errorf("error validating fee recipient files: %w", err)
 	}
 
+	filesCorrect := fileExists && correctAddress
 	if !fileExists {
 		m.log.Println("Fee recipient files don't all exist, regenerating...")
 	} else if !correctAddress {
 		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
-	} else {
-		// Files are all correct, return.
-		return nil
 	}
 
 	// Regenerate the fee recipient files
-	err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
-	if err != nil {
-		m.log.Println("***ERROR***")
-		m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
-		m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
+	if !filesCorrect {
+		err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
+		if err != nil {
+			m.log.Println("***ERROR***")
+			m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
+			m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
+
+			err = validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
+			if err != nil {
+				return fmt.Errorf("error stopping validator client: %w", err)
+			}
+			return nil
+		}
+	}
 
-		err = validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
+	// With the Keymanager API, set the fee recipient on the running VC instead of restarting it.
+	// This runs even if the files were correct, since the VC keeps per-validator fee recipients that override them.
+	if m.cfg.Smartnode.IsKeymanagerApiEnabled() {
+		km, err := services.GetKeymanager(m.c)
 		if err != nil {
-			return fmt.Errorf("error stopping validator client: %w", err)
+			return err
+		}
+		updated, err := validator.SetValidatorFeeRecipients(km, correctFeeRecipient)
+		if err == nil {
+			if updated > 0 {
+				m.log.Printlnf("Set the fee recipient of %d validators to %s.", updated, correctFeeRecipient.Hex())
+			}
+			return nil
+		}
+		if filesCorrect {
+			return fmt.Errorf("error setting fee recipient through the Keymanager API: %w", err)
 		}
+		m.log.Printlnf("WARNING: Couldn't set the fee recipient through the Keymanager API: %s", err.Error())
+	}
+	if filesCorrect {
+		// Files are all correct, return.
 		return nil
 	}
 
@@ -16,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
@@ -26,12 +27,14 @@ import (
 
 // Config
 var tasksInterval, _ = time.ParseDuration("5m")
-var taskCooldown, _ = time.ParseDuration("10s")
+var taskTimeout, _ = time.ParseDuration("15m")
+var pendingTxCheckInterval, _ = time.ParseDuration("1m")
 
 const (
 	MaxConcurrentEth1Requests = 200
 
 	StakePrelaunchMinipoolsColor = color.FgBlue
+	CheckScrubMinipoolsColor     = color.FgHiMagenta
 	DownloadRewardsTreesColor    = color.FgGreen
 	MetricsColor                 = color.FgHiYellow
 	ManageFeeRecipientColor      = color.FgHiCyan
@@ -41,6 +44,7 @@ const (
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
+	PendingTransactionsColor     = color.FgWhite
 )
 
 // Register node command
@@ -111,6 +115,8 @@ func run(c *cli.Context) error {
 		return err
 	}
 	stateLocker := collectors.NewStateLocker()
+	eventTrigger := services.NewBeaconEventTrigger(bc, &updateLog)
+	rewardsFileCollector := collectors.NewRewardsFileCollector()
 
 	// Initialize tasks
 	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
@@ -125,11 +131,15 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	checkScrubMinipools, err := newCheckScrubMinipools(c, log.NewColorLogger(CheckScrubMinipoolsColor))
+	if err != nil {
+		return err
+	}
 	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor))
 	if err != nil {
 		return err
 	}
-	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewColorLogger(DownloadRewardsTreesColor))
+	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewColorLogger(DownloadRewardsTreesColor), rewardsFileCollector)
 	if err != nil {
 		return err
 	}
@@ -138,88 +148,152 @@ func run(c *cli.Context) error {
 		return err
 	}
 
-	// Wait group to handle the various threads
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	txManager := w.GetTxManager()
+	pendingTxLog := log.NewColorLogger(PendingTransactionsColor)
 
-	// Run task loop
+	// Create the scheduler; each cycle checks the clients before running any tasks
 	isAtlasDeployedMasterFlag := false
-	go func() {
-		for {
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Check the BC status
-			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Update the network state
-			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address)
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-			stateLocker.UpdateState(state, totalEffectiveStake)
-
-			// Check for Atlas
-			if !isAtlasDeployedMasterFlag && state.IsAtlasDeployed {
-				printAtlasMessage(&updateLog)
-				isAtlasDeployedMasterFlag = true
-			}
-
-			// Manage the fee recipient for the node
-			if err := manageFeeRecipient.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the rewards download check
-			if err := downloadRewardsTrees.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	prepare := func() (interface{}, error) {
+		// Check the EC status
+		err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+		if err != nil {
+			return nil, err
+		}
 
-			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+		// Check the BC status
+		err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	getState := func(data interface{}) (*state.NetworkState, error) {
+		// Update the network state
+		state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+		stateLocker.UpdateState(state, totalEffectiveStake)
 
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+		// Check for Atlas
+		if !isAtlasDeployedMasterFlag && state.IsAtlasDeployed {
+			printAtlasMessage(&updateLog)
+			isAtlasDeployedMasterFlag = true
+		}
+		return state, nil
+	}
+	taskScheduler := scheduler.NewScheduler(bc, eventTrigger, prepare, getState, &updateLog, &errorLog)
+
+	// Register the tasks
+	tasks := []scheduler.Task{
+		{
+			Name:       "manage-fee-recipient",
+			Cadence:    scheduler.Cadence_Interval,
+			Interval:   tasksInterval,
+			Timeout:    taskTimeout,
+			NeedsState: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return manageFeeRecipient.run(cycle.State)
+			},
+		},
+		{
+			Name:       "download-rewards-trees",
+			Cadence:    scheduler.Cadence_Interval,
+			Interval:   tasksInterval,
+			Timeout:    taskTimeout,
+			NeedsState: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return downloadRewardsTrees.run(cycle.State)
+			},
+		},
+		{
+			Name:       "check-scrub-minipools",
+			Cadence:    scheduler.Cadence_Interval,
+			Interval:   tasksInterval,
+			Timeout:    taskTimeout,
+			NeedsState: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return checkScrubMinipools.run(cycle.State)
+			},
+		},
+		{
+			Name:              "stake-prelaunch-minipools",
+			Cadence:           scheduler.Cadence_Interval,
+			Interval:          tasksInterval,
+			Timeout:           taskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return stakePrelaunchMinipools.run(cycle.State)
+			},
+		},
+		{
+			Name:              "distribute-minipools",
+			Cadence:           scheduler.Cadence_Interval,
+			Interval:          tasksInterval,
+			Timeout:           taskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return distributeMinipools.run(cycle.State)
+			},
+		},
+		{
+			// Balances are distributed before bonds are reduced
+			Name:              "reduce-bonds",
+			Cadence:           scheduler.Cadence_Interval,
+			Interval:          tasksInterval,
+			DependsOn:         []string{"distribute-minipools"},
+			Timeout:           taskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return reduceBonds.run(cycle.State)
+			},
+		},
+		{
+			// This also covers the transactions sent by the API and the watchtower, since they share the pending transaction file.
+			// It only replaces transactions that were already sent, so it doesn't take the transaction lock; the tasks waiting on them need it to finish.
+			Name:     "check-pending-transactions",
+			Cadence:  scheduler.Cadence_Interval,
+			Interval: pendingTxCheckInterval,
+			Timeout:  taskTimeout,
+			Run: func(cycle *scheduler.Cycle) error {
+				return txManager.CheckPendingTransactions(&pendingTxLog)
+			},
+		},
+		{
+			Name:              "promote-minipools",
+			Cadence:           scheduler.Cadence_Interval,
+			Interval:          tasksInterval,
+			Timeout:           taskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				return promoteMinipools.run(cycle.State)
+			},
+		},
+	}
+	for _, task := range tasks {
+		err = taskScheduler.AddTask(task)
+		if err != nil {
+			return fmt.Errorf("error adding task %s: %w", task.Name, err)
+		}
+	}
 
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
+	// Wait group to handle the various threads
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
 
-			time.Sleep(tasksInterval)
-		}
+	// Run task loop
+	go func() {
+		taskScheduler.Run()
 		wg.Done()
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, rewardsFileCollector, taskScheduler.GetCollector())
 		if err != nil {
 			errorLog.Println(err)
 		}
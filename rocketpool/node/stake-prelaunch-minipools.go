@@ -153,8 +153,8 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
+	// Restart validator process if any minipools were staked successfully; with the Keymanager API, their keys were already loaded when they were created
+	if successCount > 0 && !t.cfg.Smartnode.IsKeymanagerApiEnabled() {
 		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
 			return err
 		}
@@ -0,0 +1,20 @@
+package watchtower
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record a submitted transaction in the audit log.
+// The receipt from waiting for the transaction is used for its hash, since the transaction manager may have replaced the one that was sent.
+// A failure to write the record is logged instead of failing the task, since the transaction has already been sent.
+func writeAuditRecord(logger log.ColorLogger, auditLog *audit.Log, client rocketpool.ExecutionClient, record audit.Record, inputs interface{}, hash common.Hash, receipt *types.Receipt, txErr error) {
+	err := auditLog.RecordTransaction(client, record, inputs, hash, receipt, txErr)
+	if err != nil {
+		logger.Printlnf("WARNING: Couldn't add transaction %s to the audit log: %s", hash.Hex(), err.Error())
+	}
+}
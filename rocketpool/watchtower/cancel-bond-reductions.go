@@ -3,7 +3,6 @@ package watchtower
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
@@ -11,6 +10,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
@@ -32,13 +32,13 @@ type cancelBondReductions struct {
 	w                *wallet.Wallet
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
-	lock             *sync.Mutex
-	isRunning        bool
+	shadow           *shadowRecorder
+	auditLog         *audit.Log
 	generationPrefix string
 }
 
 // Create cancel bond reductions task
-func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*cancelBondReductions, error) {
+func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, shadow *shadowRecorder) (*cancelBondReductions, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -57,9 +57,12 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &cancelBondReductions{
 		c:                c,
 		log:              logger,
@@ -68,8 +71,8 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 		w:                w,
 		rp:               rp,
 		ec:               ec,
-		lock:             lock,
-		isRunning:        false,
+		shadow:           shadow,
+		auditLog:         auditLog,
 		generationPrefix: "[Bond Reduction]",
 	}, nil
 
@@ -94,32 +97,10 @@ func (t *cancelBondReductions) run(state *state.NetworkState, isAtlasDeployed bo
 	// Log
 	t.log.Println("Checking for bond reductions to cancel...")
 
-	// Check if the check is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Bond reduction cancel check is already running in the background.")
-		t.lock.Unlock()
-		return nil
-	}
-	t.lock.Unlock()
-
 	// Run the check
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		t.printMessage("Starting bond reduction cancel check in a separate thread.")
-
-		err := t.checkBondReductions(state)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", t.generationPrefix, err))
-			return
-		}
-
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
+	if err := t.checkBondReductions(state); err != nil {
+		return fmt.Errorf("%s %w", t.generationPrefix, err)
+	}
 
 	// Return
 	return nil
@@ -161,7 +142,7 @@ func (t *cancelBondReductions) checkBondReductions(state *state.NetworkState) er
 				// Check the balance
 				if validator.Balance < threshold {
 					// Cancel because it's under-balance
-					t.cancelBondReduction(mpd.MinipoolAddress, fmt.Sprintf("minipool balance is %d (below the threshold)", validator.Balance))
+					t.cancelBondReduction(state, mpd.MinipoolAddress, fmt.Sprintf("minipool balance is %d (below the threshold)", validator.Balance))
 				}
 
 			case beacon.ValidatorState_ActiveExiting,
@@ -170,7 +151,7 @@ func (t *cancelBondReductions) checkBondReductions(state *state.NetworkState) er
 				beacon.ValidatorState_ExitedSlashed,
 				beacon.ValidatorState_WithdrawalPossible,
 				beacon.ValidatorState_WithdrawalDone:
-				t.cancelBondReduction(mpd.MinipoolAddress, "minipool is already slashed, exiting, or exited")
+				t.cancelBondReduction(state, mpd.MinipoolAddress, "minipool is already slashed, exiting, or exited")
 
 			default:
 				return fmt.Errorf("unknown validator state: %v", validator.Status)
@@ -183,7 +164,7 @@ func (t *cancelBondReductions) checkBondReductions(state *state.NetworkState) er
 }
 
 // Cancel a bond reduction
-func (t *cancelBondReductions) cancelBondReduction(address common.Address, reason string) error {
+func (t *cancelBondReductions) cancelBondReduction(state *state.NetworkState, address common.Address, reason string) error {
 
 	// Log
 	t.printMessage("=== CANCELLING BOND REDUCTION ===")
@@ -191,6 +172,11 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	t.printMessage(fmt.Sprintf("Reason:   %s", reason))
 	t.printMessage("=================================")
 
+	// In shadow mode, record the vote instead of making it
+	if t.shadow.isActive() {
+		return t.proposeCancelBondReduction(address, reason)
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -221,7 +207,15 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "cancel-bond-reductions",
+		Action: "voteCancelReduction",
+		Target: address.Hex(),
+		Slot:   state.BeaconSlotNumber,
+		Block:  state.ElBlockNumber,
+		Reason: reason,
+	}, nil, hash, receipt, err)
 	if err != nil {
 		return err
 	}
@@ -234,15 +228,41 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 
 }
 
-func (t *cancelBondReductions) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Bond reduction cancel check failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
+// Record the cancellation vote that would have been made in shadow mode
+func (t *cancelBondReductions) proposeCancelBondReduction(address common.Address, reason string) error {
+	// Get the time of the reduction request, so it can be told apart from later ones
+	reduceBondTime, err := minipool.GetReduceBondTime(t.rp, address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting bond reduction time for minipool %s: %w", address.Hex(), err)
+	}
+
+	t.shadow.propose(shadowProposal{
+		Task:        "cancel-bond-reductions",
+		Key:         fmt.Sprintf("cancel-reduction-%s-%d", address.Hex(), reduceBondTime.Unix()),
+		Description: fmt.Sprintf("voteCancelReduction(minipool %s) because %s", address.Hex(), reason),
+		CheckOutcome: func() (shadowOutcome, error) {
+			// Cancellation votes aren't readable per member, so this checks whether the reduction was cancelled or went through
+			cancelled, err := minipool.GetReduceBondCancelled(t.rp, address, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if cancelled {
+				return shadowOutcome_Match, nil
+			}
+			lastReductionTime, err := minipool.GetLastBondReductionTime(t.rp, address, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if !lastReductionTime.Before(reduceBondTime) {
+				return shadowOutcome_Mismatch, nil
+			}
+			return shadowOutcome_Pending, nil
+		},
+	})
+	return nil
 }
 
-// Print a message from the tree generation goroutine
+// Print a message with the task's log prefix
 func (t *cancelBondReductions) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.generationPrefix, message)
 }
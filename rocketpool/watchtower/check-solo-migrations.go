@@ -3,7 +3,6 @@ package watchtower
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
@@ -37,13 +37,13 @@ type checkSoloMigrations struct {
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
-	lock             *sync.Mutex
-	isRunning        bool
+	shadow           *shadowRecorder
+	auditLog         *audit.Log
 	generationPrefix string
 }
 
 // Create check solo migrations task
-func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*checkSoloMigrations, error) {
+func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, shadow *shadowRecorder) (*checkSoloMigrations, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,9 +66,12 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &checkSoloMigrations{
 		c:                c,
 		log:              logger,
@@ -78,8 +81,8 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 		rp:               rp,
 		ec:               ec,
 		bc:               bc,
-		lock:             lock,
-		isRunning:        false,
+		shadow:           shadow,
+		auditLog:         auditLog,
 		generationPrefix: "[Solo Migration]",
 	}, nil
 
@@ -104,32 +107,10 @@ func (t *checkSoloMigrations) run(state *state.NetworkState, isAtlasDeployed boo
 	// Log
 	t.log.Println("Checking for solo migrations...")
 
-	// Check if the check is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Solo migration check is already running in the background.")
-		t.lock.Unlock()
-		return nil
-	}
-	t.lock.Unlock()
-
 	// Run the check
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		t.printMessage("Starting solo migration check in a separate thread.")
-
-		err := t.checkSoloMigrations(state)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", t.generationPrefix, err))
-			return
-		}
-
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
+	if err := t.checkSoloMigrations(state); err != nil {
+		return fmt.Errorf("%s %w", t.generationPrefix, err)
+	}
 
 	// Return
 	return nil
@@ -164,12 +145,12 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 		// Scrub minipools that aren't seen on Beacon yet
 		validator := state.ValidatorDetails[mpd.Pubkey]
 		if !validator.Exists {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) did not exist on Beacon yet, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex()))
+			t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) did not exist on Beacon yet, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex()))
 		}
 
 		// Scrub minipools that are in the wrong state
 		if validator.Status != beacon.ValidatorState_ActiveOngoing {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) was in state %v, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex(), validator.Status))
+			t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) was in state %v, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex(), validator.Status))
 			continue
 		}
 
@@ -180,17 +161,17 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 			creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
 			remainingTime := creationTime.Add(scrubThreshold).Sub(blockTime)
 			if remainingTime < 0 {
-				t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool timed out (created %s, current time %s, scrubbed after %s)", creationTime, blockTime, scrubThreshold))
+				t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("minipool timed out (created %s, current time %s, scrubbed after %s)", creationTime, blockTime, scrubThreshold))
 				continue
 			}
 			continue
 		case elPrefix:
 			if withdrawalCreds != mpd.WithdrawalCredentials {
-				t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("withdrawal credentials do not match (expected %s, actual %s)", mpd.WithdrawalCredentials.Hex(), withdrawalCreds.Hex()))
+				t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("withdrawal credentials do not match (expected %s, actual %s)", mpd.WithdrawalCredentials.Hex(), withdrawalCreds.Hex()))
 				continue
 			}
 		default:
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("unexpected prefix in withdrawal credentials: %s", withdrawalCreds.Hex()))
+			t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("unexpected prefix in withdrawal credentials: %s", withdrawalCreds.Hex()))
 			continue
 		}
 
@@ -203,11 +184,11 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 		currentBalance += minipoolBalanceGwei
 
 		if currentBalance < threshold {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the threshold of %d", currentBalance, threshold))
+			t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the threshold of %d", currentBalance, threshold))
 			continue
 		}
 		if currentBalance < (creationBalanceGwei - buffer) {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the creation balance of %d, and below the acceptable buffer threshold of %d", currentBalance, creationBalanceGwei, buffer))
+			t.scrubVacantMinipool(state, mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the creation balance of %d, and below the acceptable buffer threshold of %d", currentBalance, creationBalanceGwei, buffer))
 			continue
 		}
 
@@ -218,7 +199,7 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 }
 
 // Scrub a vacant minipool
-func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason string) error {
+func (t *checkSoloMigrations) scrubVacantMinipool(state *state.NetworkState, address common.Address, reason string) error {
 
 	// Log
 	t.printMessage("=== SCRUBBING SOLO MIGRATION ===")
@@ -232,6 +213,12 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 		return fmt.Errorf("error scrubbing migration of minipool %s: %w", address.Hex(), err)
 	}
 
+	// In shadow mode, record the vote instead of making it
+	if t.shadow.isActive() {
+		t.proposeScrubVacantMinipool(mp, reason)
+		return nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -262,7 +249,15 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "check-solo-migrations",
+		Action: "voteScrub",
+		Target: address.Hex(),
+		Slot:   state.BeaconSlotNumber,
+		Block:  state.ElBlockNumber,
+		Reason: reason,
+	}, nil, hash, receipt, err)
 	if err != nil {
 		return err
 	}
@@ -275,15 +270,31 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 
 }
 
-func (t *checkSoloMigrations) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Solo migration check failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
+// Record the scrub vote that would have been made in shadow mode
+func (t *checkSoloMigrations) proposeScrubVacantMinipool(mp minipool.Minipool, reason string) {
+	address := mp.GetAddress()
+	t.shadow.propose(shadowProposal{
+		Task:        "check-solo-migrations",
+		Key:         fmt.Sprintf("solo-migration-%s", address.Hex()),
+		Description: fmt.Sprintf("voteScrub(minipool %s) because %s", address.Hex(), reason),
+		CheckOutcome: func() (shadowOutcome, error) {
+			// Scrub votes aren't readable per member, so this checks whether the migration was scrubbed or promoted
+			details, err := mp.GetStatusDetails(nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if details.Status == types.Dissolved {
+				return shadowOutcome_Match, nil
+			}
+			if details.IsVacant {
+				return shadowOutcome_Pending, nil
+			}
+			return shadowOutcome_Mismatch, nil
+		},
+	})
 }
 
-// Print a message from the tree generation goroutine
+// Print a message with the task's log prefix
 func (t *checkSoloMigrations) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.generationPrefix, message)
 }
@@ -0,0 +1,156 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The latest state of a single L2 price messenger
+type l2MessengerMetrics struct {
+	rateStale       float64
+	isTurn          float64
+	lastCheckTime   float64
+	lastSuccessTime float64
+	submissions     float64
+	failures        float64
+}
+
+// Represents the collector for the L2 price messenger metrics
+type L2PriceCollector struct {
+
+	// Whether the messenger's rate was stale in the latest check
+	rateStaleDesc *prometheus.Desc
+
+	// Whether it was this node's turn to submit the rate in the latest check
+	isTurnDesc *prometheus.Desc
+
+	// The time of the latest check
+	lastCheckTimeDesc *prometheus.Desc
+
+	// The time of the latest successful rate submission
+	lastSuccessTimeDesc *prometheus.Desc
+
+	// The number of successful rate submissions
+	submissionsDesc *prometheus.Desc
+
+	// The number of failed rate submission attempts
+	failuresDesc *prometheus.Desc
+
+	// The metrics for each messenger
+	messengers map[string]*l2MessengerMetrics
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new L2PriceCollector instance
+func NewL2PriceCollector() *L2PriceCollector {
+	subsystem := "l2_price"
+	labels := []string{"network"}
+	return &L2PriceCollector{
+		rateStaleDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rate_stale"),
+			"Whether the messenger's rate was stale (1) or not (0) in the latest check",
+			labels, nil,
+		),
+		isTurnDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "is_turn"),
+			"Whether it was this node's turn (1) or not (0) to submit the rate in the latest check",
+			labels, nil,
+		),
+		lastCheckTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_check_time"),
+			"The time of the latest check of the messenger",
+			labels, nil,
+		),
+		lastSuccessTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_success_time"),
+			"The time of the latest successful rate submission to the messenger",
+			labels, nil,
+		),
+		submissionsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submissions_total"),
+			"The number of successful rate submissions to the messenger",
+			labels, nil,
+		),
+		failuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of failed rate submission attempts to the messenger",
+			labels, nil,
+		),
+		messengers: map[string]*l2MessengerMetrics{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *L2PriceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.rateStaleDesc
+	channel <- collector.isTurnDesc
+	channel <- collector.lastCheckTimeDesc
+	channel <- collector.lastSuccessTimeDesc
+	channel <- collector.submissionsDesc
+	channel <- collector.failuresDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *L2PriceCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Update all of the metrics
+	for name, metrics := range collector.messengers {
+		channel <- prometheus.MustNewConstMetric(
+			collector.rateStaleDesc, prometheus.GaugeValue, metrics.rateStale, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.isTurnDesc, prometheus.GaugeValue, metrics.isTurn, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastCheckTimeDesc, prometheus.GaugeValue, metrics.lastCheckTime, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastSuccessTimeDesc, prometheus.GaugeValue, metrics.lastSuccessTime, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.submissionsDesc, prometheus.CounterValue, metrics.submissions, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failuresDesc, prometheus.CounterValue, metrics.failures, name)
+	}
+
+}
+
+// Record the result of checking a messenger's rate
+func (collector *L2PriceCollector) RecordCheck(network string, rateStale bool, isTurn bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	metrics := collector.getMessenger(network)
+	metrics.rateStale = boolToFloat(rateStale)
+	metrics.isTurn = boolToFloat(isTurn)
+	metrics.lastCheckTime = float64(time.Now().Unix())
+}
+
+// Record the result of a rate submission attempt
+func (collector *L2PriceCollector) RecordSubmission(network string, succeeded bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	metrics := collector.getMessenger(network)
+	if succeeded {
+		metrics.submissions++
+		metrics.lastSuccessTime = float64(time.Now().Unix())
+		metrics.rateStale = 0
+	} else {
+		metrics.failures++
+	}
+}
+
+// Get the metrics for a messenger, creating them if they don't exist yet; the lock must be held
+func (collector *L2PriceCollector) getMessenger(network string) *l2MessengerMetrics {
+	metrics, exists := collector.messengers[network]
+	if !exists {
+		metrics = &l2MessengerMetrics{}
+		collector.messengers[network] = metrics
+	}
+	return metrics
+}
+
+// Convert a flag to a metric value
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,124 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the watchtower lease metrics
+type LeaseCollector struct {
+
+	// Whether this instance is the leader
+	isLeaderDesc *prometheus.Desc
+
+	// The time the current lease expires
+	expiryTimeDesc *prometheus.Desc
+
+	// The time of the latest successful lease refresh
+	lastRefreshTimeDesc *prometheus.Desc
+
+	// The number of times this instance became or stopped being the leader
+	leaderChangesDesc *prometheus.Desc
+
+	// The number of failed lease refreshes
+	refreshFailuresDesc *prometheus.Desc
+
+	// The latest values
+	instanceID      string
+	holder          string
+	isLeader        float64
+	expiryTime      float64
+	lastRefreshTime float64
+	leaderChanges   float64
+	refreshFailures float64
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new LeaseCollector instance
+func NewLeaseCollector(instanceID string) *LeaseCollector {
+	subsystem := "watchtower_lease"
+	return &LeaseCollector{
+		isLeaderDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "is_leader"),
+			"Whether this watchtower instance is the leader (1) or a standby (0)",
+			[]string{"instance_id", "holder"}, nil,
+		),
+		expiryTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "expiry_time"),
+			"The time the current holder's lease expires",
+			nil, nil,
+		),
+		lastRefreshTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_refresh_time"),
+			"The time this instance last read or renewed the lease successfully",
+			nil, nil,
+		),
+		leaderChangesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "leader_changes_total"),
+			"The number of times this instance became or stopped being the leader",
+			nil, nil,
+		),
+		refreshFailuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "refresh_failures_total"),
+			"The number of times this instance failed to read or renew the lease",
+			nil, nil,
+		),
+		instanceID: instanceID,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *LeaseCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.isLeaderDesc
+	channel <- collector.expiryTimeDesc
+	channel <- collector.lastRefreshTimeDesc
+	channel <- collector.leaderChangesDesc
+	channel <- collector.refreshFailuresDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *LeaseCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.isLeaderDesc, prometheus.GaugeValue, collector.isLeader, collector.instanceID, collector.holder)
+	channel <- prometheus.MustNewConstMetric(
+		collector.expiryTimeDesc, prometheus.GaugeValue, collector.expiryTime)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastRefreshTimeDesc, prometheus.GaugeValue, collector.lastRefreshTime)
+	channel <- prometheus.MustNewConstMetric(
+		collector.leaderChangesDesc, prometheus.CounterValue, collector.leaderChanges)
+	channel <- prometheus.MustNewConstMetric(
+		collector.refreshFailuresDesc, prometheus.CounterValue, collector.refreshFailures)
+
+}
+
+// Record a successful lease refresh
+func (collector *LeaseCollector) RecordRefresh(holder string, expiresAt time.Time, isLeader bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.holder = holder
+	collector.expiryTime = float64(expiresAt.Unix())
+	collector.lastRefreshTime = float64(time.Now().Unix())
+	collector.setLeader(isLeader)
+}
+
+// Record a failed lease refresh, along with whether this instance can still act as the leader
+func (collector *LeaseCollector) RecordRefreshFailure(isLeader bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.refreshFailures++
+	collector.setLeader(isLeader)
+}
+
+// Update the leader flag, counting the change if there is one; the lock must be held
+func (collector *LeaseCollector) setLeader(isLeader bool) {
+	value := boolToFloat(isLeader)
+	if value != collector.isLeader {
+		collector.leaderChanges++
+	}
+	collector.isLeader = value
+}
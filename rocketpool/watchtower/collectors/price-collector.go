@@ -0,0 +1,176 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The latest reading from a single RPL price source
+type priceSourceMetrics struct {
+	price     float64
+	deviation float64
+	errors    float64
+}
+
+// Represents the collector for the RPL price source metrics
+type PriceCollector struct {
+
+	// The RPL price in ETH reported by each source in the latest check
+	sourcePriceDesc *prometheus.Desc
+
+	// How far each source was from the others in the latest check, in percent
+	sourceDeviationDesc *prometheus.Desc
+
+	// The number of times each source failed to provide a price
+	sourceErrorsDesc *prometheus.Desc
+
+	// The median RPL price of all of the sources in the latest check
+	medianPriceDesc *prometheus.Desc
+
+	// How far the median price was from the previous on-chain price in the latest check, in percent
+	priceChangeDesc *prometheus.Desc
+
+	// Whether the latest check stopped the price submission
+	guardTrippedDesc *prometheus.Desc
+
+	// The number of checks that stopped a price submission
+	guardTripsDesc *prometheus.Desc
+
+	// The time of the latest check
+	lastCheckTimeDesc *prometheus.Desc
+
+	// The latest values
+	sources      map[string]*priceSourceMetrics
+	medianPrice  float64
+	priceChange  float64
+	guardTripped float64
+	guardTrips   float64
+	lastCheck    float64
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new PriceCollector instance
+func NewPriceCollector() *PriceCollector {
+	subsystem := "rpl_price"
+	return &PriceCollector{
+		sourcePriceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "source_price"),
+			"The RPL price in ETH reported by the source in the latest check",
+			[]string{"source"}, nil,
+		),
+		sourceDeviationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "source_deviation_percent"),
+			"How far the source was from the other source, or from the median of all of the sources if there are more than two, in the latest check, in percent",
+			[]string{"source"}, nil,
+		),
+		sourceErrorsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "source_errors_total"),
+			"The number of times the source failed to provide a price",
+			[]string{"source"}, nil,
+		),
+		medianPriceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "median_price"),
+			"The median RPL price in ETH of all of the sources in the latest check",
+			nil, nil,
+		),
+		priceChangeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "change_percent"),
+			"How far the median price was from the previous on-chain price in the latest check, in percent",
+			nil, nil,
+		),
+		guardTrippedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "guard_tripped"),
+			"Whether the latest check stopped the price submission (1) or not (0)",
+			nil, nil,
+		),
+		guardTripsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "guard_trips_total"),
+			"The number of checks that stopped a price submission",
+			nil, nil,
+		),
+		lastCheckTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_check_time"),
+			"The time of the latest price check",
+			nil, nil,
+		),
+		sources: map[string]*priceSourceMetrics{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *PriceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.sourcePriceDesc
+	channel <- collector.sourceDeviationDesc
+	channel <- collector.sourceErrorsDesc
+	channel <- collector.medianPriceDesc
+	channel <- collector.priceChangeDesc
+	channel <- collector.guardTrippedDesc
+	channel <- collector.guardTripsDesc
+	channel <- collector.lastCheckTimeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *PriceCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Update all of the metrics
+	for name, metrics := range collector.sources {
+		channel <- prometheus.MustNewConstMetric(
+			collector.sourcePriceDesc, prometheus.GaugeValue, metrics.price, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.sourceDeviationDesc, prometheus.GaugeValue, metrics.deviation, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.sourceErrorsDesc, prometheus.CounterValue, metrics.errors, name)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.medianPriceDesc, prometheus.GaugeValue, collector.medianPrice)
+	channel <- prometheus.MustNewConstMetric(
+		collector.priceChangeDesc, prometheus.GaugeValue, collector.priceChange)
+	channel <- prometheus.MustNewConstMetric(
+		collector.guardTrippedDesc, prometheus.GaugeValue, collector.guardTripped)
+	channel <- prometheus.MustNewConstMetric(
+		collector.guardTripsDesc, prometheus.CounterValue, collector.guardTrips)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastCheckTimeDesc, prometheus.GaugeValue, collector.lastCheck)
+
+}
+
+// Record the price a source reported and how far it was from the others
+func (collector *PriceCollector) RecordSourcePrice(source string, price float64, deviation float64) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	metrics := collector.getSource(source)
+	metrics.price = price
+	metrics.deviation = deviation
+}
+
+// Record a source failing to provide a price
+func (collector *PriceCollector) RecordSourceError(source string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.getSource(source).errors++
+}
+
+// Record the result of a price check
+func (collector *PriceCollector) RecordCheck(medianPrice float64, priceChange float64, tripped bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.medianPrice = medianPrice
+	collector.priceChange = priceChange
+	collector.lastCheck = float64(time.Now().Unix())
+	if tripped {
+		collector.guardTripped = 1
+		collector.guardTrips++
+	} else {
+		collector.guardTripped = 0
+	}
+}
+
+// Get the metrics for a source, creating them if they don't exist yet; the lock must be held
+func (collector *PriceCollector) getSource(source string) *priceSourceMetrics {
+	metrics, exists := collector.sources[source]
+	if !exists {
+		metrics = &priceSourceMetrics{}
+		collector.sources[source] = metrics
+	}
+	return metrics
+}
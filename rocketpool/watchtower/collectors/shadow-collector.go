@@ -0,0 +1,193 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The shadow mode results for a single task
+type shadowTaskMetrics struct {
+	proposals         float64
+	pendingProposals  float64
+	memberMatches     float64
+	memberMismatches  float64
+	outcomeMatches    float64
+	outcomeMismatches float64
+	expiredProposals  float64
+	lastMismatchTime  float64
+}
+
+// Represents the collector for the watchtower's shadow mode metrics
+type ShadowCollector struct {
+
+	// The number of transactions the task would have submitted
+	proposalsDesc *prometheus.Desc
+
+	// The number of proposals still waiting for the Oracle DAO to reach a result
+	pendingProposalsDesc *prometheus.Desc
+
+	// The number of member submissions that matched a proposal
+	memberMatchesDesc *prometheus.Desc
+
+	// The number of member submissions that didn't match a proposal
+	memberMismatchesDesc *prometheus.Desc
+
+	// The number of proposals that matched the Oracle DAO's final result
+	outcomeMatchesDesc *prometheus.Desc
+
+	// The number of proposals that didn't match the Oracle DAO's final result
+	outcomeMismatchesDesc *prometheus.Desc
+
+	// The number of proposals the Oracle DAO never reached a result for
+	expiredProposalsDesc *prometheus.Desc
+
+	// The time of the latest mismatch
+	lastMismatchTimeDesc *prometheus.Desc
+
+	// The metrics for each task
+	tasks map[string]*shadowTaskMetrics
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new ShadowCollector instance
+func NewShadowCollector() *ShadowCollector {
+	subsystem := "shadow"
+	labels := []string{"task"}
+	return &ShadowCollector{
+		proposalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposals_total"),
+			"The number of transactions the task would have submitted",
+			labels, nil,
+		),
+		pendingProposalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "pending_proposals"),
+			"The number of proposals still waiting for the Oracle DAO to reach a result",
+			labels, nil,
+		),
+		memberMatchesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "member_matches_total"),
+			"The number of Oracle DAO member submissions that matched a proposal",
+			labels, nil,
+		),
+		memberMismatchesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "member_mismatches_total"),
+			"The number of Oracle DAO member submissions that didn't match a proposal",
+			labels, nil,
+		),
+		outcomeMatchesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "outcome_matches_total"),
+			"The number of proposals that matched the Oracle DAO's final result",
+			labels, nil,
+		),
+		outcomeMismatchesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "outcome_mismatches_total"),
+			"The number of proposals that didn't match the Oracle DAO's final result",
+			labels, nil,
+		),
+		expiredProposalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "expired_proposals_total"),
+			"The number of proposals the Oracle DAO never reached a result for",
+			labels, nil,
+		),
+		lastMismatchTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_mismatch_time"),
+			"The time of the latest mismatch with a member's submission or the Oracle DAO's result",
+			labels, nil,
+		),
+		tasks: map[string]*shadowTaskMetrics{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ShadowCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.proposalsDesc
+	channel <- collector.pendingProposalsDesc
+	channel <- collector.memberMatchesDesc
+	channel <- collector.memberMismatchesDesc
+	channel <- collector.outcomeMatchesDesc
+	channel <- collector.outcomeMismatchesDesc
+	channel <- collector.expiredProposalsDesc
+	channel <- collector.lastMismatchTimeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ShadowCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Update all of the metrics
+	for name, metrics := range collector.tasks {
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalsDesc, prometheus.CounterValue, metrics.proposals, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.pendingProposalsDesc, prometheus.GaugeValue, metrics.pendingProposals, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.memberMatchesDesc, prometheus.CounterValue, metrics.memberMatches, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.memberMismatchesDesc, prometheus.CounterValue, metrics.memberMismatches, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.outcomeMatchesDesc, prometheus.CounterValue, metrics.outcomeMatches, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.outcomeMismatchesDesc, prometheus.CounterValue, metrics.outcomeMismatches, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.expiredProposalsDesc, prometheus.CounterValue, metrics.expiredProposals, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastMismatchTimeDesc, prometheus.GaugeValue, metrics.lastMismatchTime, name)
+	}
+
+}
+
+// Record a new proposal
+func (collector *ShadowCollector) RecordProposal(task string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.getTask(task).proposals++
+}
+
+// Set the number of proposals that are still waiting for a result
+func (collector *ShadowCollector) SetPendingProposals(task string, count int) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.getTask(task).pendingProposals = float64(count)
+}
+
+// Record how a member's submission compared to a proposal
+func (collector *ShadowCollector) RecordMemberSubmission(task string, matched bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	metrics := collector.getTask(task)
+	if matched {
+		metrics.memberMatches++
+	} else {
+		metrics.memberMismatches++
+		metrics.lastMismatchTime = float64(time.Now().Unix())
+	}
+}
+
+// Record how the Oracle DAO's final result compared to a proposal
+func (collector *ShadowCollector) RecordOutcome(task string, matched bool) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	metrics := collector.getTask(task)
+	if matched {
+		metrics.outcomeMatches++
+	} else {
+		metrics.outcomeMismatches++
+		metrics.lastMismatchTime = float64(time.Now().Unix())
+	}
+}
+
+// Record a proposal that expired before the Oracle DAO reached a result
+func (collector *ShadowCollector) RecordExpiredProposal(task string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.getTask(task).expiredProposals++
+}
+
+// Get the metrics for a task, creating them if they don't exist yet; the lock must be held
+func (collector *ShadowCollector) getTask(task string) *shadowTaskMetrics {
+	metrics, exists := collector.tasks[task]
+	if !exists {
+		metrics = &shadowTaskMetrics{}
+		collector.tasks[task] = metrics
+	}
+	return metrics
+}
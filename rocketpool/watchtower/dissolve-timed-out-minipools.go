@@ -13,6 +13,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -25,12 +26,13 @@ const MinipoolStatusBatchSize = 20
 
 // Dissolve timed out minipools task
 type dissolveTimedOutMinipools struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	ec       rocketpool.ExecutionClient
+	rp       *rocketpool.RocketPool
+	auditLog *audit.Log
 }
 
 // Create dissolve timed out minipools task
@@ -53,15 +55,20 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	return &dissolveTimedOutMinipools{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		ec:  ec,
-		rp:  rp,
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		w:        w,
+		ec:       ec,
+		rp:       rp,
+		auditLog: auditLog,
 	}, nil
 
 }
@@ -90,7 +97,7 @@ func (t *dissolveTimedOutMinipools) run(state *state.NetworkState, isAtlasDeploy
 
 	// Dissolve minipools
 	for _, mp := range minipools {
-		if err := t.dissolveMinipool(mp); err != nil {
+		if err := t.dissolveMinipool(mp, state); err != nil {
 			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
 		}
 	}
@@ -132,7 +139,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools(state *state.NetworkSta
 }
 
 // Dissolve a minipool
-func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error {
+func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool, state *state.NetworkState) error {
 
 	// Log
 	t.log.Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
@@ -167,7 +174,15 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "dissolve-timed-out-minipools",
+		Action: "dissolve",
+		Target: mp.GetAddress().Hex(),
+		Slot:   state.BeaconSlotNumber,
+		Block:  state.ElBlockNumber,
+		Reason: fmt.Sprintf("minipool has been in prelaunch for longer than the %s launch timeout", time.Duration(state.NetworkDetails.MinipoolLaunchTimeout.Uint64())*time.Second),
+	}, nil, hash, receipt, err)
 	if err != nil {
 		return err
 	}
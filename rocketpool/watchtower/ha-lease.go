@@ -0,0 +1,94 @@
+package watchtower
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Keeps this instance's hold on the lease shared by a set of redundant watchtowers.
+// Only the instance holding the lease sends transactions; the others run the submission tasks in shadow mode until it expires.
+type leaseKeeper struct {
+	lease    *lease.FileLease
+	log      log.ColorLogger
+	errLog   log.ColorLogger
+	coll     *collectors.LeaseCollector
+	isLeader bool
+}
+
+// Create a new lease keeper
+func newLeaseKeeper(cfg *config.RocketPoolConfig, logger log.ColorLogger, errorLogger log.ColorLogger) (*leaseKeeper, error) {
+
+	// Get the instance ID
+	instanceID := cfg.Smartnode.WatchtowerHaInstanceID.Value.(string)
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname for the watchtower instance name: %w", err)
+		}
+		instanceID = hostname
+	}
+
+	// Create the lease
+	duration := time.Duration(cfg.Smartnode.WatchtowerHaLeaseDuration.Value.(uint64)) * time.Second
+	fileLease, err := lease.NewFileLease(cfg.Smartnode.GetWatchtowerLeasePath(true), instanceID, duration)
+	if err != nil {
+		return nil, fmt.Errorf("error creating watchtower lease: %w", err)
+	}
+
+	// Return
+	return &leaseKeeper{
+		lease:  fileLease,
+		log:    logger,
+		errLog: errorLogger,
+		coll:   collectors.NewLeaseCollector(instanceID),
+	}, nil
+
+}
+
+// Check if this instance is the leader and can send transactions
+func (k *leaseKeeper) isLeaderNow() bool {
+	return k.lease.IsLeader()
+}
+
+// Keep refreshing the lease, three times per lease period
+func (k *leaseKeeper) run() {
+	interval := k.lease.GetDuration() / 3
+	for {
+		time.Sleep(interval)
+		k.refresh()
+	}
+}
+
+// Take or renew the lease, logging any change of leader
+func (k *leaseKeeper) refresh() {
+	_, err := k.lease.Refresh()
+	isLeader := k.lease.IsLeader()
+	if err != nil {
+		k.errLog.Printlnf("Error refreshing the watchtower lease: %s", err.Error())
+		k.coll.RecordRefreshFailure(isLeader)
+	} else {
+		record := k.lease.GetRecord()
+		k.coll.RecordRefresh(record.Holder, record.ExpiresAt, isLeader)
+	}
+
+	if isLeader == k.isLeader {
+		return
+	}
+	k.isLeader = isLeader
+	if isLeader {
+		k.log.Printlnf("This instance (%s) is now the leader and will send the Oracle DAO transactions.", k.lease.GetInstanceID())
+		return
+	}
+	record := k.lease.GetRecord()
+	if record != nil && record.Holder != k.lease.GetInstanceID() {
+		k.log.Printlnf("This instance (%s) is now a standby; %s is the leader. Submissions will be computed in shadow mode.", k.lease.GetInstanceID(), record.Holder)
+	} else {
+		k.log.Printlnf("This instance (%s) could not renew its lease and is now a standby. Submissions will be computed in shadow mode.", k.lease.GetInstanceID())
+	}
+}
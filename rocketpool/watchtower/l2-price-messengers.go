@@ -0,0 +1,446 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+)
+
+const (
+	OptimismMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+		}
+	]`
+
+	PolygonMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+		}
+	]`
+
+	ArbitrumMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [
+			{
+			"internalType": "uint256",
+			"name": "_maxSubmissionCost",
+			"type": "uint256"
+			},
+			{
+			"internalType": "uint256",
+			"name": "_gasLimit",
+			"type": "uint256"
+			},
+			{
+			"internalType": "uint256",
+			"name": "_gasPriceBid",
+			"type": "uint256"
+			}
+		],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+		}
+	]`
+
+	zkSyncEraMessengerAbi string = `[
+		{
+			"inputs": [],
+			"name": "rateStale",
+			"outputs": [
+			{
+				"internalType": "bool",
+				"name": "",
+				"type": "bool"
+			}
+			],
+			"stateMutability": "view",
+			"type": "function"
+		},
+		{
+			"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "_l2GasLimit",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "_l2GasPerPubdataByteLimit",
+				"type": "uint256"
+			}
+			],
+			"name": "submitRate",
+			"outputs": [],
+			"stateMutability": "payable",
+			"type": "function"
+		}
+	]`
+)
+
+// A price messenger that relays the RPL rate from mainnet to an L2 network
+type L2PriceMessenger interface {
+	// Get the ID of the messenger, used to refer to it in the Smartnode config
+	GetId() string
+
+	// Get the name of the L2 network, for logs and metrics
+	GetName() string
+
+	// Get the address of the messenger contract on mainnet; blank if there isn't one on the current network
+	GetAddress() string
+
+	// Get the ABI of the messenger contract
+	GetAbi() string
+
+	// Get the ETH value and arguments to call submitRate with
+	GetSubmission(maxFee *big.Int) (L2RateSubmission, error)
+
+	// Get the retry and gas estimation policy for submissions
+	GetPolicy() L2MessengerPolicy
+
+	// Replace the retry and gas estimation policy with one from the Smartnode config
+	setPolicy(policy L2MessengerPolicy)
+}
+
+// The ETH value and arguments of a submitRate call
+type L2RateSubmission struct {
+	Value *big.Int      `json:"value,omitempty"`
+	Args  []interface{} `json:"args,omitempty"`
+}
+
+// How submissions to a messenger are made
+type L2MessengerPolicy struct {
+	// The number of times to try the submission during one of this node's turns before giving up until its next one
+	Attempts int
+
+	// How long to wait before trying a failed submission again; the retry happens on the first run of the task after that
+	RetryDelay time.Duration
+
+	// The multiplier applied to the estimated gas limit
+	GasLimitMultiplier float64
+}
+
+// Creates a messenger for the current network
+type l2PriceMessengerFactory func(cfg *config.RocketPoolConfig) L2PriceMessenger
+
+// The messengers the watchtower submits the RPL rate to.
+// Supporting a new L2 means adding its messenger address to the Smartnode config and its factory here.
+var l2PriceMessengerRegistry = []l2PriceMessengerFactory{
+	newOptimismPriceMessenger,
+	newPolygonPriceMessenger,
+	newArbitrumPriceMessenger,
+	newZkSyncEraPriceMessenger,
+}
+
+// The policy used by messengers that don't need anything special
+var defaultL2MessengerPolicy = L2MessengerPolicy{
+	Attempts:           2,
+	RetryDelay:         time.Minute,
+	GasLimitMultiplier: rocketpool.GasLimitMultiplier,
+}
+
+// Changes to a messenger's policy from the Smartnode config; unset fields keep the messenger's own values
+type l2MessengerPolicyOverride struct {
+	attempts           *int
+	retryDelay         *time.Duration
+	gasLimitMultiplier *float64
+}
+
+// Get the messengers that are deployed on the current network, with their policies overridden by the Smartnode config
+func getL2PriceMessengers(cfg *config.RocketPoolConfig) ([]L2PriceMessenger, error) {
+	overrides, err := parseL2MessengerPolicies(cfg.Smartnode.L2MessengerPolicies.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	messengers := []L2PriceMessenger{}
+	for _, factory := range l2PriceMessengerRegistry {
+		messenger := factory(cfg)
+		override, exists := overrides[messenger.GetId()]
+		if exists {
+			messenger.setPolicy(override.apply(messenger.GetPolicy()))
+			delete(overrides, messenger.GetId())
+		}
+		if messenger.GetAddress() == "" {
+			continue
+		}
+		messengers = append(messengers, messenger)
+	}
+	for id := range overrides {
+		return nil, fmt.Errorf("L2 messenger policy is for unknown messenger [%s]", id)
+	}
+	return messengers, nil
+}
+
+// Parse the L2 messenger policy overrides from the Smartnode config, in the form messenger:attempts:retryDelay:gasLimitMultiplier
+func parseL2MessengerPolicies(value string) (map[string]l2MessengerPolicyOverride, error) {
+	overrides := map[string]l2MessengerPolicyOverride{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, ":")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("L2 messenger policy [%s] must be in the form messenger:attempts:retryDelay:gasLimitMultiplier", entry)
+		}
+		id := strings.TrimSpace(fields[0])
+		if _, exists := overrides[id]; exists {
+			return nil, fmt.Errorf("L2 messenger policy for [%s] is set more than once", id)
+		}
+
+		var override l2MessengerPolicyOverride
+		if field := strings.TrimSpace(fields[1]); field != "" {
+			attempts, err := strconv.Atoi(field)
+			if err != nil || attempts < 1 {
+				return nil, fmt.Errorf("L2 messenger policy for [%s] has invalid attempts [%s]; it must be at least 1", id, field)
+			}
+			override.attempts = &attempts
+		}
+		if field := strings.TrimSpace(fields[2]); field != "" {
+			retryDelay, err := time.ParseDuration(field)
+			if err != nil || retryDelay < 0 {
+				return nil, fmt.Errorf("L2 messenger policy for [%s] has invalid retry delay [%s]", id, field)
+			}
+			override.retryDelay = &retryDelay
+		}
+		if field := strings.TrimSpace(fields[3]); field != "" {
+			gasLimitMultiplier, err := strconv.ParseFloat(field, 64)
+			if err != nil || gasLimitMultiplier < 1 {
+				return nil, fmt.Errorf("L2 messenger policy for [%s] has invalid gas limit multiplier [%s]; it must be at least 1", id, field)
+			}
+			override.gasLimitMultiplier = &gasLimitMultiplier
+		}
+		overrides[id] = override
+	}
+	return overrides, nil
+}
+
+// Apply the override to a messenger's policy
+func (o l2MessengerPolicyOverride) apply(policy L2MessengerPolicy) L2MessengerPolicy {
+	if o.attempts != nil {
+		policy.Attempts = *o.attempts
+	}
+	if o.retryDelay != nil {
+		policy.RetryDelay = *o.retryDelay
+	}
+	if o.gasLimitMultiplier != nil {
+		policy.GasLimitMultiplier = *o.gasLimitMultiplier
+	}
+	return policy
+}
+
+// A messenger whose submitRate function doesn't take any arguments or ETH
+type basicPriceMessenger struct {
+	id      string
+	name    string
+	address string
+	abi     string
+	policy  L2MessengerPolicy
+}
+
+// Get the ID of the messenger
+func (m *basicPriceMessenger) GetId() string {
+	return m.id
+}
+
+// Get the name of the L2 network
+func (m *basicPriceMessenger) GetName() string {
+	return m.name
+}
+
+// Get the address of the messenger contract
+func (m *basicPriceMessenger) GetAddress() string {
+	return m.address
+}
+
+// Get the ABI of the messenger contract
+func (m *basicPriceMessenger) GetAbi() string {
+	return m.abi
+}
+
+// Get the ETH value and arguments to call submitRate with
+func (m *basicPriceMessenger) GetSubmission(maxFee *big.Int) (L2RateSubmission, error) {
+	return L2RateSubmission{}, nil
+}
+
+// Get the retry and gas estimation policy for submissions
+func (m *basicPriceMessenger) GetPolicy() L2MessengerPolicy {
+	return m.policy
+}
+
+// Replace the retry and gas estimation policy
+func (m *basicPriceMessenger) setPolicy(policy L2MessengerPolicy) {
+	m.policy = policy
+}
+
+// Create the Optimism price messenger
+func newOptimismPriceMessenger(cfg *config.RocketPoolConfig) L2PriceMessenger {
+	return &basicPriceMessenger{
+		id:      "optimism",
+		name:    "Optimism",
+		address: cfg.Smartnode.GetOptimismMessengerAddress(),
+		abi:     OptimismMessengerAbi,
+		policy:  defaultL2MessengerPolicy,
+	}
+}
+
+// Create the Polygon price messenger
+func newPolygonPriceMessenger(cfg *config.RocketPoolConfig) L2PriceMessenger {
+	return &basicPriceMessenger{
+		id:      "polygon",
+		name:    "Polygon",
+		address: cfg.Smartnode.GetPolygonMessengerAddress(),
+		abi:     PolygonMessengerAbi,
+		policy:  defaultL2MessengerPolicy,
+	}
+}
+
+// The Arbitrum price messenger, which pays for a retryable ticket to carry the rate to L2
+type arbitrumPriceMessenger struct {
+	basicPriceMessenger
+}
+
+// Create the Arbitrum price messenger
+func newArbitrumPriceMessenger(cfg *config.RocketPoolConfig) L2PriceMessenger {
+	return &arbitrumPriceMessenger{
+		basicPriceMessenger: basicPriceMessenger{
+			id:      "arbitrum",
+			name:    "Arbitrum",
+			address: cfg.Smartnode.GetArbitrumMessengerAddress(),
+			abi:     ArbitrumMessengerAbi,
+			policy:  defaultL2MessengerPolicy,
+		},
+	}
+}
+
+// Get the ETH value and arguments to call submitRate with
+func (m *arbitrumPriceMessenger) GetSubmission(maxFee *big.Int) (L2RateSubmission, error) {
+	// Get the current network recommended max fee
+	suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
+	if err != nil {
+		return L2RateSubmission{}, fmt.Errorf("error getting recommended base fee from the network for Arbitrum price submission: %w", err)
+	}
+
+	// Constants for Arbitrum
+	bufferMultiplier := big.NewInt(4)
+	dataLength := big.NewInt(36)
+	arbitrumGasLimit := big.NewInt(40000)
+	arbitrumMaxFeePerGas := eth.GweiToWei(0.1)
+
+	// Gas limit calculation on Arbitrum
+	maxSubmissionCost := big.NewInt(6)
+	maxSubmissionCost.Mul(maxSubmissionCost, dataLength)
+	maxSubmissionCost.Add(maxSubmissionCost, big.NewInt(1400))
+	maxSubmissionCost.Mul(maxSubmissionCost, suggestedMaxFee)  // (1400 + 6 * dataLength) * baseFee
+	maxSubmissionCost.Mul(maxSubmissionCost, bufferMultiplier) // Multiply by the buffer constant for safety
+
+	// Provide enough ETH for the L2 and roundtrip TX's
+	value := big.NewInt(0)
+	value.Mul(arbitrumGasLimit, arbitrumMaxFeePerGas)
+	value.Add(value, maxSubmissionCost)
+
+	return L2RateSubmission{
+		Value: value,
+		Args:  []interface{}{maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas},
+	}, nil
+}
+
+// The zkSync Era price messenger, which pays for the L2 transaction that carries the rate
+type zkSyncEraPriceMessenger struct {
+	basicPriceMessenger
+}
+
+// Create the zkSync Era price messenger
+func newZkSyncEraPriceMessenger(cfg *config.RocketPoolConfig) L2PriceMessenger {
+	return &zkSyncEraPriceMessenger{
+		basicPriceMessenger: basicPriceMessenger{
+			id:      "zksyncEra",
+			name:    "zkSync Era",
+			address: cfg.Smartnode.GetZkSyncEraMessengerAddress(),
+			abi:     zkSyncEraMessengerAbi,
+			policy:  defaultL2MessengerPolicy,
+		},
+	}
+}
+
+// Get the ETH value and arguments to call submitRate with
+func (m *zkSyncEraPriceMessenger) GetSubmission(maxFee *big.Int) (L2RateSubmission, error) {
+	// Constants for zkSync Era
+	l1GasPerPubdataByte := big.NewInt(17)
+	fairL2GasPrice := eth.GweiToWei(0.5)
+	l2GasLimit := big.NewInt(750000)
+	gasPerPubdataByte := big.NewInt(800)
+
+	// Value calculation on zkSync Era
+	pubdataPrice := big.NewInt(0).Mul(l1GasPerPubdataByte, maxFee)
+	minL2GasPrice := big.NewInt(0).Add(pubdataPrice, gasPerPubdataByte)
+	minL2GasPrice.Sub(minL2GasPrice, big.NewInt(1))
+	minL2GasPrice.Div(minL2GasPrice, gasPerPubdataByte)
+	gasPrice := big.NewInt(0).Set(fairL2GasPrice)
+	if minL2GasPrice.Cmp(gasPrice) > 0 {
+		gasPrice.Set(minL2GasPrice)
+	}
+
+	return L2RateSubmission{
+		Value: big.NewInt(0).Mul(l2GasLimit, gasPrice),
+		Args:  []interface{}{l2GasLimit, gasPerPubdataByte},
+	}, nil
+}
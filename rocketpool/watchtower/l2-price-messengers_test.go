@@ -0,0 +1,100 @@
+package watchtower
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseL2MessengerPolicies(t *testing.T) {
+	base := L2MessengerPolicy{
+		Attempts:           3,
+		RetryDelay:         time.Minute,
+		GasLimitMultiplier: 1.5,
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected map[string]L2MessengerPolicy
+		err      string
+	}{
+		{
+			name:     "empty",
+			value:    "",
+			expected: map[string]L2MessengerPolicy{},
+		},
+		{
+			name:  "full override",
+			value: "arbitrum:5:2m:2",
+			expected: map[string]L2MessengerPolicy{
+				"arbitrum": {Attempts: 5, RetryDelay: 2 * time.Minute, GasLimitMultiplier: 2},
+			},
+		},
+		{
+			name:  "blank fields keep the defaults",
+			value: " optimism:1:: , zksyncEra::30s:",
+			expected: map[string]L2MessengerPolicy{
+				"optimism":  {Attempts: 1, RetryDelay: time.Minute, GasLimitMultiplier: 1.5},
+				"zksyncEra": {Attempts: 3, RetryDelay: 30 * time.Second, GasLimitMultiplier: 1.5},
+			},
+		},
+		{
+			name:  "wrong number of fields",
+			value: "polygon:2:1m",
+			err:   "must be in the form",
+		},
+		{
+			name:  "duplicate messenger",
+			value: "polygon:2::,polygon:3::",
+			err:   "more than once",
+		},
+		{
+			name:  "zero attempts",
+			value: "polygon:0::",
+			err:   "invalid attempts",
+		},
+		{
+			name:  "bad retry delay",
+			value: "polygon::soon:",
+			err:   "invalid retry delay",
+		},
+		{
+			name:  "negative retry delay",
+			value: "polygon::-1m:",
+			err:   "invalid retry delay",
+		},
+		{
+			name:  "gas limit multiplier below 1",
+			value: "polygon:::0.5",
+			err:   "invalid gas limit multiplier",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			overrides, err := parseL2MessengerPolicies(test.value)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(overrides) != len(test.expected) {
+				t.Fatalf("expected %d overrides, got %d", len(test.expected), len(overrides))
+			}
+			for id, expected := range test.expected {
+				override, exists := overrides[id]
+				if !exists {
+					t.Fatalf("missing override for %s", id)
+				}
+				if policy := override.apply(base); policy != expected {
+					t.Errorf("%s: expected policy %+v, got %+v", id, expected, policy)
+				}
+			}
+		})
+	}
+}
@@ -8,11 +8,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, priceCollector *collectors.PriceCollector, l2PriceCollector *collectors.L2PriceCollector, shadowCollector *collectors.ShadowCollector, leaseCollector *collectors.LeaseCollector, taskCollector *scheduler.TaskCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -28,6 +29,13 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(scrubCollector)
+	registry.MustRegister(priceCollector)
+	registry.MustRegister(l2PriceCollector)
+	registry.MustRegister(shadowCollector)
+	registry.MustRegister(taskCollector)
+	if leaseCollector != nil {
+		registry.MustRegister(leaseCollector)
+	}
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -2,18 +2,18 @@ package watchtower
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/node"
@@ -22,18 +22,26 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
-// Number of slots to go back in time and scan for penalties if state is empty (400k is approx. 8 weeks)
-const NewPenaltyScanBuffer = 400000
+// Settings
+const (
+	// Number of slots to go back in time and scan for penalties if state is empty (400k is approx. 8 weeks)
+	NewPenaltyScanBuffer = 400000
+
+	// Number of slots to process between saves of the scan cursor
+	penaltyCursorSaveInterval = 1000
+)
 
 // Process withdrawals task
 type processPenalties struct {
@@ -43,19 +51,20 @@ type processPenalties struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
-	ec             rocketpool.ExecutionClient
+	ec             *services.ExecutionClientManager
 	bc             beacon.Client
-	lock           *sync.Mutex
-	isRunning      bool
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 	beaconConfig   beacon.Eth2Config
+	dryRun         bool
+	auditLog       *audit.Log
 	m              *state.NetworkStateManager
-	s              *state.NetworkState
 }
 
+// The scan cursor; only finalized slots are ever processed, so it can't be invalidated by a reorg
 type penaltyState struct {
+	// The latest slot that has been fully processed
 	LatestPenaltySlot uint64 `yaml:"latestPenaltySlot"`
 }
 
@@ -82,6 +91,10 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
@@ -109,7 +122,6 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &processPenalties{
 		c:              c,
 		log:            logger,
@@ -119,12 +131,12 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		ec:             ec,
 		bc:             bc,
 		rp:             rp,
-		lock:           lock,
-		isRunning:      false,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
 		beaconConfig:   beaconConfig,
+		dryRun:         cfg.Smartnode.PenaltyDryRun.Value == true,
+		auditLog:       auditLog,
 		m:              m,
 	}, nil
 }
@@ -159,10 +171,10 @@ func (s *penaltyState) loadState(path string) (*penaltyState, error) {
 	return s, nil
 }
 
+// Save the state, replacing the previous file atomically so a crash mid-write can't corrupt the cursor
 func (s *penaltyState) saveState(path string) error {
 	// Marshal state object
 	data, err := yaml.Marshal(s)
-
 	if err != nil {
 		return err
 	}
@@ -173,211 +185,152 @@ func (s *penaltyState) saveState(path string) error {
 	if err != nil {
 		return fmt.Errorf("error creating watchtower directory: %w", err)
 	}
-	return os.WriteFile(path, data, 0644)
+	tempPath := path + ".tmp"
+	file, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", tempPath, err)
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", tempPath, err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("error closing %s: %w", tempPath, closeErr)
+	}
+	return os.Rename(tempPath, path)
 }
 
 // Process penalties
-func (t *processPenalties) run(isAtlasDeployed bool) error {
+func (t *processPenalties) run(cycle *scheduler.Cycle) error {
 
-	// Wait for eth clients to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
-		return err
-	}
-	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
-		return err
+	// Log
+	checkPrefix := "[Fee Recipients]"
+	t.log.Println("Checking for illegal fee recipients...")
+	if t.dryRun {
+		t.log.Printlnf("%s Dry run mode is enabled, penalties will be logged but not submitted.", checkPrefix)
 	}
 
-	// Get node account
-	nodeAccount, err := t.w.GetNodeAccount()
+	// Get the Smoothing Pool address
+	smoothingPoolContract, err := t.rp.GetContract("rocketSmoothingPool", nil)
 	if err != nil {
-		return err
+		return fmt.Errorf("%s Error getting smoothing pool contract: %w", checkPrefix, err)
 	}
+	smoothingPoolAddress := *smoothingPoolContract.Address
 
-	// Get trusted node status
-	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	// Only finalized blocks are checked, so a block can't be reorged out after it's been processed
+	finalizedBlock, exists, err := t.bc.GetBeaconBlock("finalized")
 	if err != nil {
-		return err
+		return fmt.Errorf("%s Error getting finalized beacon block: %w", checkPrefix, err)
 	}
-	if !(nodeTrusted) {
-		return nil
+	if !exists {
+		return fmt.Errorf("%s Beacon node doesn't have a finalized block yet", checkPrefix)
 	}
+	finalizedSlot := finalizedBlock.Slot
 
-	// Log
-	t.log.Println("Checking for illegal fee recipients...")
+	// Read state from file or create if this is the first run
+	watchtowerStatePath := t.cfg.Smartnode.GetWatchtowerStatePath()
+	var s penaltyState
+	if stateFileExists(watchtowerStatePath) {
+		_, err := s.loadState(watchtowerStatePath)
+		if err != nil {
+			return fmt.Errorf("%s Error loading watchtower state: %w", checkPrefix, err)
+		}
+	} else if finalizedSlot > NewPenaltyScanBuffer {
+		// No state file so start from NewPenaltyScanBuffer slots ago
+		s.LatestPenaltySlot = finalizedSlot - NewPenaltyScanBuffer
+	}
 
-	// Check if the check is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Fee recipient check is already running in the background.")
-		t.lock.Unlock()
+	if finalizedSlot <= s.LatestPenaltySlot {
+		// Nothing to do
+		t.log.Printlnf("%s Finished checking for illegal fee recipients.", checkPrefix)
 		return nil
 	}
-	t.lock.Unlock()
+	t.log.Printlnf("%s Checking slots %d to %d.", checkPrefix, s.LatestPenaltySlot+1, finalizedSlot)
 
-	// Run the check
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		checkPrefix := "[Fee Recipients]"
-
-		// Get the Smoothing Pool address
-		smoothingPoolContract, err := t.rp.GetContract("rocketSmoothingPool", nil)
+	// Loop over unprocessed slots; the cursor only moves past a slot once it's been fully handled
+	for slot := s.LatestPenaltySlot + 1; slot <= finalizedSlot; slot++ {
+		block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(slot, 10))
 		if err != nil {
-			t.handleError(fmt.Errorf("%s Error getting smoothing pool contract: %w", checkPrefix, err))
-			return
+			return fmt.Errorf("%s Error getting beacon block %d: %w", checkPrefix, slot, err)
 		}
-		smoothingPoolAddress := *smoothingPoolContract.Address
 
-		// Get latest block
-		head, headExists, err := t.bc.GetBeaconBlock("finalized")
-		if err != nil {
-			t.handleError(fmt.Errorf("%s Error getting beacon block: %w", checkPrefix, err))
-			return
-		}
-
-		currentSlot := head.Slot
-
-		// Read state from file or create if this is the first run
-		watchtowerStatePath := t.cfg.Smartnode.GetWatchtowerStatePath()
-		var s penaltyState
-
-		if stateFileExists(watchtowerStatePath) {
-			_, err := s.loadState(watchtowerStatePath)
+		// Missed slots don't have a block, and the canonical chain has no orphaned blocks in it once it's finalized
+		penalized := false
+		if exists {
+			penalized, err = t.processBlock(cycle, &block, smoothingPoolAddress)
 			if err != nil {
-				t.handleError(fmt.Errorf("%s Error loading watchtower state: %w", checkPrefix, err))
-				return
+				return fmt.Errorf("%s Error processing slot %d: %w", checkPrefix, slot, err)
 			}
-		} else {
-			// No state file so start from NewPenaltyScanBuffer slots ago
-			if currentSlot > NewPenaltyScanBuffer {
-				s.LatestPenaltySlot = currentSlot - NewPenaltyScanBuffer
-			}
-		}
-
-		if currentSlot <= s.LatestPenaltySlot {
-			// Nothing to do
-			t.log.Printlnf("%s Finished checking for illegal fee recipients.", checkPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
 		}
+		s.LatestPenaltySlot = slot
 
-		t.log.Printlnf("Starting check in a separate thread at block %d", s.LatestPenaltySlot)
-
-		// Loop over unprocessed slots
-		slotsSinceUpdate := 0
-		for i := s.LatestPenaltySlot; i < currentSlot; i++ {
-			block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(i, 10))
+		// Save right away after a penalty, and periodically otherwise
+		if penalized || slot%penaltyCursorSaveInterval == 0 {
+			err = s.saveState(watchtowerStatePath)
 			if err != nil {
-				t.handleError(fmt.Errorf("%s Error getting beacon block: %w", checkPrefix, err))
-				return
-			}
-			if exists {
-				illegalFeeRecipientFound, err := t.processBlock(&block, smoothingPoolAddress)
-				if illegalFeeRecipientFound {
-					s.LatestPenaltySlot = block.Slot
-					saveErr := s.saveState(watchtowerStatePath)
-					if saveErr != nil {
-						t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, saveErr))
-						return
-					}
-				}
-				if err != nil {
-					t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-					return
-				}
-			}
-
-			slotsSinceUpdate++
-			if slotsSinceUpdate >= 10000 {
-				t.log.Printlnf("\t%s At block %d of %d...", checkPrefix, i, currentSlot)
-				slotsSinceUpdate = 0
-				s.LatestPenaltySlot = block.Slot
-				err = s.saveState(watchtowerStatePath)
-				if err != nil {
-					t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err))
-					return
-				}
+				return fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err)
 			}
-		}
-
-		if headExists {
-			_, err = t.processBlock(&head, smoothingPoolAddress)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
-				return
+			if !penalized {
+				t.log.Printlnf("\t%s At slot %d of %d...", checkPrefix, slot, finalizedSlot)
 			}
 		}
+	}
 
-		// Update latest slot in state
-		s.LatestPenaltySlot = currentSlot
-		err = s.saveState(watchtowerStatePath)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err))
-			return
-		}
-
-		t.log.Printlnf("%s Finished checking for illegal fee recipients.", checkPrefix)
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
+	// Update latest slot in state
+	err = s.saveState(watchtowerStatePath)
+	if err != nil {
+		return fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err)
+	}
 
-	// Return
+	t.log.Printlnf("%s Finished checking for illegal fee recipients.", checkPrefix)
 	return nil
 
 }
 
-func (t *processPenalties) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Illegal fee recipient check failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
-}
-
-func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPoolAddress common.Address) (bool, error) {
-
-	isIllegalFeeRecipient := false
+// Check a block for an illegal fee recipient and penalize its minipool if it has one.
+// Returns true if the block was penalized.
+func (t *processPenalties) processBlock(cycle *scheduler.Cycle, block *beacon.BeaconBlock, smoothingPoolAddress common.Address) (bool, error) {
 
 	if !block.HasExecutionPayload {
 		// Merge hasn't occurred yet so skip
-		return isIllegalFeeRecipient, nil
+		return false, nil
 	}
 
 	status, err := t.bc.GetValidatorStatusByIndex(strconv.FormatUint(block.ProposerIndex, 10), nil)
 	if err != nil {
-		return isIllegalFeeRecipient, err
+		return false, err
 	}
 
 	// Get the minipool address from the proposer's pubkey
 	minipoolAddress, err := minipool.GetMinipoolByPubkey(t.rp, status.Pubkey, nil)
 	if err != nil {
-		return isIllegalFeeRecipient, err
+		return false, err
 	}
 
 	// A zero result indicates this proposer is not a RocketPool node operator
 	var emptyAddress [20]byte
 	if bytes.Equal(emptyAddress[:], minipoolAddress[:]) {
-		return isIllegalFeeRecipient, nil
+		return false, nil
 	}
 
 	// Retrieve the node's distributor address
 	mp, err := minipool.NewMinipool(t.rp, minipoolAddress, nil)
 	if err != nil {
-		return isIllegalFeeRecipient, err
+		return false, err
 	}
 
 	nodeAddress, err := mp.GetNodeAddress(nil)
 	if err != nil {
-		return isIllegalFeeRecipient, err
+		return false, err
 	}
 
 	distributorAddress, err := node.GetDistributorAddress(t.rp, nodeAddress, nil)
 	if err != nil {
-		return isIllegalFeeRecipient, err
+		return false, err
 	}
 
 	// Retrieve the rETH address
@@ -385,12 +338,12 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 
 	// Ignore blocks that were sent to the smoothing pool
 	if smoothingPoolAddress != emptyAddress && block.FeeRecipient == smoothingPoolAddress {
-		return isIllegalFeeRecipient, nil
+		return false, nil
 	}
 
 	// Ignore blocks that were sent to the rETH address
 	if block.FeeRecipient == rethAddress {
-		return isIllegalFeeRecipient, nil
+		return false, nil
 	}
 
 	// Check if the user was opted into the smoothing pool for this block
@@ -399,12 +352,21 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 	}
 	isOptedIn, err := node.GetSmoothingPoolRegistrationState(t.rp, nodeAddress, &opts)
 	if err != nil {
-		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %s\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err.Error())
 		isOptedIn = false
 	}
 
 	// Check for smoothing pool theft
-	if isOptedIn && block.FeeRecipient != smoothingPoolAddress {
+	if isOptedIn {
+		// Blocks built with MEV-Boost have the builder as the fee recipient, and pay the proposer in their last transaction
+		paid, err := t.hasMevPayment(block, smoothingPoolAddress)
+		if err != nil {
+			return false, err
+		}
+		if paid {
+			return false, nil
+		}
+
 		t.log.Println("=== SMOOTHING POOL THEFT DETECTED ===")
 		t.log.Printlnf("Beacon Block:  %d", block.Slot)
 		t.log.Printlnf("Minipool:      %s", minipoolAddress.Hex())
@@ -412,44 +374,55 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		t.log.Printlnf("FEE RECIPIENT: %s", block.FeeRecipient.Hex())
 		t.log.Println("=====================================")
 
-		isIllegalFeeRecipient = true
-		err = t.submitPenalty(minipoolAddress, block)
-		return isIllegalFeeRecipient, err
+		err = t.submitPenalty(cycle, minipoolAddress, block)
+		return true, err
 	}
 
 	// Make sure they didn't opt out in order to steal a block
-	if !isOptedIn {
-		// Get the opt out time
-		optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, &opts)
-		if err != nil {
-			t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
-		} else if optOutTime != time.Unix(0, 0) {
-			// Get the time of the epoch before this one
-			blockEpoch := block.Slot / t.beaconConfig.SlotsPerEpoch
-			previousEpoch := blockEpoch - 1
-			genesisTime := time.Unix(int64(t.beaconConfig.GenesisTime), 0)
-			epochStartTime := genesisTime.Add(time.Second * time.Duration(t.beaconConfig.SecondsPerEpoch*previousEpoch))
-
-			// If they opted out after the start of the previous epoch, they cheated
-			if optOutTime.Sub(epochStartTime) > 0 {
-				t.log.Println("=== SMOOTHING POOL THEFT DETECTED ===")
-				t.log.Printlnf("Beacon Block:         %d", block.Slot)
-				t.log.Printlnf("Safe Opt Out Time:    %s", epochStartTime)
-				t.log.Printlnf("ACTUAL OPT OUT TIME:  %s", optOutTime)
-				t.log.Printlnf("Minipool:             %s", minipoolAddress.Hex())
-				t.log.Printlnf("Node:                 %s", nodeAddress.Hex())
-				t.log.Printlnf("FEE RECIPIENT:        %s", block.FeeRecipient.Hex())
-				t.log.Println("=====================================")
-
-				isIllegalFeeRecipient = true
-				err = t.submitPenalty(minipoolAddress, block)
-				return isIllegalFeeRecipient, err
+	optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, &opts)
+	if err != nil {
+		t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %s\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err.Error())
+	} else if optOutTime != time.Unix(0, 0) {
+		// Get the time of the epoch before this one
+		blockEpoch := block.Slot / t.beaconConfig.SlotsPerEpoch
+		previousEpoch := blockEpoch - 1
+		genesisTime := time.Unix(int64(t.beaconConfig.GenesisTime), 0)
+		epochStartTime := genesisTime.Add(time.Second * time.Duration(t.beaconConfig.SecondsPerEpoch*previousEpoch))
+
+		// If they opted out after the start of the previous epoch, they cheated unless the builder still paid the smoothing pool
+		if optOutTime.Sub(epochStartTime) > 0 {
+			paid, err := t.hasMevPayment(block, smoothingPoolAddress)
+			if err != nil {
+				return false, err
+			}
+			if paid {
+				return false, nil
 			}
+
+			t.log.Println("=== SMOOTHING POOL THEFT DETECTED ===")
+			t.log.Printlnf("Beacon Block:         %d", block.Slot)
+			t.log.Printlnf("Safe Opt Out Time:    %s", epochStartTime)
+			t.log.Printlnf("ACTUAL OPT OUT TIME:  %s", optOutTime)
+			t.log.Printlnf("Minipool:             %s", minipoolAddress.Hex())
+			t.log.Printlnf("Node:                 %s", nodeAddress.Hex())
+			t.log.Printlnf("FEE RECIPIENT:        %s", block.FeeRecipient.Hex())
+			t.log.Println("=====================================")
+
+			err = t.submitPenalty(cycle, minipoolAddress, block)
+			return true, err
 		}
 	}
 
 	// Check for distributor address theft
-	if !isOptedIn && block.FeeRecipient != distributorAddress {
+	if block.FeeRecipient != distributorAddress {
+		paid, err := t.hasMevPayment(block, distributorAddress)
+		if err != nil {
+			return false, err
+		}
+		if paid {
+			return false, nil
+		}
+
 		t.log.Println("=== ILLEGAL FEE RECIPIENT DETECTED ===")
 		t.log.Printlnf("Beacon Block:  %d", block.Slot)
 		t.log.Printlnf("Minipool:      %s", minipoolAddress.Hex())
@@ -458,17 +431,55 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		t.log.Printlnf("FEE RECIPIENT: %s", block.FeeRecipient.Hex())
 		t.log.Println("======================================")
 
-		isIllegalFeeRecipient = true
-		err = t.submitPenalty(minipoolAddress, block)
-		return isIllegalFeeRecipient, err
+		err = t.submitPenalty(cycle, minipoolAddress, block)
+		return true, err
 	}
 
 	// No cheating detected
-	return isIllegalFeeRecipient, nil
+	return false, nil
+
+}
+
+// Check if a block built with MEV-Boost paid the expected fee recipient.
+// Builders set themselves as the block's fee recipient and pay the proposer with the block's last transaction,
+// so that transaction has to come from the block's fee recipient, go to the expected address, carry value, and succeed.
+func (t *processPenalties) hasMevPayment(block *beacon.BeaconBlock, expectedRecipient common.Address) (bool, error) {
+	executionBlock, err := t.ec.BlockByNumber(context.Background(), new(big.Int).SetUint64(block.ExecutionBlockNumber))
+	if err != nil {
+		return false, fmt.Errorf("error getting execution block %d for slot %d: %w", block.ExecutionBlockNumber, block.Slot, err)
+	}
+	txs := executionBlock.Transactions()
+	if len(txs) == 0 {
+		return false, nil
+	}
+	payment := txs[len(txs)-1]
+	if payment.To() == nil || *payment.To() != expectedRecipient || payment.Value().Sign() <= 0 {
+		return false, nil
+	}
+
+	// Anyone could send the recipient a transaction, so only the builder's payment counts
+	sender, err := types.Sender(types.LatestSignerForChainID(payment.ChainId()), payment)
+	if err != nil {
+		return false, fmt.Errorf("error getting the sender of transaction %s: %w", payment.Hash().Hex(), err)
+	}
+	if sender != block.FeeRecipient {
+		return false, nil
+	}
+
+	// Reverted transfers don't pay anything
+	receipt, err := t.ec.TransactionReceipt(context.Background(), payment.Hash())
+	if err != nil {
+		return false, fmt.Errorf("error getting the receipt of transaction %s: %w", payment.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return false, nil
+	}
 
+	t.log.Printlnf("Slot %d was built by %s, which paid %s to %s.", block.Slot, sender.Hex(), eth.WeiToEth(payment.Value()), expectedRecipient.Hex())
+	return true, nil
 }
 
-func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *beacon.BeaconBlock) error {
+func (t *processPenalties) submitPenalty(cycle *scheduler.Cycle, minipoolAddress common.Address, block *beacon.BeaconBlock) error {
 
 	// Check if this penalty has already been applied
 	blockNumberBuf := make([]byte, 32)
@@ -483,56 +494,72 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 		return nil
 	}
 
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
-	if err != nil {
-		return err
-	}
-
-	// Get the gas limit
-	gasInfo, err := network.EstimateSubmitPenaltyGas(t.rp, minipoolAddress, slotBig, opts)
-	if err != nil {
-		return fmt.Errorf("Could not estimate the gas required to submit penalty: %w", err)
-	}
-	var gas *big.Int
-	if t.gasLimit != 0 {
-		gas = new(big.Int).SetUint64(t.gasLimit)
-	} else {
-		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	// Only log the penalty in dry run mode
+	if t.dryRun {
+		t.log.Printlnf("DRY RUN: Would have submitted a penalty against %s with fee recipient %s on block %d.", minipoolAddress.Hex(), block.FeeRecipient.Hex(), block.Slot)
+		return nil
 	}
 
-	// Get the max fee
-	maxFee := t.maxFee
-	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+	// Take a turn with the other tasks that send transactions
+	return cycle.RunTransaction(func() error {
+		// Get transactor
+		opts, err := t.w.GetNodeAccountTransactor()
 		if err != nil {
 			return err
 		}
-	}
 
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, t.gasLimit) {
-		return nil
-	}
+		// Get the gas limit
+		gasInfo, err := network.EstimateSubmitPenaltyGas(t.rp, minipoolAddress, slotBig, opts)
+		if err != nil {
+			return fmt.Errorf("Could not estimate the gas required to submit penalty: %w", err)
+		}
+		var gas *big.Int
+		if t.gasLimit != 0 {
+			gas = new(big.Int).SetUint64(t.gasLimit)
+		} else {
+			gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+		}
+
+		// Get the max fee
+		maxFee := t.maxFee
+		if maxFee == nil || maxFee.Uint64() == 0 {
+			maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+			if err != nil {
+				return err
+			}
+		}
 
-	opts.GasFeeCap = maxFee
-	opts.GasTipCap = t.maxPriorityFee
-	opts.GasLimit = gas.Uint64()
+		// Print the gas info; if it's too high, fail so the cursor stays on this slot and the penalty is retried next time
+		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, t.gasLimit) {
+			return fmt.Errorf("gas price is too high to submit penalty against %s for block %d, will retry later", minipoolAddress.Hex(), block.Slot)
+		}
 
-	hash, err := network.SubmitPenalty(t.rp, minipoolAddress, slotBig, opts)
-	if err != nil {
-		return fmt.Errorf("Error submitting penalty against %s for block %d: %w", minipoolAddress.Hex(), block.Slot, err)
-	}
+		opts.GasFeeCap = maxFee
+		opts.GasTipCap = t.maxPriorityFee
+		opts.GasLimit = gas.Uint64()
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
-	if err != nil {
-		return err
-	}
+		hash, err := network.SubmitPenalty(t.rp, minipoolAddress, slotBig, opts)
+		if err != nil {
+			return fmt.Errorf("Error submitting penalty against %s for block %d: %w", minipoolAddress.Hex(), block.Slot, err)
+		}
 
-	// Log result
-	t.log.Printlnf("Submitted penalty against %s with fee recipient %s on block %d with tx %s", minipoolAddress.Hex(), block.FeeRecipient.Hex(), block.Slot, hash.Hex())
+		// Print TX info and wait for it to be included in a block
+		receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+		writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+			Task:   "process-penalties",
+			Action: "submitPenalty",
+			Target: minipoolAddress.Hex(),
+			Slot:   block.Slot,
+			Block:  block.ExecutionBlockNumber,
+			Reason: fmt.Sprintf("block used fee recipient %s instead of the smoothing pool or the node's distributor", block.FeeRecipient.Hex()),
+		}, nil, hash, receipt, err)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		// Log result
+		t.log.Printlnf("Submitted penalty against %s with fee recipient %s on block %d with tx %s", minipoolAddress.Hex(), block.FeeRecipient.Hex(), block.Slot, hash.Hex())
+		return nil
+	})
 
 }
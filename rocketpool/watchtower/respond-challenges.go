@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -18,12 +19,13 @@ import (
 
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	m   *state.NetworkStateManager
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	m        *state.NetworkStateManager
+	auditLog *audit.Log
 }
 
 // Create respond to challenges task
@@ -42,15 +44,20 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	return &respondChallenges{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		m:   m,
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		w:        w,
+		rp:       rp,
+		m:        m,
+		auditLog: auditLog,
 	}, nil
 
 }
@@ -114,7 +121,13 @@ func (t *respondChallenges) run(isAtlasDeployed bool) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "respond-challenges",
+		Action: "decideChallenge",
+		Target: nodeAccount.Address.Hex(),
+		Reason: "node has an active challenge against it",
+	}, nil, hash, receipt, err)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,264 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// A source the watchtower can read the RPL price from
+type rplPriceSource interface {
+	// Get the name of the source, for logs and metrics
+	GetName() string
+
+	// Get the RPL price in ETH at the given block
+	GetPrice(blockNumber uint64) (rplPriceReading, error)
+}
+
+// The RPL price a source reported, along with the values it was calculated from
+type rplPriceReading struct {
+	Source  string      `json:"source"`
+	Price   *big.Int    `json:"price"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// The values a TWAP price was calculated from
+type twapDetails struct {
+	PoolAddress     string     `json:"poolAddress"`
+	TwapInterval    uint32     `json:"twapInterval"`
+	TickCumulatives []*big.Int `json:"tickCumulatives"`
+	Tick            *big.Int   `json:"tick"`
+}
+
+// Reads the RPL spot price from the 1inch oracle
+type oneInchPriceSource struct {
+	c            *cli.Context
+	cfg          *config.RocketPoolConfig
+	rp           *rocketpool.RocketPool
+	printMessage func(string)
+}
+
+// Create a new 1inch oracle price source
+func newOneInchPriceSource(c *cli.Context, cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, printMessage func(string)) *oneInchPriceSource {
+	return &oneInchPriceSource{
+		c:            c,
+		cfg:          cfg,
+		rp:           rp,
+		printMessage: printMessage,
+	}
+}
+
+// Get the name of the source
+func (s *oneInchPriceSource) GetName() string {
+	return "1inch"
+}
+
+// Get the RPL price at the given block
+func (s *oneInchPriceSource) GetPrice(blockNumber uint64) (rplPriceReading, error) {
+
+	// Require 1inch oracle contract
+	if err := services.RequireOneInchOracle(s.c); err != nil {
+		return rplPriceReading{}, err
+	}
+
+	// Get RPL token address
+	rplAddress := common.HexToAddress(s.cfg.Smartnode.GetRplTokenAddress())
+
+	// Initialize call options
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}
+
+	// Get a client with the block number available
+	client, err := eth1.GetBestApiClient(s.rp, s.cfg, s.printMessage, opts.BlockNumber)
+	if err != nil {
+		return rplPriceReading{}, err
+	}
+
+	// Generate an OIO wrapper using the client
+	oio, err := contracts.NewOneInchOracle(common.HexToAddress(s.cfg.Smartnode.GetOneInchOracleAddress()), client.Client)
+	if err != nil {
+		return rplPriceReading{}, err
+	}
+
+	// Get RPL price
+	rplPrice, err := oio.GetRateToEth(opts, rplAddress, true)
+	if err != nil {
+		return rplPriceReading{}, fmt.Errorf("could not get RPL price at block %d: %w", blockNumber, err)
+	}
+
+	return rplPriceReading{
+		Source: s.GetName(),
+		Price:  rplPrice,
+	}, nil
+
+}
+
+// Reads the RPL TWAP from an RPL/WETH Uniswap v3 pool
+type uniswapTwapPriceSource struct {
+	cfg          *config.RocketPoolConfig
+	rp           *rocketpool.RocketPool
+	poolAddress  common.Address
+	interval     uint32
+	printMessage func(string)
+}
+
+// Create a new Uniswap v3 TWAP price source
+func newUniswapTwapPriceSource(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, poolAddress common.Address, interval uint32, printMessage func(string)) *uniswapTwapPriceSource {
+	return &uniswapTwapPriceSource{
+		cfg:          cfg,
+		rp:           rp,
+		poolAddress:  poolAddress,
+		interval:     interval,
+		printMessage: printMessage,
+	}
+}
+
+// Get the name of the source
+func (s *uniswapTwapPriceSource) GetName() string {
+	return fmt.Sprintf("uniswap-%s", s.poolAddress.Hex())
+}
+
+// Get the RPL price at the given block
+func (s *uniswapTwapPriceSource) GetPrice(blockNumber uint64) (rplPriceReading, error) {
+
+	// Initialize call options
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}
+
+	// Get a client with the block number available
+	client, err := eth1.GetBestApiClient(s.rp, s.cfg, s.printMessage, opts.BlockNumber)
+	if err != nil {
+		return rplPriceReading{}, err
+	}
+
+	// Construct the pool contract instance
+	parsed, err := abi.JSON(strings.NewReader(RplTwapPoolAbi))
+	if err != nil {
+		return rplPriceReading{}, fmt.Errorf("error decoding RPL TWAP pool ABI: %w", err)
+	}
+	addr := s.poolAddress
+	poolContract := bind.NewBoundContract(addr, parsed, client.Client, client.Client, client.Client)
+	pool := rocketpool.Contract{
+		Contract: poolContract,
+		Address:  &addr,
+		ABI:      &parsed,
+		Client:   client.Client,
+	}
+
+	// Get RPL price
+	response := poolObserveResponse{}
+	interval := s.interval
+	args := []uint32{interval, 0}
+
+	err = pool.Call(opts, &response, "observe", args)
+	if err != nil {
+		return rplPriceReading{}, fmt.Errorf("could not get RPL price from pool %s at block %d: %w", addr.Hex(), blockNumber, err)
+	}
+
+	tick := big.NewInt(0).Sub(response.TickCumulatives[1], response.TickCumulatives[0])
+	tick.Div(tick, big.NewInt(int64(interval))) // tick = (cumulative[1] - cumulative[0]) / interval
+
+	base := eth.EthToWei(1.0001) // 1.0001e18
+	one := eth.EthToWei(1)       // 1e18
+
+	numerator := big.NewInt(0).Exp(base, tick, nil) // 1.0001e18 ^ tick
+	numerator.Mul(numerator, one)
+
+	denominator := big.NewInt(0).Exp(one, tick, nil) // 1e18 ^ tick
+	denominator.Div(numerator, denominator)          // denominator = (1.0001e18^tick / 1e18^tick)
+
+	numerator.Mul(one, one)                               // 1e18 ^ 2
+	rplPrice := big.NewInt(0).Div(numerator, denominator) // 1e18 ^ 2 / (1.0001e18^tick * 1e18 / 1e18^tick)
+
+	return rplPriceReading{
+		Source: s.GetName(),
+		Price:  rplPrice,
+		Details: twapDetails{
+			PoolAddress:     addr.Hex(),
+			TwapInterval:    interval,
+			TickCumulatives: response.TickCumulatives,
+			Tick:            tick,
+		},
+	}, nil
+
+}
+
+// Parse the comma-separated list of pools to cross-check the RPL price against
+func parseRplPriceCheckPools(value string) ([]common.Address, error) {
+	pools := []common.Address{}
+	for _, pool := range strings.Split(value, ",") {
+		pool = strings.TrimSpace(pool)
+		if pool == "" {
+			continue
+		}
+		if !common.IsHexAddress(pool) {
+			return nil, fmt.Errorf("RPL price check pool [%s] is not a valid address", pool)
+		}
+		pools = append(pools, common.HexToAddress(pool))
+	}
+	return pools, nil
+}
+
+// Get the median of the prices the sources reported
+func getMedianRplPrice(readings []rplPriceReading) *big.Int {
+	prices := make([]*big.Int, len(readings))
+	for i, reading := range readings {
+		prices[i] = reading.Price
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+
+	middle := len(prices) / 2
+	if len(prices)%2 == 1 {
+		return big.NewInt(0).Set(prices[middle])
+	}
+	median := big.NewInt(0).Add(prices[middle-1], prices[middle])
+	return median.Div(median, big.NewInt(2))
+}
+
+// Get how far each source's price is from the others, in percent.
+// With two sources each is compared to the other one, since both are exactly as far from their median as half of the gap between them.
+// With more sources each is compared to the median of all of them.
+func getSourceDeviations(readings []rplPriceReading, medianPrice *big.Int) []float64 {
+	deviations := make([]float64, len(readings))
+	for i, reading := range readings {
+		reference := medianPrice
+		if len(readings) == 2 {
+			reference = readings[1-i].Price
+		}
+		deviations[i] = getPriceDeviation(reading.Price, reference)
+	}
+	return deviations
+}
+
+// Get how far a price is from a reference price, in percent
+func getPriceDeviation(price *big.Int, reference *big.Int) float64 {
+	if reference.Sign() == 0 {
+		if price.Sign() == 0 {
+			return 0
+		}
+		return 100
+	}
+	difference := new(big.Float).SetInt(big.NewInt(0).Sub(price, reference))
+	deviation, _ := difference.Quo(difference, new(big.Float).SetInt(reference)).Float64()
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	return deviation * 100
+}
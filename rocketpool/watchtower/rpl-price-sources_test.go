@@ -0,0 +1,114 @@
+package watchtower
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func newTestReadings(prices ...int64) []rplPriceReading {
+	readings := make([]rplPriceReading, len(prices))
+	for i, price := range prices {
+		readings[i] = rplPriceReading{Price: big.NewInt(price)}
+	}
+	return readings
+}
+
+func TestGetMedianRplPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		prices   []int64
+		expected int64
+	}{
+		{name: "one source", prices: []int64{100}, expected: 100},
+		{name: "two sources", prices: []int64{104, 100}, expected: 102},
+		{name: "three sources", prices: []int64{120, 100, 101}, expected: 101},
+		{name: "four sources", prices: []int64{90, 100, 102, 130}, expected: 101},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			median := getMedianRplPrice(newTestReadings(test.prices...))
+			if median.Int64() != test.expected {
+				t.Errorf("expected median %d, got %s", test.expected, median)
+			}
+		})
+	}
+}
+
+func TestGetSourceDeviations(t *testing.T) {
+	tests := []struct {
+		name     string
+		prices   []int64
+		expected []float64
+	}{
+		{
+			name:     "one source",
+			prices:   []int64{100},
+			expected: []float64{0},
+		},
+		{
+			// Compared to their median, both of these would only be about 1.9% off
+			name:     "two sources are compared to each other",
+			prices:   []int64{100, 104},
+			expected: []float64{100 * 4.0 / 104, 4},
+		},
+		{
+			name:     "three sources are compared to the median",
+			prices:   []int64{100, 104, 110},
+			expected: []float64{100 * 4.0 / 104, 0, 100 * 6.0 / 104},
+		},
+		{
+			name:     "zero price",
+			prices:   []int64{0, 100},
+			expected: []float64{100, 100},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readings := newTestReadings(test.prices...)
+			deviations := getSourceDeviations(readings, getMedianRplPrice(readings))
+			if len(deviations) != len(test.expected) {
+				t.Fatalf("expected %d deviations, got %d", len(test.expected), len(deviations))
+			}
+			for i, expected := range test.expected {
+				if math.Abs(deviations[i]-expected) > 1e-9 {
+					t.Errorf("source %d: expected deviation %f, got %f", i, expected, deviations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRplPriceCheckPools(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+		fails    bool
+	}{
+		{name: "empty", value: "", expected: 0},
+		{name: "one pool", value: "0xe42318eA3b998e8355a3Da364EB9D48eC725Eb45", expected: 1},
+		{name: "spaces and blank entries", value: " 0xe42318eA3b998e8355a3Da364EB9D48eC725Eb45, ,0x632E675672F2657F227da8D9bB3fE9177838e726 ", expected: 2},
+		{name: "invalid address", value: "0xe42318eA3b998e8355a3Da364EB9D48eC725Eb45,pool", fails: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pools, err := parseRplPriceCheckPools(test.value)
+			if test.fails {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pools) != test.expected {
+				t.Errorf("expected %d pools, got %d", test.expected, len(pools))
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package watchtower
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long to wait for the Oracle DAO to reach a result for a proposal before giving up on it
+	shadowProposalExpiry time.Duration = 24 * time.Hour
+)
+
+// The tasks that support shadow mode, named after their scheduler tasks
+var shadowTasks = []string{
+	"submit-rpl-price",
+	"submit-network-balances",
+	"submit-scrub-minipools",
+	"submit-rewards-tree",
+	"cancel-bond-reductions",
+	"check-solo-migrations",
+}
+
+// How a member's on-chain submission compares to a shadow proposal
+type shadowVote int
+
+const (
+	// The member hasn't submitted anything for the proposal yet
+	shadowVote_None shadowVote = iota
+
+	// The member submitted the same values
+	shadowVote_Match
+
+	// The member submitted different values
+	shadowVote_Mismatch
+)
+
+// How the Oracle DAO's final result compares to a shadow proposal
+type shadowOutcome int
+
+const (
+	// The Oracle DAO hasn't reached a result yet
+	shadowOutcome_Pending shadowOutcome = iota
+
+	// The Oracle DAO reached the same result
+	shadowOutcome_Match
+
+	// The Oracle DAO reached a different result
+	shadowOutcome_Mismatch
+
+	// The chain moved past the proposal without a result it can be compared with
+	shadowOutcome_Superseded
+)
+
+// A transaction a task would have submitted if the watchtower weren't in shadow mode
+type shadowProposal struct {
+	// The name of the task that made the proposal
+	Task string
+
+	// Identifies the proposal, so a task that runs again before the Oracle DAO reaches a result doesn't record it twice
+	Key string
+
+	// The transaction that would have been submitted
+	Description string
+
+	// Compares a member's submission to the proposal; only set for tasks whose submissions can be read per member
+	CheckMember func(member common.Address) (shadowVote, error)
+
+	// Compares the Oracle DAO's result to the proposal
+	CheckOutcome func() (shadowOutcome, error)
+
+	created time.Time
+	votes   map[common.Address]shadowVote
+}
+
+// Records the transactions the submission tasks would have sent and compares them with what the Oracle DAO actually did
+type shadowRecorder struct {
+	rp        *rocketpool.RocketPool
+	log       log.ColorLogger
+	coll      *collectors.ShadowCollector
+	proposals []*shadowProposal
+	seen      map[string]time.Time
+	lock      sync.Mutex
+
+	// Checks whether shadow mode applies right now; if this isn't set, it always does
+	isEnabled func() bool
+}
+
+// Create a new shadow recorder
+func newShadowRecorder(rp *rocketpool.RocketPool, logger log.ColorLogger, coll *collectors.ShadowCollector, isEnabled func() bool) *shadowRecorder {
+	return &shadowRecorder{
+		rp:        rp,
+		log:       logger,
+		coll:      coll,
+		seen:      map[string]time.Time{},
+		isEnabled: isEnabled,
+	}
+}
+
+// Check if the tasks should record their transactions instead of sending them.
+// This is safe to call on a nil recorder, which means shadow mode is off.
+func (r *shadowRecorder) isActive() bool {
+	if r == nil {
+		return false
+	}
+	return r.isEnabled == nil || r.isEnabled()
+}
+
+// Record a transaction instead of submitting it
+func (r *shadowRecorder) propose(proposal shadowProposal) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, exists := r.seen[proposal.Key]; exists {
+		return
+	}
+	proposal.created = time.Now()
+	proposal.votes = map[common.Address]shadowVote{}
+	r.seen[proposal.Key] = proposal.created
+	r.proposals = append(r.proposals, &proposal)
+
+	r.log.Println("=== SHADOW MODE: NOT SUBMITTED ===")
+	r.log.Printlnf("Task:        %s", proposal.Task)
+	r.log.Printlnf("Transaction: %s", proposal.Description)
+	r.log.Println("==================================")
+
+	r.coll.RecordProposal(proposal.Task)
+	r.updatePendingCounts()
+}
+
+// Compare the pending proposals with the members' submissions and the Oracle DAO's results
+func (r *shadowRecorder) compare() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.proposals) == 0 {
+		r.pruneSeen()
+		return nil
+	}
+
+	members, err := trustednode.GetMemberAddresses(r.rp, nil)
+	if err != nil {
+		return fmt.Errorf("error getting Oracle DAO members: %w", err)
+	}
+
+	pending := []*shadowProposal{}
+	for _, proposal := range r.proposals {
+		resolved, err := r.compareProposal(proposal, members)
+		if err != nil {
+			// Keep the proposal so it's checked again next time
+			r.log.Printlnf("WARNING: Couldn't compare %s proposal [%s]: %s", proposal.Task, proposal.Description, err.Error())
+			pending = append(pending, proposal)
+			continue
+		}
+		if !resolved {
+			pending = append(pending, proposal)
+		}
+	}
+	r.proposals = pending
+
+	r.pruneSeen()
+	r.updatePendingCounts()
+	return nil
+}
+
+// Compare a single proposal, returning true once it doesn't need to be checked again
+func (r *shadowRecorder) compareProposal(proposal *shadowProposal, members []common.Address) (bool, error) {
+
+	// Check the members that haven't submitted yet
+	if proposal.CheckMember != nil {
+		for _, member := range members {
+			if _, exists := proposal.votes[member]; exists {
+				continue
+			}
+			vote, err := proposal.CheckMember(member)
+			if err != nil {
+				return false, fmt.Errorf("error checking the submission of member %s: %w", member.Hex(), err)
+			}
+			switch vote {
+			case shadowVote_Match:
+				r.coll.RecordMemberSubmission(proposal.Task, true)
+			case shadowVote_Mismatch:
+				r.log.Printlnf("MISMATCH: Member %s submitted different values than %s proposal [%s].", member.Hex(), proposal.Task, proposal.Description)
+				r.coll.RecordMemberSubmission(proposal.Task, false)
+			default:
+				continue
+			}
+			proposal.votes[member] = vote
+		}
+	}
+
+	// Check the Oracle DAO's result
+	outcome, err := proposal.CheckOutcome()
+	if err != nil {
+		return false, fmt.Errorf("error checking the Oracle DAO result: %w", err)
+	}
+	switch outcome {
+	case shadowOutcome_Match:
+		r.log.Printlnf("The Oracle DAO agreed with %s proposal [%s].", proposal.Task, proposal.Description)
+		r.coll.RecordOutcome(proposal.Task, true)
+		return true, nil
+	case shadowOutcome_Mismatch:
+		r.log.Printlnf("MISMATCH: The Oracle DAO did not agree with %s proposal [%s].", proposal.Task, proposal.Description)
+		r.coll.RecordOutcome(proposal.Task, false)
+		return true, nil
+	case shadowOutcome_Superseded:
+		r.log.Printlnf("The chain moved past %s proposal [%s] before the Oracle DAO reached a result for it.", proposal.Task, proposal.Description)
+		return true, nil
+	}
+
+	if time.Since(proposal.created) > shadowProposalExpiry {
+		r.log.Printlnf("The Oracle DAO didn't reach a result for %s proposal [%s] within %s, giving up on it.", proposal.Task, proposal.Description, shadowProposalExpiry)
+		r.coll.RecordExpiredProposal(proposal.Task)
+		return true, nil
+	}
+	return false, nil
+}
+
+// Forget old proposal keys once the tasks can't propose them again; the lock must be held
+func (r *shadowRecorder) pruneSeen() {
+	for key, created := range r.seen {
+		if time.Since(created) > 2*shadowProposalExpiry {
+			delete(r.seen, key)
+		}
+	}
+}
+
+// Update the pending proposal counts for each task; the lock must be held
+func (r *shadowRecorder) updatePendingCounts() {
+	counts := map[string]int{}
+	for _, proposal := range r.proposals {
+		counts[proposal.Task]++
+	}
+	for _, task := range shadowTasks {
+		r.coll.SetPendingProposals(task, counts[task])
+	}
+}
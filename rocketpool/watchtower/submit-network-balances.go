@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,17 +11,15 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/legacy"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	rpbalances "github.com/rocket-pool/smartnode/shared/services/balances"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -40,30 +37,13 @@ type submitNetworkBalances struct {
 	ec         rocketpool.ExecutionClient
 	rp         *rocketpool.RocketPool
 	bc         beacon.Client
-	lock       *sync.Mutex
-	isRunning  bool
+	shadow     *shadowRecorder
+	auditLog   *audit.Log
 	legacyImpl *legacy.SubmitNetworkBalances
 }
 
-// Network balance info
-type networkBalances struct {
-	Block                 uint64
-	DepositPool           *big.Int
-	MinipoolsTotal        *big.Int
-	MinipoolsStaking      *big.Int
-	DistributorShareTotal *big.Int
-	SmoothingPoolShare    *big.Int
-	RETHContract          *big.Int
-	RETHSupply            *big.Int
-	NodeCreditBalance     *big.Int
-}
-type minipoolBalanceDetails struct {
-	IsStaking   bool
-	UserBalance *big.Int
-}
-
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, shadow *shadowRecorder) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -86,6 +66,10 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Legacy implementation for prior to the changeover
 	legacyImpl, err := legacy.NewSubmitNetworkBalances(c, logger, getWatchtowerMaxFee(cfg), getWatchtowerPrioFee(cfg))
@@ -94,7 +78,6 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &submitNetworkBalances{
 		c:          c,
 		log:        logger,
@@ -104,8 +87,8 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		ec:         ec,
 		rp:         rp,
 		bc:         bc,
-		lock:       lock,
-		isRunning:  false,
+		shadow:     shadow,
+		auditLog:   auditLog,
 		legacyImpl: legacyImpl,
 	}, nil
 
@@ -175,98 +158,71 @@ func (t *submitNetworkBalances) run(state *state.NetworkState, isAtlasDeployed b
 
 	// Run the old behavior until we've flipped over to the new one
 	if requiredEpoch < transitionEpoch {
+		if t.shadow.isActive() {
+			t.log.Println("Shadow mode doesn't support legacy balance reporting, skipping balance report.")
+			return nil
+		}
 		t.log.Printlnf("Current target epoch is %d, using legacy balance reporting behavior until epoch %d", requiredEpoch, transitionEpoch)
 		return t.legacyImpl.Run()
 	}
 
-	// Check if the process is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Balance report is already running in the background.")
-		t.lock.Unlock()
-		return nil
-	}
-	t.lock.Unlock()
-
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		logPrefix := "[Balance Report]"
-		t.log.Printlnf("%s Starting balance report in a separate thread.", logPrefix)
-
-		// Log
-		t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
+	// Log
+	t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
 
-		// Get network balances at block
-		balances, err := t.getNetworkBalances(header, blockNumberBig, slotNumber, blockTime, isAtlasDeployed)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-			return
-		}
+	// Get network balances at block
+	balances, err := t.getNetworkBalances(header, blockNumberBig, slotNumber, blockTime, isAtlasDeployed)
+	if err != nil {
+		return err
+	}
 
-		// Log
-		t.log.Printlnf("Deposit pool balance: %s wei", balances.DepositPool.String())
-		t.log.Printlnf("Node credit balance: %s wei", balances.NodeCreditBalance.String())
-		t.log.Printlnf("Total minipool user balance: %s wei", balances.MinipoolsTotal.String())
-		t.log.Printlnf("Staking minipool user balance: %s wei", balances.MinipoolsStaking.String())
-		t.log.Printlnf("Fee distributor user balance: %s wei", balances.DistributorShareTotal.String())
-		t.log.Printlnf("Smoothing pool user balance: %s wei", balances.SmoothingPoolShare.String())
-		t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
-		t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
-
-		// Check if we have reported these specific values before
-		hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, blockNumber, balances)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-			return
-		}
-		if hasSubmittedSpecific {
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// Log
+	t.log.Printlnf("Deposit pool balance: %s wei", balances.DepositPool.String())
+	t.log.Printlnf("Node credit balance: %s wei", balances.NodeCreditBalance.String())
+	t.log.Printlnf("Total minipool user balance: %s wei", balances.MinipoolsTotal.String())
+	t.log.Printlnf("Staking minipool user balance: %s wei", balances.MinipoolsStaking.String())
+	t.log.Printlnf("Fee distributor user balance: %s wei", balances.DistributorShareTotal.String())
+	t.log.Printlnf("Smoothing pool user balance: %s wei", balances.SmoothingPoolShare.String())
+	t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
+	t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
+
+	// In shadow mode, record the submission instead of making it
+	if t.shadow.isActive() {
+		t.proposeBalances(balances)
+		return nil
+	}
 
-		// We haven't submitted these values, check if we've submitted any for this block so we can log it
-		hasSubmitted, err := t.hasSubmittedBlockBalances(nodeAccount.Address, blockNumber)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-			return
-		}
-		if hasSubmitted {
-			t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
-		}
+	// Check if we have reported these specific values before
+	hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, blockNumber, balances)
+	if err != nil {
+		return err
+	}
+	if hasSubmittedSpecific {
+		return nil
+	}
 
-		// Log
-		t.log.Println("Submitting balances...")
+	// We haven't submitted these values, check if we've submitted any for this block so we can log it
+	hasSubmitted, err := t.hasSubmittedBlockBalances(nodeAccount.Address, blockNumber)
+	if err != nil {
+		return err
+	}
+	if hasSubmitted {
+		t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
+	}
 
-		// Submit balances
-		if err := t.submitBalances(balances); err != nil {
-			t.handleError(fmt.Errorf("%s could not submit network balances: %w", logPrefix, err))
-			return
-		}
+	// Log
+	t.log.Println("Submitting balances...")
 
-		// Log and return
-		t.log.Printlnf("%s Balance report complete.", logPrefix)
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
+	// Submit balances
+	if err := t.submitBalances(balances, slotNumber); err != nil {
+		return fmt.Errorf("could not submit network balances: %w", err)
+	}
 
-	// Return
+	// Log and return
+	t.log.Println("Balance report complete.")
 	return nil
 
 }
 
-func (t *submitNetworkBalances) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Balance report failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
-}
-
 // Check whether balances for a block has already been submitted by the node
 func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Address, blockNumber uint64) (bool, error) {
 
@@ -277,16 +233,10 @@ func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Add
 }
 
 // Check whether specific balances for a block has already been submitted by the node
-func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress common.Address, blockNumber uint64, balances networkBalances) (bool, error) {
+func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress common.Address, blockNumber uint64, balances rpbalances.NetworkBalances) (bool, error) {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := rpbalances.GetTotalEthBalance(balances)
 
 	blockNumberBuf := make([]byte, 32)
 	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
@@ -304,215 +254,101 @@ func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress co
 
 }
 
+// Record the balances submission that would have been made in shadow mode
+func (t *submitNetworkBalances) proposeBalances(balances rpbalances.NetworkBalances) {
+	totalEth := rpbalances.GetTotalEthBalance(balances)
+	t.shadow.propose(shadowProposal{
+		Task:        "submit-network-balances",
+		Key:         fmt.Sprintf("balances-%d", balances.Block),
+		Description: fmt.Sprintf("submitBalances(block %d, total ETH %s wei, staking ETH %s wei, rETH supply %s wei)", balances.Block, totalEth.String(), balances.MinipoolsStaking.String(), balances.RETHSupply.String()),
+		CheckMember: func(member common.Address) (shadowVote, error) {
+			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(member, balances.Block, balances)
+			if err != nil {
+				return shadowVote_None, err
+			}
+			if hasSubmittedSpecific {
+				return shadowVote_Match, nil
+			}
+			hasSubmitted, err := t.hasSubmittedBlockBalances(member, balances.Block)
+			if err != nil {
+				return shadowVote_None, err
+			}
+			if hasSubmitted {
+				return shadowVote_Mismatch, nil
+			}
+			return shadowVote_None, nil
+		},
+		CheckOutcome: func() (shadowOutcome, error) {
+			balancesBlock, err := network.GetBalancesBlock(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if balancesBlock < balances.Block {
+				return shadowOutcome_Pending, nil
+			}
+			if balancesBlock > balances.Block {
+				return shadowOutcome_Superseded, nil
+			}
+			networkTotalEth, err := network.GetTotalETHBalance(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			networkStakingEth, err := network.GetStakingETHBalance(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			networkRethSupply, err := network.GetTotalRETHSupply(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if networkTotalEth.Cmp(totalEth) != 0 || networkStakingEth.Cmp(balances.MinipoolsStaking) != 0 || networkRethSupply.Cmp(balances.RETHSupply) != 0 {
+				return shadowOutcome_Mismatch, nil
+			}
+			return shadowOutcome_Match, nil
+		},
+	})
+}
+
 // Prints a message to the log
 func (t *submitNetworkBalances) printMessage(message string) {
 	t.log.Println(message)
 }
 
 // Get the network balances at a specific block
-func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header, elBlock *big.Int, beaconBlock uint64, slotTime time.Time, isAtlasDeployed bool) (networkBalances, error) {
+func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header, elBlock *big.Int, beaconBlock uint64, slotTime time.Time, isAtlasDeployed bool) (rpbalances.NetworkBalances, error) {
 
 	// Get a client with the block number available
 	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, elBlock)
 	if err != nil {
-		return networkBalances{}, err
+		return rpbalances.NetworkBalances{}, err
 	}
 
 	// Create a new state gen manager
 	mgr, err := state.NewNetworkStateManager(client, t.cfg, client.Client, t.bc, &t.log)
 	if err != nil {
-		return networkBalances{}, fmt.Errorf("error creating network state manager for EL block %s, Beacon slot %d: %w", elBlock, beaconBlock, err)
+		return rpbalances.NetworkBalances{}, fmt.Errorf("error creating network state manager for EL block %s, Beacon slot %d: %w", elBlock, beaconBlock, err)
 	}
 
 	// Create a new state for the target block
 	state, err := mgr.GetStateForSlot(beaconBlock)
 	if err != nil {
-		return networkBalances{}, fmt.Errorf("couldn't get network state for EL block %s, Beacon slot %d: %w", elBlock, beaconBlock, err)
-	}
-
-	// Data
-	var wg errgroup.Group
-	var depositPoolBalance *big.Int
-	var mpBalanceDetails []minipoolBalanceDetails
-	var distributorShares []*big.Int
-	var smoothingPoolShare *big.Int
-	rethContractBalance := state.NetworkDetails.RETHBalance
-	rethTotalSupply := state.NetworkDetails.TotalRETHSupply
-
-	// Get deposit pool balance
-	if isAtlasDeployed {
-		depositPoolBalance = state.NetworkDetails.DepositPoolUserBalance
-	} else {
-		depositPoolBalance = state.NetworkDetails.DepositPoolBalance
-	}
-
-	// Get minipool balance details
-	wg.Go(func() error {
-		mpBalanceDetails = make([]minipoolBalanceDetails, len(state.MinipoolDetails))
-		for i, mpd := range state.MinipoolDetails {
-			mpBalanceDetails[i] = t.getMinipoolBalanceDetails(&mpd, state, t.cfg)
-		}
-		return nil
-	})
-
-	// Get distributor balance details
-	wg.Go(func() error {
-		distributorShares = make([]*big.Int, len(state.NodeDetails))
-		for i, node := range state.NodeDetails {
-			distributorShares[i] = node.DistributorBalanceUserETH // Uses the go-lib based off-chain calculation method instead of the contract method
-		}
-
-		return nil
-	})
-
-	// Get the smoothing pool user share
-	wg.Go(func() error {
-
-		// Get the current interval
-		currentIndex := state.NetworkDetails.RewardIndex
-
-		// Get the start time for the current interval, and how long an interval is supposed to take
-		startTime := state.NetworkDetails.IntervalStart
-		intervalTime := state.NetworkDetails.IntervalDuration
-
-		timeSinceStart := slotTime.Sub(startTime)
-		intervalsPassed := timeSinceStart / intervalTime
-		endTime := slotTime
-
-		// Approximate the staker's share of the smoothing pool balance
-		treegen, err := rprewards.NewTreeGenerator(t.log, "[Balances]", client, t.cfg, t.bc, currentIndex, startTime, endTime, beaconBlock, elBlockHeader, uint64(intervalsPassed), state)
-		if err != nil {
-			return fmt.Errorf("error creating merkle tree generator to approximate share of smoothing pool: %w", err)
-		}
-		smoothingPoolShare, err = treegen.ApproximateStakerShareOfSmoothingPool()
-		if err != nil {
-			return fmt.Errorf("error getting approximate share of smoothing pool: %w", err)
-		}
-
-		return nil
-
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return networkBalances{}, err
-	}
-
-	// Balances
-	balances := networkBalances{
-		Block:                 elBlockHeader.Number.Uint64(),
-		DepositPool:           depositPoolBalance,
-		MinipoolsTotal:        big.NewInt(0),
-		MinipoolsStaking:      big.NewInt(0),
-		DistributorShareTotal: big.NewInt(0),
-		SmoothingPoolShare:    smoothingPoolShare,
-		RETHContract:          rethContractBalance,
-		RETHSupply:            rethTotalSupply,
-		NodeCreditBalance:     big.NewInt(0),
-	}
-
-	// Add minipool balances
-	for _, mp := range mpBalanceDetails {
-		balances.MinipoolsTotal.Add(balances.MinipoolsTotal, mp.UserBalance)
-		if mp.IsStaking {
-			balances.MinipoolsStaking.Add(balances.MinipoolsStaking, mp.UserBalance)
-		}
+		return rpbalances.NetworkBalances{}, fmt.Errorf("couldn't get network state for EL block %s, Beacon slot %d: %w", elBlock, beaconBlock, err)
 	}
 
-	// Add node credits
-	if state.IsAtlasDeployed {
-		for _, node := range state.NodeDetails {
-			balances.NodeCreditBalance.Add(balances.NodeCreditBalance, node.DepositCreditBalance)
-		}
-	}
-
-	// Add distributor shares
-	for _, share := range distributorShares {
-		balances.DistributorShareTotal.Add(balances.DistributorShareTotal, share)
+	// Calculate the balances
+	balances, _, err := rpbalances.GetNetworkBalances(t.log, client, t.cfg, t.bc, state, elBlockHeader, slotTime, isAtlasDeployed)
+	if err != nil {
+		return rpbalances.NetworkBalances{}, err
 	}
-
-	// Return
 	return balances, nil
 
 }
 
-// Get minipool balance details
-func (t *submitNetworkBalances) getMinipoolBalanceDetails(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, cfg *config.RocketPoolConfig) minipoolBalanceDetails {
-
-	status := mpd.Status
-	userDepositBalance := mpd.UserDepositBalance
-	mpType := mpd.DepositType
-	validator := state.ValidatorDetails[mpd.Pubkey]
-
-	blockEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
-
-	// Ignore vacant minipools
-	if mpd.IsVacant {
-		return minipoolBalanceDetails{
-			UserBalance: big.NewInt(0),
-		}
-	}
-
-	// Dissolved minipools don't contribute to rETH
-	if status == rptypes.Dissolved {
-		return minipoolBalanceDetails{
-			UserBalance: big.NewInt(0),
-		}
-	}
-
-	// Use user deposit balance if initialized or prelaunch
-	if status == rptypes.Initialized || status == rptypes.Prelaunch {
-		return minipoolBalanceDetails{
-			UserBalance: userDepositBalance,
-		}
-	}
-
-	// "Broken" LEBs with the Redstone delegates report their total balance minus their node deposit balance
-	if mpd.DepositType == rptypes.Variable && mpd.Version == 2 {
-		brokenBalance := big.NewInt(0).Set(mpd.Balance)
-		brokenBalance.Add(brokenBalance, eth.GweiToWei(float64(validator.Balance)))
-		brokenBalance.Sub(brokenBalance, mpd.NodeRefundBalance)
-		brokenBalance.Sub(brokenBalance, mpd.NodeDepositBalance)
-		return minipoolBalanceDetails{
-			IsStaking:   (validator.Exists && validator.ActivationEpoch < blockEpoch && validator.ExitEpoch > blockEpoch),
-			UserBalance: brokenBalance,
-		}
-	}
-
-	// Use user deposit balance if validator not yet active on beacon chain at block
-	if !validator.Exists || validator.ActivationEpoch >= blockEpoch {
-		return minipoolBalanceDetails{
-			UserBalance: userDepositBalance,
-		}
-	}
-
-	// Here userBalance is CalculateUserShare(beaconBalance + minipoolBalance - refund)
-	userBalance := mpd.UserShareOfBalanceIncludingBeacon
-	if userDepositBalance.Cmp(big.NewInt(0)) == 0 && mpType == rptypes.Full {
-		return minipoolBalanceDetails{
-			IsStaking:   (validator.ExitEpoch > blockEpoch),
-			UserBalance: big.NewInt(0).Sub(userBalance, eth.EthToWei(16)), // Remove 16 ETH from the user balance for full minipools in the refund queue
-		}
-	} else {
-		return minipoolBalanceDetails{
-			IsStaking:   (validator.ExitEpoch > blockEpoch),
-			UserBalance: userBalance,
-		}
-	}
-
-}
-
 // Submit network balances
-func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
+func (t *submitNetworkBalances) submitBalances(balances rpbalances.NetworkBalances, slotNumber uint64) error {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := rpbalances.GetTotalEthBalance(balances)
 
 	ratio := eth.WeiToEth(totalEth) / eth.WeiToEth(balances.RETHSupply)
 	t.log.Printlnf("Total ETH = %s\n", totalEth)
@@ -551,7 +387,13 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "submit-network-balances",
+		Action: "submitBalances",
+		Slot:   slotNumber,
+		Block:  balances.Block,
+	}, balances, hash, receipt, err)
 	if err != nil {
 		return fmt.Errorf("error waiting for transaction: %w", err)
 	}
@@ -19,9 +19,12 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/storage"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
@@ -30,7 +33,6 @@ import (
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
-	"github.com/web3-storage/go-w3s-client"
 )
 
 // Submit rewards Merkle Tree task
@@ -43,6 +45,8 @@ type submitRewardsTree struct {
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
+	shadow           *shadowRecorder
+	auditLog         *audit.Log
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
@@ -50,7 +54,7 @@ type submitRewardsTree struct {
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree, error) {
+func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, shadow *shadowRecorder) (*submitRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -73,6 +77,10 @@ func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger lo
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
 
 	lock := &sync.Mutex{}
 	generator := &submitRewardsTree{
@@ -84,6 +92,8 @@ func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger lo
 		bc:               bc,
 		w:                w,
 		rp:               rp,
+		shadow:           shadow,
+		auditLog:         auditLog,
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
@@ -94,7 +104,7 @@ func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger lo
 }
 
 // Submit rewards Merkle Tree
-func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, beaconSlot uint64, isAtlasDeployed bool) error {
+func (t *submitRewardsTree) run(cycle *scheduler.Cycle, nodeTrusted bool, state *state.NetworkState, beaconSlot uint64, isAtlasDeployed bool) error {
 
 	// Wait for clients to sync
 	if err := services.WaitEthClientSynced(t.c, true); err != nil {
@@ -110,8 +120,8 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 		return err
 	}
 
-	// Check node trusted status
-	if !nodeTrusted {
+	// Check node trusted status; shadow mode runs the same way for members and non-members
+	if !nodeTrusted && !t.shadow.isActive() {
 		if t.cfg.Smartnode.RewardsTreeMode.Value.(cfgtypes.RewardsMode) != cfgtypes.RewardsMode_Generate {
 			return nil
 		} else {
@@ -175,6 +185,11 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 
 	// Check if we can reuse an existing file for this interval
 	if t.isExistingFileValid(rewardsTreePath, uint64(intervalsPassed)) {
+		// In shadow mode, record the existing tree instead of submitting it
+		if t.shadow.isActive() {
+			return t.proposeRewardsSnapshotFromFile(currentIndex, rewardsTreePath)
+		}
+
 		if !nodeTrusted {
 			t.log.Printlnf("Merkle rewards tree for interval %d already exists at %s.", currentIndex, rewardsTreePath)
 			return nil
@@ -204,14 +219,16 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 		}
 
 		// Upload the file
-		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
+		cid, err := t.uploadFile(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
-			return fmt.Errorf("Error uploading Merkle tree to Web3.Storage: %w", err)
+			return fmt.Errorf("Error uploading Merkle tree: %w", err)
 		}
 		t.log.Printlnf("Uploaded Merkle tree with CID %s", cid)
 
-		// Submit to the contracts
-		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, proofWrapper, cid, big.NewInt(int64(intervalsPassed)))
+		// Submit to the contracts, taking a turn with the other tasks that send transactions
+		err = cycle.RunTransaction(func() error {
+			return t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, proofWrapper, cid, big.NewInt(int64(intervalsPassed)))
+		})
 		if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
@@ -221,7 +238,7 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 	}
 
 	// Generate the tree
-	t.generateTree(intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
+	t.generateTree(cycle, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
 
 	// Done
 	return nil
@@ -274,8 +291,8 @@ func (t *submitRewardsTree) isExistingFileValid(rewardsTreePath string, interval
 
 }
 
-// Kick off the tree generation goroutine
-func (t *submitRewardsTree) generateTree(intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) {
+// Kick off the tree generation goroutine; it outlives the task, so its submission takes the cycle's transaction lock itself
+func (t *submitRewardsTree) generateTree(cycle *scheduler.Cycle, intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) {
 
 	go func() {
 		t.lock.Lock()
@@ -290,7 +307,7 @@ func (t *submitRewardsTree) generateTree(intervalsPassed time.Duration, nodeTrus
 		}
 
 		// Generate the tree
-		err = t.generateTreeImpl(client, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
+		err = t.generateTreeImpl(cycle, client, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
 		if err != nil {
 			t.handleError(err)
 		}
@@ -303,7 +320,7 @@ func (t *submitRewardsTree) generateTree(intervalsPassed time.Duration, nodeTrus
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) error {
+func (t *submitRewardsTree) generateTreeImpl(cycle *scheduler.Cycle, rp *rocketpool.RocketPool, intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) error {
 
 	// Log
 	if uint64(intervalsPassed) > 1 {
@@ -328,6 +345,7 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 	if err != nil {
 		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
 	}
+	treegen.EnableCheckpoints(t.cfg.Smartnode.GetRewardsCheckpointPath(currentIndex, true))
 	rewardsFile, err := treegen.GenerateTree()
 	if err != nil {
 		return fmt.Errorf("Error generating Merkle tree: %w", err)
@@ -348,12 +366,12 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 		return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
 	}
 
-	// Upload it if this is an Oracle DAO node
-	if nodeTrusted {
-		t.printMessage("Uploading minipool performance file to Web3.Storage...")
-		minipoolPerformanceCid, err := t.uploadFileToWeb3Storage(minipoolPerformanceBytes, compressedMinipoolPerformancePath, "compressed minipool performance")
+	// Upload it if this is an Oracle DAO node that isn't in shadow mode
+	if nodeTrusted && !t.shadow.isActive() {
+		t.printMessage("Uploading minipool performance file...")
+		minipoolPerformanceCid, err := t.uploadFile(minipoolPerformanceBytes, compressedMinipoolPerformancePath, "compressed minipool performance")
 		if err != nil {
-			return fmt.Errorf("Error uploading minipool performance file to Web3.Storage: %w", err)
+			return fmt.Errorf("Error uploading minipool performance file: %w", err)
 		}
 		t.printMessage(fmt.Sprintf("Uploaded minipool performance file with CID %s", minipoolPerformanceCid))
 		rewardsFile.MinipoolPerformanceFileCID = minipoolPerformanceCid
@@ -375,18 +393,27 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
+	// In shadow mode, record the submission instead of uploading and submitting the tree
+	if t.shadow.isActive() {
+		t.proposeRewardsSnapshot(currentIndex, rewardsFile)
+		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
+		return nil
+	}
+
 	// Only do the upload and submission process if this is an Oracle DAO node
 	if nodeTrusted {
 		// Upload the rewards tree file
-		t.printMessage("Uploading to Web3.Storage and submitting results to the contracts...")
-		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
+		t.printMessage("Uploading Merkle tree and submitting results to the contracts...")
+		cid, err := t.uploadFile(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
-			return fmt.Errorf("Error uploading Merkle tree to Web3.Storage: %w", err)
+			return fmt.Errorf("Error uploading Merkle tree: %w", err)
 		}
 		t.printMessage(fmt.Sprintf("Uploaded Merkle tree with CID %s", cid))
 
-		// Submit to the contracts
-		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile, cid, big.NewInt(int64(intervalsPassed)))
+		// Submit to the contracts, taking a turn with the other tasks that send transactions
+		err = cycle.RunTransaction(func() error {
+			return t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile, cid, big.NewInt(int64(intervalsPassed)))
+		})
 		if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
@@ -473,7 +500,14 @@ func (t *submitRewardsTree) submitRewardsSnapshot(index *big.Int, consensusBlock
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "submit-rewards-tree",
+		Action: "submitRewardSnapshot",
+		Target: fmt.Sprintf("interval %s", index.String()),
+		Slot:   consensusBlock,
+		Block:  executionBlock,
+	}, submission, hash, receipt, err)
 	if err != nil {
 		return err
 	}
@@ -482,48 +516,87 @@ func (t *submitRewardsTree) submitRewardsSnapshot(index *big.Int, consensusBlock
 	return nil
 }
 
-// Compress and upload a file to Web3.Storage and get the CID for it
-func (t *submitRewardsTree) uploadFileToWeb3Storage(wrapperBytes []byte, compressedPath string, description string) (string, error) {
+// Record the rewards snapshot submission that would have been made in shadow mode
+func (t *submitRewardsTree) proposeRewardsSnapshot(index uint64, rewardsFile *rprewards.RewardsFile) {
+	merkleRoot := common.HexToHash(rewardsFile.MerkleRoot)
+	t.shadow.propose(shadowProposal{
+		Task:        "submit-rewards-tree",
+		Key:         fmt.Sprintf("rewards-%d", index),
+		Description: fmt.Sprintf("submitRewardSnapshot(interval %d, Merkle root %s, %d intervals passed)", index, merkleRoot.Hex(), rewardsFile.IntervalsPassed),
+		CheckOutcome: func() (shadowOutcome, error) {
+			// Members' submissions are only stored as hashes of the whole snapshot, so this compares against the canonical tree
+			currentIndex, err := rewards.GetRewardIndex(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if currentIndex.Uint64() <= index {
+				return shadowOutcome_Pending, nil
+			}
+			event, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if event.MerkleRoot != merkleRoot {
+				return shadowOutcome_Mismatch, nil
+			}
+			return shadowOutcome_Match, nil
+		},
+	})
+}
+
+// Record the rewards snapshot submission for a tree that was already generated in shadow mode
+func (t *submitRewardsTree) proposeRewardsSnapshotFromFile(index uint64, rewardsTreePath string) error {
+	wrapperBytes, err := os.ReadFile(rewardsTreePath)
+	if err != nil {
+		return fmt.Errorf("Error reading rewards tree file: %w", err)
+	}
 
-	// Get the API token
-	apiToken := t.cfg.Smartnode.Web3StorageApiToken.Value.(string)
-	if apiToken == "" {
-		return "", fmt.Errorf("***ERROR***\nYou have not configured your Web3.Storage API token yet, so you cannot submit Merkle rewards trees.\nPlease get an API token from https://web3.storage and enter it in the Smartnode section of the `service config` TUI (or use `--smartnode-web3StorageApiToken` if you configure your system headlessly).")
+	proofWrapper := new(rprewards.RewardsFile)
+	err = json.Unmarshal(wrapperBytes, proofWrapper)
+	if err != nil {
+		return fmt.Errorf("Error deserializing rewards tree file: %w", err)
 	}
 
-	// Create the client
-	w3sClient, err := w3s.NewClient(w3s.WithToken(apiToken))
+	t.proposeRewardsSnapshot(index, proofWrapper)
+	return nil
+}
+
+// Compress and upload a file with the configured uploader, copy it to any mirrors, and get the CID for it
+func (t *submitRewardsTree) uploadFile(wrapperBytes []byte, compressedPath string, description string) (string, error) {
+
+	// Get the uploader
+	uploader, err := storage.GetUploader(t.cfg)
 	if err != nil {
-		return "", fmt.Errorf("Error creating new Web3.Storage client: %w", err)
+		return "", err
 	}
 
 	// Compress the file
 	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
 	compressedBytes := encoder.EncodeAll(wrapperBytes, make([]byte, 0, len(wrapperBytes)))
 
-	// Create the compressed tree file
-	compressedFile, err := os.Create(compressedPath)
-	if err != nil {
-		return "", fmt.Errorf("Error creating %s file [%s]: %w", description, compressedPath, err)
-	}
-	defer compressedFile.Close()
-
 	// Write the compressed data to the file
-	_, err = compressedFile.Write(compressedBytes)
+	err = os.WriteFile(compressedPath, compressedBytes, 0644)
 	if err != nil {
 		return "", fmt.Errorf("Error writing %s to %s: %w", description, compressedPath, err)
 	}
 
-	// Rewind it to the start
-	compressedFile.Seek(0, 0)
-
 	// Upload it
-	cid, err := w3sClient.Put(context.Background(), compressedFile)
+	cid, err := uploader.Upload(compressedPath)
 	if err != nil {
-		return "", fmt.Errorf("Error uploading %s: %w", description, err)
+		return "", fmt.Errorf("Error uploading %s to %s: %w", description, uploader.GetName(), err)
+	}
+
+	// Copy it to the mirrors; the CID is already known so failures here aren't fatal
+	for _, mirror := range storage.GetMirrors(t.cfg) {
+		err = mirror.Mirror(cid, compressedPath)
+		if err != nil {
+			t.printMessage(fmt.Sprintf("WARNING: couldn't mirror %s to %s: %s", description, mirror.GetName(), err.Error()))
+		} else {
+			t.printMessage(fmt.Sprintf("Mirrored %s to %s.", description, mirror.GetName()))
+		}
 	}
 
-	return cid.String(), nil
+	return cid, nil
 
 }
 
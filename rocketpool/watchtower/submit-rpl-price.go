@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -23,139 +22,20 @@ import (
 	"github.com/urfave/cli"
 
 	v110_network "github.com/rocket-pool/rocketpool-go/legacy/v1.1.0/network"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
-	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
-	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
 const (
-	OptimismMessengerAbi string = `[
-		{
-		"inputs": [],
-		"name": "rateStale",
-		"outputs": [
-			{
-			"internalType": "bool",
-			"name": "",
-			"type": "bool"
-			}
-		],
-		"stateMutability": "view",
-		"type": "function"
-		},
-		{
-		"inputs": [],
-		"name": "submitRate",
-		"outputs": [],
-		"stateMutability": "nonpayable",
-		"type": "function"
-		}
-	]`
-
-	PolygonMessengerAbi string = `[
-		{
-		"inputs": [],
-		"name": "rateStale",
-		"outputs": [
-			{
-			"internalType": "bool",
-			"name": "",
-			"type": "bool"
-			}
-		],
-		"stateMutability": "view",
-		"type": "function"
-		},
-		{
-		"inputs": [],
-		"name": "submitRate",
-		"outputs": [],
-		"stateMutability": "nonpayable",
-		"type": "function"
-		}
-	]`
-
-	ArbitrumMessengerAbi string = `[
-		{
-		"inputs": [],
-		"name": "rateStale",
-		"outputs": [
-			{
-			"internalType": "bool",
-			"name": "",
-			"type": "bool"
-			}
-		],
-		"stateMutability": "view",
-		"type": "function"
-		},
-		{
-		"inputs": [
-			{
-			"internalType": "uint256",
-			"name": "_maxSubmissionCost",
-			"type": "uint256"
-			},
-			{
-			"internalType": "uint256",
-			"name": "_gasLimit",
-			"type": "uint256"
-			},
-			{
-			"internalType": "uint256",
-			"name": "_gasPriceBid",
-			"type": "uint256"
-			}
-		],
-		"name": "submitRate",
-		"outputs": [],
-		"stateMutability": "payable",
-		"type": "function"
-		}
-	]`
-
-	zkSyncEraMessengerAbi string = `[
-		{
-			"inputs": [],
-			"name": "rateStale",
-			"outputs": [
-			{
-				"internalType": "bool",
-				"name": "",
-				"type": "bool"
-			}
-			],
-			"stateMutability": "view",
-			"type": "function"
-		},
-		{
-			"inputs": [
-			{
-				"internalType": "uint256",
-				"name": "_l2GasLimit",
-				"type": "uint256"
-			},
-			{
-				"internalType": "uint256",
-				"name": "_l2GasPerPubdataByteLimit",
-				"type": "uint256"
-			}
-			],
-			"name": "submitRate",
-			"outputs": [],
-			"stateMutability": "payable",
-			"type": "function"
-		}
-	]`
-
 	RplTwapPoolAbi string = `[
 		{
 		"inputs": [{
@@ -181,10 +61,8 @@ const (
 
 // Settings
 const (
-	SubmissionKey                      string  = "network.prices.submitted.node.key"
-	BlocksPerTurn                      uint64  = 75  // Approx. 15 minutes
-	RplPriceDecreaseDeviationThreshold float64 = 0.5 // Error out if price drops >50%
-	RplPriceIncreaseDeviationThreshold float64 = 1.6 // Error out if price rises >60%
+	SubmissionKey string = "network.prices.submitted.node.key"
+	BlocksPerTurn uint64 = 75 // Approx. 15 minutes
 
 	twapNumberOfSeconds uint32 = 60 * 60 * 12 // 12 hours
 )
@@ -196,21 +74,41 @@ type poolObserveResponse struct {
 
 // Submit RPL price task
 type submitRplPrice struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	ec        rocketpool.ExecutionClient
-	w         *wallet.Wallet
-	rp        *rocketpool.RocketPool
-	oio       *contracts.OneInchOracle
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c            *cli.Context
+	log          log.ColorLogger
+	errLog       log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	ec           rocketpool.ExecutionClient
+	w            *wallet.Wallet
+	rp           *rocketpool.RocketPool
+	oio          *contracts.OneInchOracle
+	bc           beacon.Client
+	coll         *collectors.PriceCollector
+	l2Coll       *collectors.L2PriceCollector
+	l2Messengers []L2PriceMessenger
+	l2Retries    map[string]*l2RetryState
+	shadow       *shadowRecorder
+	auditLog     *audit.Log
+}
+
+// The failed submissions to a messenger during one of this node's turns
+type l2RetryState struct {
+	turn       uint64
+	attempts   int
+	retryAfter time.Time
+}
+
+// The values the RPL price was calculated from, for the audit log
+type rplPriceInputs struct {
+	Sources           []rplPriceReading `json:"sources"`
+	MedianPrice       *big.Int          `json:"medianPrice"`
+	PreviousPrice     *big.Int          `json:"previousPrice"`
+	RplPrice          *big.Int          `json:"rplPrice"`
+	EffectiveRplStake *big.Int          `json:"effectiveRplStake"`
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.PriceCollector, l2Coll *collectors.L2PriceCollector, shadow *shadowRecorder) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -237,20 +135,32 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	if err != nil {
 		return nil, err
 	}
+	auditLog, err := services.GetAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
+	l2Messengers, err := getL2PriceMessengers(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &submitRplPrice{
-		c:      c,
-		log:    logger,
-		errLog: errorLogger,
-		cfg:    cfg,
-		ec:     ec,
-		w:      w,
-		rp:     rp,
-		oio:    oio,
-		bc:     bc,
-		lock:   lock,
+		c:            c,
+		log:          logger,
+		errLog:       errorLogger,
+		cfg:          cfg,
+		ec:           ec,
+		w:            w,
+		rp:           rp,
+		oio:          oio,
+		bc:           bc,
+		coll:         coll,
+		l2Coll:       l2Coll,
+		l2Messengers: l2Messengers,
+		l2Retries:    map[string]*l2RetryState{},
+		shadow:       shadow,
+		auditLog:     auditLog,
 	}, nil
 
 }
@@ -274,32 +184,9 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 		return nil
 	}
 
-	// Check if Optimism rate is stale and submit
-	err = t.submitOptimismPrice()
-	if err != nil {
-		// Error is not fatal for this task so print and continue
-		t.log.Printf("Error submitting Optimism price: %q\n", err)
-	}
-
-	// Check if Polygon rate is stale and submit
-	err = t.submitPolygonPrice()
-	if err != nil {
-		// Error is not fatal for this task so print and continue
-		t.log.Printf("Error submitting Polygon price: %q\n", err)
-	}
-
-	// Check if Arbitrum rate is stale and submit
-	err = t.submitArbitrumPrice()
-	if err != nil {
-		// Error is not fatal for this task so print and continue
-		t.log.Printf("Error submitting Arbitrum price: %q\n", err)
-	}
-
-	// Check if zkSync rate is stale and submit
-	err = t.submitZkSyncEraPrice()
-	if err != nil {
-		// Error is not fatal for this task so print and continue
-		t.log.Printf("Error submitting zkSync Era price: %q\n", err)
+	// The L2 rate updates don't have a result worth comparing, so they're skipped in shadow mode
+	if !t.shadow.isActive() {
+		t.submitL2Prices()
 	}
 
 	// Log
@@ -339,109 +226,84 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 		return nil
 	}
 
-	// Check if the process is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Prices report is already running in the background.")
-		t.lock.Unlock()
-		return nil
+	// Log
+	t.log.Printlnf("Getting RPL price for block %d...", blockNumber)
+
+	// Get RPL price at block
+	inputs, err := t.getRplPrice(blockNumber, targetEpoch)
+	if err != nil {
+		return err
 	}
-	t.lock.Unlock()
-
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		logPrefix := "[Price Report]"
-		t.log.Printlnf("%s Starting price report in a separate thread.", logPrefix)
-
-		// Log
-		t.log.Printlnf("Getting RPL price for block %d...", blockNumber)
-
-		// Get RPL price at block
-		var rplPrice *big.Int
-		twapEpoch := t.cfg.Smartnode.RplTwapEpoch.Value.(uint64)
-		if targetEpoch < twapEpoch {
-			rplPrice, err = t.getRplPrice(blockNumber)
-		} else {
-			rplPrice, err = t.getRplTwap(blockNumber)
+	rplPrice := inputs.RplPrice
+
+	// Calculate the total effective RPL stake on the network
+	zero := new(big.Int).SetUint64(0)
+	var effectiveRplStake *big.Int
+	if !isAtlasDeployed {
+		nodeStakingAddress := t.cfg.Smartnode.GetV110NodeStakingAddress()
+		effectiveRplStake, err = v110_node.CalculateTotalEffectiveRPLStake(t.rp, zero, zero, rplPrice, nil, &nodeStakingAddress)
+		if err != nil {
+			return fmt.Errorf("error getting total effective RPL stake: %w", err)
 		}
+	} else {
+		_, effectiveRplStake, err = state.CalculateTrueEffectiveStakes(false)
 		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-			return
+			return fmt.Errorf("error getting total effective RPL stake: %w", err)
 		}
+	}
 
-		// Calculate the total effective RPL stake on the network
-		zero := new(big.Int).SetUint64(0)
-		var effectiveRplStake *big.Int
-		if !isAtlasDeployed {
-			nodeStakingAddress := t.cfg.Smartnode.GetV110NodeStakingAddress()
-			effectiveRplStake, err = v110_node.CalculateTotalEffectiveRPLStake(t.rp, zero, zero, rplPrice, nil, &nodeStakingAddress)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s error getting total effective RPL stake: %w", logPrefix, err))
-				return
-			}
-		} else {
-			_, effectiveRplStake, err = state.CalculateTrueEffectiveStakes(false)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s error getting total effective RPL stake: %w", logPrefix, err))
-				return
-			}
-		}
+	inputs.EffectiveRplStake = effectiveRplStake
 
-		// Log
-		t.log.Printlnf("RPL price: %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
+	// Log
+	t.log.Printlnf("RPL price: %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
 
-		// Check if we have reported these specific values before
-		hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(nodeAccount.Address, blockNumber, rplPrice, effectiveRplStake, isAtlasDeployed)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-			return
-		}
-		if hasSubmittedSpecific {
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-			return
-		}
+	// In shadow mode, record the submission instead of making it
+	if t.shadow.isActive() {
+		t.proposeRplPrice(blockNumber, rplPrice, effectiveRplStake, isAtlasDeployed)
+		return nil
+	}
 
-		// We haven't submitted these values, check if we've submitted any for this block so we can log it
-		hasSubmitted, err := t.hasSubmittedBlockPrices(nodeAccount.Address, blockNumber)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-			return
-		}
-		if hasSubmitted {
-			t.log.Printlnf("Have previously submitted out-of-date prices for block %d, trying again...", blockNumber)
-		}
+	// Check if we have reported these specific values before
+	hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(nodeAccount.Address, blockNumber, rplPrice, effectiveRplStake, isAtlasDeployed)
+	if err != nil {
+		return err
+	}
+	if hasSubmittedSpecific {
+		return nil
+	}
 
-		// Log
-		t.log.Println("Submitting RPL price...")
+	// We haven't submitted these values, check if we've submitted any for this block so we can log it
+	hasSubmitted, err := t.hasSubmittedBlockPrices(nodeAccount.Address, blockNumber)
+	if err != nil {
+		return err
+	}
+	if hasSubmitted {
+		t.log.Printlnf("Have previously submitted out-of-date prices for block %d, trying again...", blockNumber)
+	}
 
-		// Submit RPL price
-		if err := t.submitRplPrice(blockNumber, rplPrice, effectiveRplStake, isAtlasDeployed); err != nil {
-			t.handleError(fmt.Errorf("%s could not submit RPL price: %w", logPrefix, err))
-			return
-		}
+	// Log
+	t.log.Println("Submitting RPL price...")
 
-		// Log and return
-		t.log.Printlnf("%s Price report complete.", logPrefix)
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
+	// Submit RPL price
+	if err := t.submitRplPrice(blockNumber, slotNumber, rplPrice, effectiveRplStake, inputs, isAtlasDeployed); err != nil {
+		return fmt.Errorf("could not submit RPL price: %w", err)
+	}
 
-	// Return
+	// Log and return
+	t.log.Println("Price report complete.")
 	return nil
 
 }
 
-func (t *submitRplPrice) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Price report failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
+// Update the RPL rates on the L2 networks whose rates are stale
+func (t *submitRplPrice) submitL2Prices() {
+	for _, messenger := range t.l2Messengers {
+		err := t.submitL2Price(messenger)
+		if err != nil {
+			// Error is not fatal for this task so print and continue
+			t.log.Printf("Error submitting %s price: %q\n", messenger.GetName(), err)
+		}
+	}
 }
 
 // Check whether prices for a block has already been submitted by the node
@@ -477,140 +339,173 @@ func (t *submitRplPrice) hasSubmittedSpecificBlockPrices(nodeAddress common.Addr
 	}
 }
 
-// Get RPL price at block
-func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*big.Int, error) {
-
-	// Require 1inch oracle contract
-	if err := services.RequireOneInchOracle(t.c); err != nil {
-		return nil, err
-	}
+// Record the RPL price submission that would have been made in shadow mode
+func (t *submitRplPrice) proposeRplPrice(blockNumber uint64, rplPrice, effectiveRplStake *big.Int, isAtlasDeployed bool) {
+	t.shadow.propose(shadowProposal{
+		Task:        "submit-rpl-price",
+		Key:         fmt.Sprintf("prices-%d", blockNumber),
+		Description: fmt.Sprintf("submitPrices(block %d, RPL price %s wei, effective RPL stake %s wei)", blockNumber, rplPrice.String(), effectiveRplStake.String()),
+		CheckMember: func(member common.Address) (shadowVote, error) {
+			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(member, blockNumber, rplPrice, effectiveRplStake, isAtlasDeployed)
+			if err != nil {
+				return shadowVote_None, err
+			}
+			if hasSubmittedSpecific {
+				return shadowVote_Match, nil
+			}
+			hasSubmitted, err := t.hasSubmittedBlockPrices(member, blockNumber)
+			if err != nil {
+				return shadowVote_None, err
+			}
+			if hasSubmitted {
+				return shadowVote_Mismatch, nil
+			}
+			return shadowVote_None, nil
+		},
+		CheckOutcome: func() (shadowOutcome, error) {
+			pricesBlock, err := network.GetPricesBlock(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if pricesBlock < blockNumber {
+				return shadowOutcome_Pending, nil
+			}
+			if pricesBlock > blockNumber {
+				return shadowOutcome_Superseded, nil
+			}
+			networkPrice, err := network.GetRPLPrice(t.rp, nil)
+			if err != nil {
+				return shadowOutcome_Pending, err
+			}
+			if networkPrice.Cmp(rplPrice) != 0 {
+				return shadowOutcome_Mismatch, nil
+			}
+			return shadowOutcome_Match, nil
+		},
+	})
+}
 
-	// Get RPL token address
-	rplAddress := common.HexToAddress(t.cfg.Smartnode.GetRplTokenAddress())
+// Get the sources to read the RPL price from; the first one is the one whose price gets submitted
+func (t *submitRplPrice) getPriceSources(targetEpoch uint64) ([]rplPriceSource, error) {
 
-	// Initialize call options
-	opts := &bind.CallOpts{
-		BlockNumber: big.NewInt(int64(blockNumber)),
+	// Before the switch to TWAP, the 1inch oracle is the only source
+	twapEpoch := t.cfg.Smartnode.RplTwapEpoch.Value.(uint64)
+	if targetEpoch < twapEpoch {
+		return []rplPriceSource{
+			newOneInchPriceSource(t.c, t.cfg, t.rp, t.printMessage),
+		}, nil
 	}
 
-	// Get a client with the block number available
-	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
-	if err != nil {
-		return nil, err
+	// Use the main TWAP pool first
+	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
+	if poolAddress == "" {
+		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
+	}
+	mainPool := common.HexToAddress(poolAddress)
+	sources := []rplPriceSource{
+		newUniswapTwapPriceSource(t.cfg, t.rp, mainPool, twapNumberOfSeconds, t.printMessage),
 	}
 
-	// Generate an OIO wrapper using the client
-	oio, err := contracts.NewOneInchOracle(common.HexToAddress(t.cfg.Smartnode.GetOneInchOracleAddress()), client.Client)
+	// Add the pools to cross-check it against
+	checkPools, err := parseRplPriceCheckPools(t.cfg.Smartnode.RplPriceCheckPools.Value.(string))
 	if err != nil {
 		return nil, err
 	}
-
-	// Get RPL price
-	rplPrice, err := oio.GetRateToEth(opts, rplAddress, true)
-	if err != nil {
-		return nil, fmt.Errorf("could not get RPL price at block %d: %w", blockNumber, err)
+	for _, pool := range checkPools {
+		if pool == mainPool {
+			continue
+		}
+		sources = append(sources, newUniswapTwapPriceSource(t.cfg, t.rp, pool, twapNumberOfSeconds, t.printMessage))
 	}
-
-	// Get the previously reported price
-	previousPrice, err := network.GetRPLPrice(t.rp, opts)
-	if err != nil {
-		return nil, fmt.Errorf("could not get previous RPL price at block %d: %w", blockNumber, err)
+	if t.cfg.Smartnode.RplPriceCheckOneInch.Value == true {
+		sources = append(sources, newOneInchPriceSource(t.c, t.cfg, t.rp, t.printMessage))
 	}
 
-	// See if the new price is lower than the decrease threshold
-	one := eth.EthToWei(1)
-	decreaseThresholdBig := eth.EthToWei(RplPriceDecreaseDeviationThreshold)
-	oldDecreaseThreshold := big.NewInt(0)
-	oldDecreaseThreshold.Mul(previousPrice, decreaseThresholdBig).Div(oldDecreaseThreshold, one)
-	if rplPrice.Cmp(oldDecreaseThreshold) == -1 {
-		t.log.Println("=== RPL PRICE ANOMALY DETECTED ===")
-		t.log.Printlnf("Previous RPL Price: %s", previousPrice.String())
-		t.log.Printlnf("Min Allowed Price:  %s", oldDecreaseThreshold.String())
-		t.log.Printlnf("CURRENT RPL PRICE:  %s", rplPrice.String())
-		t.log.Println("==================================")
+	return sources, nil
 
-		return nil, fmt.Errorf("rpl price decreased beyond the allowed threshold")
-	}
+}
 
-	// See if the new price is higher than the increase threshold
-	increaseThresholdBig := eth.EthToWei(RplPriceIncreaseDeviationThreshold)
-	oldIncreaseThreshold := big.NewInt(0)
-	oldIncreaseThreshold.Mul(previousPrice, increaseThresholdBig).Div(oldIncreaseThreshold, one)
-	if rplPrice.Cmp(oldIncreaseThreshold) == 1 {
-		t.log.Println("=== RPL PRICE ANOMALY DETECTED ===")
-		t.log.Printlnf("Previous RPL Price: %s", previousPrice.String())
-		t.log.Printlnf("Max Allowed Price:  %s", oldIncreaseThreshold.String())
-		t.log.Printlnf("CURRENT RPL PRICE:  %s", rplPrice.String())
-		t.log.Println("==================================")
+// Get RPL price at block from each of the price sources, making sure they agree with each other and with the previous price
+func (t *submitRplPrice) getRplPrice(blockNumber uint64, targetEpoch uint64) (rplPriceInputs, error) {
 
-		return nil, fmt.Errorf("rpl price increased beyond the allowed threshold")
+	// Get the price from each source
+	sources, err := t.getPriceSources(targetEpoch)
+	if err != nil {
+		return rplPriceInputs{}, err
+	}
+	readings := []rplPriceReading{}
+	for _, source := range sources {
+		reading, err := source.GetPrice(blockNumber)
+		if err != nil {
+			t.coll.RecordSourceError(source.GetName())
+			return rplPriceInputs{}, fmt.Errorf("error getting RPL price from %s: %w", source.GetName(), err)
+		}
+		readings = append(readings, reading)
 	}
 
-	// Return
-	return rplPrice, nil
-
-}
-
-// Get RPL price via TWAP at block
-func (t *submitRplPrice) getRplTwap(blockNumber uint64) (*big.Int, error) {
-
-	// Initialize call options
+	// Get the previously reported price
 	opts := &bind.CallOpts{
 		BlockNumber: big.NewInt(int64(blockNumber)),
 	}
-
-	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
-	if poolAddress == "" {
-		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
-	}
-
-	// Get a client with the block number available
-	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
+	previousPrice, err := network.GetRPLPrice(t.rp, opts)
 	if err != nil {
-		return nil, err
+		return rplPriceInputs{}, fmt.Errorf("could not get previous RPL price at block %d: %w", blockNumber, err)
+	}
+
+	// Make sure every source is close enough to the others
+	rplPrice := readings[0].Price
+	medianPrice := getMedianRplPrice(readings)
+	deviations := getSourceDeviations(readings, medianPrice)
+	reference := "the median price"
+	if len(readings) == 2 {
+		reference = "the other source"
+	}
+	maxDeviation := t.cfg.Smartnode.RplPriceMaxSourceDeviation.Value.(float64)
+	problems := []string{}
+	for i, reading := range readings {
+		deviation := deviations[i]
+		t.coll.RecordSourcePrice(reading.Source, eth.WeiToEth(reading.Price), deviation)
+		if len(readings) > 1 {
+			t.log.Printlnf("RPL price from %s: %.6f ETH (%.2f%% from %s)", reading.Source, eth.WeiToEth(reading.Price), deviation, reference)
+			if deviation > maxDeviation {
+				problems = append(problems, fmt.Sprintf("%s is %.2f%% from %s, more than the allowed %.2f%%", reading.Source, deviation, reference, maxDeviation))
+			}
+		}
 	}
 
-	// Construct the pool contract instance
-	parsed, err := abi.JSON(strings.NewReader(RplTwapPoolAbi))
-	if err != nil {
-		return nil, fmt.Errorf("error decoding RPL TWAP pool ABI: %w", err)
-	}
-	addr := common.HexToAddress(poolAddress)
-	poolContract := bind.NewBoundContract(addr, parsed, client.Client, client.Client, client.Client)
-	pool := rocketpool.Contract{
-		Contract: poolContract,
-		Address:  &addr,
-		ABI:      &parsed,
-		Client:   client.Client,
+	// Make sure the price hasn't moved too far since the previous submission
+	maxChange := t.cfg.Smartnode.RplPriceMaxChange.Value.(float64)
+	change := float64(0)
+	if previousPrice.Sign() > 0 {
+		change = getPriceDeviation(rplPrice, previousPrice)
+		if change > maxChange {
+			problems = append(problems, fmt.Sprintf("the price moved %.2f%% from the previous on-chain price, more than the allowed %.2f%%", change, maxChange))
+		}
 	}
+	t.coll.RecordCheck(eth.WeiToEth(medianPrice), change, len(problems) > 0)
 
-	// Get RPL price
-	response := poolObserveResponse{}
-	interval := twapNumberOfSeconds
-	args := []uint32{interval, 0}
+	if len(problems) > 0 {
+		t.log.Println("=== RPL PRICE ANOMALY DETECTED ===")
+		t.log.Printlnf("Previous RPL Price: %s", previousPrice.String())
+		t.log.Printlnf("Median RPL Price:   %s", medianPrice.String())
+		for _, reading := range readings {
+			t.log.Printlnf("%s: %s", reading.Source, reading.Price.String())
+		}
+		for _, problem := range problems {
+			t.log.Printlnf("PROBLEM: %s", problem)
+		}
+		t.log.Println("==================================")
 
-	err = pool.Call(opts, &response, "observe", args)
-	if err != nil {
-		return nil, fmt.Errorf("could not get RPL price at block %d: %w", blockNumber, err)
+		return rplPriceInputs{}, fmt.Errorf("RPL price check failed: %s", strings.Join(problems, "; "))
 	}
 
-	tick := big.NewInt(0).Sub(response.TickCumulatives[1], response.TickCumulatives[0])
-	tick.Div(tick, big.NewInt(int64(interval))) // tick = (cumulative[1] - cumulative[0]) / interval
-
-	base := eth.EthToWei(1.0001) // 1.0001e18
-	one := eth.EthToWei(1)       // 1e18
-
-	numerator := big.NewInt(0).Exp(base, tick, nil) // 1.0001e18 ^ tick
-	numerator.Mul(numerator, one)
-
-	denominator := big.NewInt(0).Exp(one, tick, nil) // 1e18 ^ tick
-	denominator.Div(numerator, denominator)          // denominator = (1.0001e18^tick / 1e18^tick)
-
-	numerator.Mul(one, one)                               // 1e18 ^ 2
-	rplPrice := big.NewInt(0).Div(numerator, denominator) // 1e18 ^ 2 / (1.0001e18^tick * 1e18 / 1e18^tick)
-
 	// Return
-	return rplPrice, nil
+	return rplPriceInputs{
+		Sources:       readings,
+		MedianPrice:   medianPrice,
+		PreviousPrice: previousPrice,
+		RplPrice:      rplPrice,
+	}, nil
 
 }
 
@@ -619,7 +514,7 @@ func (t *submitRplPrice) printMessage(message string) {
 }
 
 // Submit RPL price and total effective RPL stake
-func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveRplStake *big.Int, isAtlasDeployed bool) error {
+func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotNumber uint64, rplPrice, effectiveRplStake *big.Int, inputs rplPriceInputs, isAtlasDeployed bool) error {
 
 	// Log
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
@@ -681,7 +576,13 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, hash, t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "submit-rpl-price",
+		Action: "submitPrices",
+		Slot:   slotNumber,
+		Block:  blockNumber,
+	}, inputs, hash, receipt, err)
 	if err != nil {
 		return err
 	}
@@ -694,195 +595,95 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 
 }
 
-// Checks if Optimism rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitOptimismPrice() error {
-	priceMessengerAddress := t.cfg.Smartnode.GetOptimismMessengerAddress()
+// Checks if a messenger's rate is stale and if it's our turn to submit, calls submitRate on it.
+// A failed submission is tried again on a later run once the messenger's retry delay has passed, up to its number of attempts per turn.
+func (t *submitRplPrice) submitL2Price(messenger L2PriceMessenger) error {
+	name := messenger.GetName()
 
-	if priceMessengerAddress == "" {
-		// No price messenger deployed on the current network
-		return nil
-	}
-
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	// Get the node account
+	nodeAccount, err := t.w.GetNodeAccount()
 	if err != nil {
-		return fmt.Errorf("Failed getting transactor: %q", err)
+		return fmt.Errorf("error getting node account: %w", err)
 	}
 
 	// Construct the price messenger contract instance
-	parsed, err := abi.JSON(strings.NewReader(OptimismMessengerAbi))
+	parsed, err := abi.JSON(strings.NewReader(messenger.GetAbi()))
 	if err != nil {
-		return fmt.Errorf("Failed decoding ABI: %q", err)
+		return fmt.Errorf("error decoding %s messenger ABI: %w", name, err)
 	}
-
-	addr := common.HexToAddress(priceMessengerAddress)
-	priceMessengerContract := bind.NewBoundContract(addr, parsed, t.ec, t.ec, t.ec)
-	priceMessenger := rocketpool.Contract{
-		Contract: priceMessengerContract,
+	addr := common.HexToAddress(messenger.GetAddress())
+	priceMessenger := &rocketpool.Contract{
+		Contract: bind.NewBoundContract(addr, parsed, t.ec, t.ec, t.ec),
 		Address:  &addr,
 		ABI:      &parsed,
 		Client:   t.ec,
 	}
 
-	// Check if the rate is stale
-	var out []interface{}
-	err = priceMessengerContract.Call(nil, &out, "rateStale")
-
+	// Check the rate, since another member may have submitted it since the last failure
+	isReady, blockNumber, err := t.isL2SubmissionReady(name, priceMessenger, nodeAccount.Address)
 	if err != nil {
-		return fmt.Errorf("Failed to query rate staleness for Optimism: %q", err)
+		return err
 	}
-
-	rateStale := *abi.ConvertType(out[0], new(bool)).(*bool)
-
-	if !rateStale {
-		// Nothing to do
+	if !isReady {
 		return nil
 	}
 
-	// Get total number of ODAO members
-	count, err := trustednode.GetMemberCount(t.rp, nil)
-	if err != nil {
-		return fmt.Errorf("Failed to get member count: %q", err)
+	// Check if a failed submission is waiting to be retried during this turn
+	policy := messenger.GetPolicy()
+	turn := blockNumber / BlocksPerTurn
+	retry, exists := t.l2Retries[messenger.GetId()]
+	if !exists || retry.turn != turn {
+		retry = &l2RetryState{turn: turn}
 	}
-
-	// Find out which index we are
-	var index = uint64(0)
-	for i := uint64(0); i < count; i++ {
-		addr, err := trustednode.GetMemberAt(t.rp, i, nil)
-		if err != nil {
-			return fmt.Errorf("Failed to get member at %d: %q", i, err)
-		}
-
-		if bytes.Compare(addr.Bytes(), opts.From.Bytes()) == 0 {
-			index = i
-			break
-		}
+	if retry.attempts >= policy.Attempts {
+		return nil
 	}
-
-	// Get current block number
-	blockNumber, err := t.ec.BlockNumber(context.Background())
-	if err != nil {
-		return fmt.Errorf("Failed to get block number: %q", err)
+	if time.Now().Before(retry.retryAfter) {
+		t.log.Printlnf("Waiting until %s to try submitting the %s price again.", retry.retryAfter.Format(time.RFC3339), name)
+		return nil
 	}
 
-	// Calculate whose turn it is to submit
-	indexToSubmit := (blockNumber / BlocksPerTurn) % count
-
-	if index == indexToSubmit {
-
-		// Temporary gas calculations until this gets put into a binding
-		input, err := priceMessenger.ABI.Pack("submitRate")
-		if err != nil {
-			return fmt.Errorf("Could not encode input data: %w", err)
-		}
-
-		// Estimate gas limit
-		gasLimit, err := t.rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
-			From:     opts.From,
-			To:       priceMessenger.Address,
-			GasPrice: big.NewInt(0), // use 0 gwei for simulation
-			Value:    opts.Value,
-			Data:     input,
-		})
-		if err != nil {
-			return fmt.Errorf("Error estimating gas limit of submitOptimismPrice: %w", err)
-		}
-
-		// Get the safe gas limit
-		safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
-		if gasLimit > rocketpool.MaxGasLimit {
-			gasLimit = rocketpool.MaxGasLimit
-		}
-		if safeGasLimit > rocketpool.MaxGasLimit {
-			safeGasLimit = rocketpool.MaxGasLimit
-		}
-		gasInfo := rocketpool.GasInfo{
-			EstGasLimit:  gasLimit,
-			SafeGasLimit: safeGasLimit,
-		}
-
-		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
-		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-			return nil
+	// Submit it
+	submitted, err := t.sendL2Rate(messenger, priceMessenger, blockNumber)
+	if err == nil {
+		delete(t.l2Retries, messenger.GetId())
+		if submitted {
+			t.l2Coll.RecordSubmission(name, true)
 		}
-
-		// Set the gas settings
-		opts.GasFeeCap = maxFee
-		opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
-		opts.GasLimit = gasInfo.SafeGasLimit
-
-		t.log.Println("Submitting rate to Optimism...")
-
-		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
-		if err != nil {
-			return fmt.Errorf("Failed to submit rate: %q", err)
-		}
-
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Optimism price for block %d.", blockNumber)
-
-	}
-
-	return nil
-}
-
-// Checks if Polygon rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitPolygonPrice() error {
-	priceMessengerAddress := t.cfg.Smartnode.GetPolygonMessengerAddress()
-
-	if priceMessengerAddress == "" {
-		// No price messenger deployed on the current network
 		return nil
 	}
+	t.l2Coll.RecordSubmission(name, false)
 
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
-	if err != nil {
-		return fmt.Errorf("Failed getting transactor: %q", err)
-	}
-
-	// Construct the price messenger contract instance
-	parsed, err := abi.JSON(strings.NewReader(PolygonMessengerAbi))
-	if err != nil {
-		return fmt.Errorf("Failed decoding ABI: %q", err)
+	// Schedule the retry
+	retry.attempts++
+	retry.retryAfter = time.Now().Add(policy.RetryDelay)
+	t.l2Retries[messenger.GetId()] = retry
+	if retry.attempts >= policy.Attempts {
+		return fmt.Errorf("%w (attempt %d of %d, giving up until this node's next turn)", err, retry.attempts, policy.Attempts)
 	}
+	return fmt.Errorf("%w (attempt %d of %d, trying again after %s)", err, retry.attempts, policy.Attempts, policy.RetryDelay)
+}
 
-	addr := common.HexToAddress(priceMessengerAddress)
-	priceMessengerContract := bind.NewBoundContract(addr, parsed, t.ec, t.ec, t.ec)
-	priceMessenger := rocketpool.Contract{
-		Contract: priceMessengerContract,
-		Address:  &addr,
-		ABI:      &parsed,
-		Client:   t.ec,
-	}
+// Check if a messenger's rate is stale and if it's this node's turn to submit it, returning the block the turn was based on
+func (t *submitRplPrice) isL2SubmissionReady(name string, priceMessenger *rocketpool.Contract, nodeAddress common.Address) (bool, uint64, error) {
 
 	// Check if the rate is stale
 	var out []interface{}
-	err = priceMessengerContract.Call(nil, &out, "rateStale")
-
+	err := priceMessenger.Contract.Call(nil, &out, "rateStale")
 	if err != nil {
-		return fmt.Errorf("Failed to query rate staleness for Polygon: %q", err)
+		return false, 0, fmt.Errorf("error querying rate staleness for %s: %w", name, err)
 	}
-
 	rateStale := *abi.ConvertType(out[0], new(bool)).(*bool)
-
 	if !rateStale {
 		// Nothing to do
-		return nil
+		t.l2Coll.RecordCheck(name, false, false)
+		return false, 0, nil
 	}
 
 	// Get total number of ODAO members
 	count, err := trustednode.GetMemberCount(t.rp, nil)
 	if err != nil {
-		return fmt.Errorf("Failed to get member count: %q", err)
+		return false, 0, fmt.Errorf("error getting member count: %w", err)
 	}
 
 	// Find out which index we are
@@ -890,10 +691,10 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 	for i := uint64(0); i < count; i++ {
 		addr, err := trustednode.GetMemberAt(t.rp, i, nil)
 		if err != nil {
-			return fmt.Errorf("Failed to get member at %d: %q", i, err)
+			return false, 0, fmt.Errorf("error getting member at %d: %w", i, err)
 		}
 
-		if bytes.Compare(addr.Bytes(), opts.From.Bytes()) == 0 {
+		if bytes.Equal(addr.Bytes(), nodeAddress.Bytes()) {
 			index = i
 			break
 		}
@@ -902,397 +703,99 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 	// Get current block number
 	blockNumber, err := t.ec.BlockNumber(context.Background())
 	if err != nil {
-		return fmt.Errorf("Failed to get block number: %q", err)
+		return false, 0, fmt.Errorf("error getting block number: %w", err)
 	}
 
 	// Calculate whose turn it is to submit
 	indexToSubmit := (blockNumber / BlocksPerTurn) % count
+	isTurn := (index == indexToSubmit)
+	t.l2Coll.RecordCheck(name, true, isTurn)
+	return isTurn, blockNumber, nil
 
-	if index == indexToSubmit {
-
-		// Temporary gas calculations until this gets put into a binding
-		input, err := priceMessenger.ABI.Pack("submitRate")
-		if err != nil {
-			return fmt.Errorf("Could not encode input data for Polygon price submission: %w", err)
-		}
-
-		// Estimate gas limit
-		gasLimit, err := t.rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
-			From:     opts.From,
-			To:       priceMessenger.Address,
-			GasPrice: big.NewInt(0), // use 0 gwei for simulation
-			Value:    opts.Value,
-			Data:     input,
-		})
-		if err != nil {
-			return fmt.Errorf("Error estimating gas limit of submitPolygonPrice: %w", err)
-		}
-
-		// Get the safe gas limit
-		safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
-		if gasLimit > rocketpool.MaxGasLimit {
-			gasLimit = rocketpool.MaxGasLimit
-		}
-		if safeGasLimit > rocketpool.MaxGasLimit {
-			safeGasLimit = rocketpool.MaxGasLimit
-		}
-		gasInfo := rocketpool.GasInfo{
-			EstGasLimit:  gasLimit,
-			SafeGasLimit: safeGasLimit,
-		}
-
-		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
-		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-			return nil
-		}
-
-		// Set the gas settings
-		opts.GasFeeCap = maxFee
-		opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
-		opts.GasLimit = gasInfo.SafeGasLimit
-
-		t.log.Println("Submitting rate to Polygon...")
-
-		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
-		if err != nil {
-			return fmt.Errorf("Failed to submit rate to Polygon: %q", err)
-		}
-
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Polygon price for block %d.", blockNumber)
-
-	}
-
-	return nil
 }
 
-// Checks if Arbitrum rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitArbitrumPrice() error {
-	priceMessengerAddress := t.cfg.Smartnode.GetArbitrumMessengerAddress()
-
-	if priceMessengerAddress == "" {
-		// No price messenger deployed on the current network
-		return nil
-	}
+// Call submitRate on a messenger; returns false without an error if the submission was skipped because gas is too high
+func (t *submitRplPrice) sendL2Rate(messenger L2PriceMessenger, priceMessenger *rocketpool.Contract, blockNumber uint64) (bool, error) {
+	name := messenger.GetName()
+	policy := messenger.GetPolicy()
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
-		return fmt.Errorf("Failed getting transactor: %q", err)
+		return false, fmt.Errorf("error getting transactor: %w", err)
 	}
 
-	// Construct the price messenger contract instance
-	parsed, err := abi.JSON(strings.NewReader(ArbitrumMessengerAbi))
+	// Get the arguments and the ETH to send with them
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	submission, err := messenger.GetSubmission(maxFee)
 	if err != nil {
-		return fmt.Errorf("Failed decoding ABI: %q", err)
-	}
-
-	addr := common.HexToAddress(priceMessengerAddress)
-	priceMessengerContract := bind.NewBoundContract(addr, parsed, t.ec, t.ec, t.ec)
-	priceMessenger := rocketpool.Contract{
-		Contract: priceMessengerContract,
-		Address:  &addr,
-		ABI:      &parsed,
-		Client:   t.ec,
+		return false, err
 	}
+	opts.Value = submission.Value
 
-	// Check if the rate is stale
-	var out []interface{}
-	err = priceMessengerContract.Call(nil, &out, "rateStale")
-
+	// Temporary gas calculations until this gets put into a binding
+	input, err := priceMessenger.ABI.Pack("submitRate", submission.Args...)
 	if err != nil {
-		return fmt.Errorf("Failed to query rate staleness for Arbitrum: %q", err)
-	}
-
-	rateStale := *abi.ConvertType(out[0], new(bool)).(*bool)
-
-	if !rateStale {
-		// Nothing to do
-		return nil
+		return false, fmt.Errorf("could not encode input data for %s price submission: %w", name, err)
 	}
 
-	// Get total number of ODAO members
-	count, err := trustednode.GetMemberCount(t.rp, nil)
+	// Estimate gas limit
+	gasLimit, err := t.rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:     opts.From,
+		To:       priceMessenger.Address,
+		GasPrice: big.NewInt(0), // use 0 gwei for simulation
+		Value:    opts.Value,
+		Data:     input,
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to get member count: %q", err)
+		return false, fmt.Errorf("error estimating gas limit of %s price submission: %w", name, err)
 	}
 
-	// Find out which index we are
-	var index = uint64(0)
-	for i := uint64(0); i < count; i++ {
-		addr, err := trustednode.GetMemberAt(t.rp, i, nil)
-		if err != nil {
-			return fmt.Errorf("Failed to get member at %d: %q", i, err)
-		}
-
-		if bytes.Compare(addr.Bytes(), opts.From.Bytes()) == 0 {
-			index = i
-			break
-		}
+	// Get the safe gas limit
+	safeGasLimit := uint64(float64(gasLimit) * policy.GasLimitMultiplier)
+	if gasLimit > rocketpool.MaxGasLimit {
+		gasLimit = rocketpool.MaxGasLimit
 	}
-
-	// Get current block number
-	blockNumber, err := t.ec.BlockNumber(context.Background())
-	if err != nil {
-		return fmt.Errorf("Failed to get block number: %q", err)
+	if safeGasLimit > rocketpool.MaxGasLimit {
+		safeGasLimit = rocketpool.MaxGasLimit
 	}
-
-	// Calculate whose turn it is to submit
-	indexToSubmit := (blockNumber / BlocksPerTurn) % count
-
-	if index == indexToSubmit {
-
-		// Get the current network recommended max fee
-		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
-		if err != nil {
-			return fmt.Errorf("error getting recommended base fee from the network for Arbitrum price submission: %w", err)
-		}
-
-		// Constants for Arbitrum
-		bufferMultiplier := big.NewInt(4)
-		dataLength := big.NewInt(36)
-		arbitrumGasLimit := big.NewInt(40000)
-		arbitrumMaxFeePerGas := eth.GweiToWei(0.1)
-
-		// Gas limit calculation on Arbitrum
-		maxSubmissionCost := big.NewInt(6)
-		maxSubmissionCost.Mul(maxSubmissionCost, dataLength)
-		maxSubmissionCost.Add(maxSubmissionCost, big.NewInt(1400))
-		maxSubmissionCost.Mul(maxSubmissionCost, suggestedMaxFee)  // (1400 + 6 * dataLength) * baseFee
-		maxSubmissionCost.Mul(maxSubmissionCost, bufferMultiplier) // Multiply by the buffer constant for safety
-
-		// Provide enough ETH for the L2 and roundtrip TX's
-		value := big.NewInt(0)
-		value.Mul(arbitrumGasLimit, arbitrumMaxFeePerGas)
-		value.Add(value, maxSubmissionCost)
-		opts.Value = value
-
-		// Temporary gas calculations until this gets put into a binding
-		input, err := priceMessenger.ABI.Pack("submitRate", maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas)
-		if err != nil {
-			return fmt.Errorf("Could not encode input data for Arbitrum price submission: %w", err)
-		}
-
-		// Estimate gas limit
-		gasLimit, err := t.rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
-			From:     opts.From,
-			To:       priceMessenger.Address,
-			GasPrice: big.NewInt(0), // use 0 gwei for simulation
-			Value:    opts.Value,
-			Data:     input,
-		})
-		if err != nil {
-			return fmt.Errorf("Error estimating gas limit of submitArbitrumPrice: %w", err)
-		}
-
-		// Get the safe gas limit
-		safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
-		if gasLimit > rocketpool.MaxGasLimit {
-			gasLimit = rocketpool.MaxGasLimit
-		}
-		if safeGasLimit > rocketpool.MaxGasLimit {
-			safeGasLimit = rocketpool.MaxGasLimit
-		}
-		gasInfo := rocketpool.GasInfo{
-			EstGasLimit:  gasLimit,
-			SafeGasLimit: safeGasLimit,
-		}
-
-		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
-		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-			return nil
-		}
-
-		// Set the gas settings
-		opts.GasFeeCap = maxFee
-		opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
-		opts.GasLimit = gasInfo.SafeGasLimit
-
-		t.log.Println("Submitting rate to Arbitrum...")
-
-		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate", maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas)
-		if err != nil {
-			return fmt.Errorf("Failed to submit Arbitrum rate: %q", err)
-		}
-
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Arbitrum price for block %d.", blockNumber)
-
-	}
-
-	return nil
-}
-
-// Checks if zkSync Era rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitZkSyncEraPrice() error {
-	priceMessengerAddress := t.cfg.Smartnode.GetZkSyncEraMessengerAddress()
-
-	if priceMessengerAddress == "" {
-		// No price messenger deployed on the current network
-		return nil
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  gasLimit,
+		SafeGasLimit: safeGasLimit,
 	}
 
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
-	if err != nil {
-		return fmt.Errorf("Failed getting transactor: %q", err)
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
+		return false, nil
 	}
 
-	// Construct the price messenger contract instance
-	parsed, err := abi.JSON(strings.NewReader(zkSyncEraMessengerAbi))
-	if err != nil {
-		return fmt.Errorf("Failed decoding ABI: %q", err)
-	}
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
+	opts.GasLimit = gasInfo.SafeGasLimit
 
-	addr := common.HexToAddress(priceMessengerAddress)
-	priceMessengerContract := bind.NewBoundContract(addr, parsed, t.ec, t.ec, t.ec)
-	priceMessenger := rocketpool.Contract{
-		Contract: priceMessengerContract,
-		Address:  &addr,
-		ABI:      &parsed,
-		Client:   t.ec,
-	}
-
-	// Check if the rate is stale
-	var out []interface{}
-	err = priceMessengerContract.Call(nil, &out, "rateStale")
+	t.log.Printlnf("Submitting rate to %s...", name)
 
+	// Submit rates
+	tx, err := priceMessenger.Transact(opts, "submitRate", submission.Args...)
 	if err != nil {
-		return fmt.Errorf("Failed to query rate staleness for zkSync Era: %q", err)
-	}
-
-	rateStale := *abi.ConvertType(out[0], new(bool)).(*bool)
-
-	if !rateStale {
-		// Nothing to do
-		return nil
+		return false, fmt.Errorf("error submitting rate to %s: %w", name, err)
 	}
 
-	// Get total number of ODAO members
-	count, err := trustednode.GetMemberCount(t.rp, nil)
-	if err != nil {
-		return fmt.Errorf("Failed to get member count: %q", err)
-	}
-
-	// Find out which index we are
-	var index = uint64(0)
-	for i := uint64(0); i < count; i++ {
-		addr, err := trustednode.GetMemberAt(t.rp, i, nil)
-		if err != nil {
-			return fmt.Errorf("Failed to get member at %d: %q", i, err)
-		}
-
-		if bytes.Compare(addr.Bytes(), opts.From.Bytes()) == 0 {
-			index = i
-			break
-		}
-	}
-
-	// Get current block number
-	blockNumber, err := t.ec.BlockNumber(context.Background())
+	// Print TX info and wait for it to be included in a block
+	receipt, err := api.PrintAndWaitForTransactionReceipt(t.cfg, tx.Hash(), t.rp.Client, t.log)
+	writeAuditRecord(t.log, t.auditLog, t.rp.Client, audit.Record{
+		Task:   "submit-rpl-price",
+		Action: "submitRate",
+		Target: name,
+		Block:  blockNumber,
+	}, submission, tx.Hash(), receipt, err)
 	if err != nil {
-		return fmt.Errorf("Failed to get block number: %q", err)
+		return false, err
 	}
 
-	// Calculate whose turn it is to submit
-	indexToSubmit := (blockNumber / BlocksPerTurn) % count
-
-	if index == indexToSubmit {
-
-		// Constants for zkSync Era
-		l1GasPerPubdataByte := big.NewInt(17)
-		fairL2GasPrice := eth.GweiToWei(0.5)
-		l2GasLimit := big.NewInt(750000)
-		gasPerPubdataByte := big.NewInt(800)
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
-
-		// Value calculation on zkSync Era
-		pubdataPrice := big.NewInt(0).Mul(l1GasPerPubdataByte, maxFee)
-		minL2GasPrice := big.NewInt(0).Add(pubdataPrice, gasPerPubdataByte)
-		minL2GasPrice.Sub(minL2GasPrice, big.NewInt(1))
-		minL2GasPrice.Div(minL2GasPrice, gasPerPubdataByte)
-		gasPrice := big.NewInt(0).Set(fairL2GasPrice)
-		if minL2GasPrice.Cmp(gasPrice) > 0 {
-			gasPrice.Set(minL2GasPrice)
-		}
-		txValue := big.NewInt(0).Mul(l2GasLimit, gasPrice)
-		opts.Value = txValue
-
-		// Temporary gas calculations until this gets put into a binding
-		input, err := priceMessenger.ABI.Pack("submitRate", l2GasLimit, gasPerPubdataByte)
-		if err != nil {
-			return fmt.Errorf("Could not encode input data for zkSync Era price submission: %w", err)
-		}
-
-		// Estimate gas limit
-		gasLimit, err := t.rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
-			From:     opts.From,
-			To:       priceMessenger.Address,
-			GasPrice: big.NewInt(0), // use 0 gwei for simulation
-			Value:    opts.Value,
-			Data:     input,
-		})
-		if err != nil {
-			return fmt.Errorf("Error estimating gas limit of submitZkSyncEraPrice: %w", err)
-		}
-
-		// Get the safe gas limit
-		safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
-		if gasLimit > rocketpool.MaxGasLimit {
-			gasLimit = rocketpool.MaxGasLimit
-		}
-		if safeGasLimit > rocketpool.MaxGasLimit {
-			safeGasLimit = rocketpool.MaxGasLimit
-		}
-		gasInfo := rocketpool.GasInfo{
-			EstGasLimit:  gasLimit,
-			SafeGasLimit: safeGasLimit,
-		}
-
-		// Print the gas info
-		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-			return nil
-		}
-
-		// Set the gas settings
-		opts.GasFeeCap = maxFee
-		opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
-		opts.GasLimit = gasInfo.SafeGasLimit
-
-		t.log.Println("Submitting rate to zkSync Era...")
-
-		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate", l2GasLimit, gasPerPubdataByte)
-		if err != nil {
-			return fmt.Errorf("Failed to submit zkSync Era rate: %q", err)
-		}
-
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted zkSync Era price for block %d.", blockNumber)
-
-	}
+	// Log
+	t.log.Printlnf("Successfully submitted %s price for block %d.", name, blockNumber)
+	return true, nil
 
-	return nil
 }
@@ -3,7 +3,6 @@ package watchtower
 import (
 	"fmt"
 	"math/big"
-	"math/rand"
 	"net/http"
 	"sync"
 	"time"
@@ -15,17 +14,24 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Config
-var minTasksInterval, _ = time.ParseDuration("4m")
-var maxTasksInterval, _ = time.ParseDuration("6m")
-var taskCooldown, _ = time.ParseDuration("5s")
+const (
+	manualTreeCheckInterval time.Duration = 5 * time.Minute
+	challengeCheckInterval  time.Duration = 5 * time.Minute
+	pendingTxCheckInterval  time.Duration = 1 * time.Minute
+	defaultTaskTimeout      time.Duration = 15 * time.Minute
+	treeGenerationTimeout   time.Duration = 6 * time.Hour
+	penaltyScanTimeout      time.Duration = 12 * time.Hour
+)
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -44,6 +50,9 @@ const (
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
 	UpdateColor                    = color.FgHiWhite
+	ShadowColor                    = color.FgHiBlue
+	LeaseColor                     = color.FgBlue
+	PendingTransactionsColor       = color.FgHiBlack
 )
 
 // Register watchtower command
@@ -52,6 +61,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Name:    name,
 		Aliases: aliases,
 		Usage:   "Run Rocket Pool watchtower activity daemon",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "shadow",
+				Usage: "Run in shadow mode: compute every Oracle DAO submission and compare it with the other members' submissions, without signing any transactions",
+			},
+		},
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
@@ -87,19 +102,62 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Let the watchtower's stuck transactions be bumped up to its own max fee instead of the node's automatic transaction threshold
+	txManager := w.GetTxManager()
+	txManager.SetMaxFeeLimit(eth.GweiToWei(getWatchtowerMaxFee(cfg)))
+	pendingTxLog := log.NewColorLogger(PendingTransactionsColor)
+
+	// Cross-check critical reads against the quorum endpoints, if any are configured
+	err = services.EnableQuorumMode(c)
+	if err != nil {
+		return fmt.Errorf("error enabling quorum mode: %w", err)
+	}
+
 	// Initialize the scrub metrics reporter
 	scrubCollector := collectors.NewScrubCollector()
 
+	// Initialize the RPL price metrics reporter
+	priceCollector := collectors.NewPriceCollector()
+
+	// Initialize the L2 price messenger metrics reporter
+	l2PriceCollector := collectors.NewL2PriceCollector()
+
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
+	// In shadow mode, the submission tasks record their transactions instead of sending them
+	shadowMode := c.Bool("shadow")
+	shadowCollector := collectors.NewShadowCollector()
+	var shadow *shadowRecorder
+	var keeper *leaseKeeper
+	var leaseCollector *collectors.LeaseCollector
+	if shadowMode {
+		shadow = newShadowRecorder(rp, log.NewColorLogger(ShadowColor), shadowCollector, nil)
+		updateLog.Println("Shadow mode is enabled, no transactions will be submitted.")
+	} else if cfg.Smartnode.WatchtowerHaEnabled.Value == true {
+		// With coordination enabled, only the instance holding the lease sends transactions and the standbys run in shadow mode
+		keeper, err = newLeaseKeeper(cfg, log.NewColorLogger(LeaseColor), errorLog)
+		if err != nil {
+			return err
+		}
+		keeper.refresh()
+		leaseCollector = keeper.coll
+		shadow = newShadowRecorder(rp, log.NewColorLogger(ShadowColor), shadowCollector, func() bool {
+			return !keeper.isLeaderNow()
+		})
+		updateLog.Printlnf("Watchtower coordination is enabled, using lease file %s.", cfg.Smartnode.GetWatchtowerLeasePath(true))
+	}
+
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
 		return err
 	}
 
+	// Listen for finalization and reorgs so the tasks can run as soon as they happen
+	eventTrigger := services.NewBeaconEventTrigger(bc, &updateLog)
+
 	// Get the node address
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
@@ -111,11 +169,11 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog, priceCollector, l2PriceCollector, shadow)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog, shadow)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
@@ -123,178 +181,255 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector, shadow)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
-	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, shadow)
 	if err != nil {
 		return fmt.Errorf("error during rewards tree check: %w", err)
 	}
-	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
+	processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog, m)
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
-	}*/
+	}
 	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
-	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog)
+	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, shadow)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
 	}
-	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog)
+	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog, shadow)
 	if err != nil {
 		return fmt.Errorf("error during solo migration check: %w", err)
 	}
 
-	intervalDelta := maxTasksInterval - minTasksInterval
-	secondsDelta := intervalDelta.Seconds()
+	// Create the scheduler; each cycle checks the clients and the node's Oracle DAO membership before running any tasks
+	isAtlasDeployedMasterFlag := false
+	prepare := func() (interface{}, error) {
+		// Check the EC status
+		err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+		if err != nil {
+			return nil, err
+		}
+
+		// Check the BC status
+		err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the Beacon block
+		//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
+		latestBlock, err := m.GetLatestBeaconBlock()
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest Beacon block: %w", err)
+		}
+
+		// Check if on the Oracle DAO
+		isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check for Atlas
+		isAtlasDeployed, err := state.IsAtlasDeployed(rp, &bind.CallOpts{
+			BlockNumber: big.NewInt(0).SetUint64(latestBlock.ExecutionBlockNumber),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error checking if Atlas is deployed: %w", err)
+		}
+		if !isAtlasDeployedMasterFlag && isAtlasDeployed {
+			printAtlasMessage(&updateLog)
+			isAtlasDeployedMasterFlag = true
+		}
+
+		return &watchtowerCycle{
+			latestBlock:     latestBlock,
+			isOnOdao:        isOnOdao,
+			isAtlasDeployed: isAtlasDeployed,
+		}, nil
+	}
+	getState := func(data interface{}) (*state.NetworkState, error) {
+		// Only Oracle DAO members and shadow mode need the network state
+		cycle := data.(*watchtowerCycle)
+		if !cycle.isOnOdao && !shadowMode {
+			return nil, nil
+		}
+		return updateNetworkState(m, &updateLog, cycle.latestBlock)
+	}
+	taskScheduler := scheduler.NewScheduler(bc, eventTrigger, prepare, getState, &updateLog, &errorLog)
+
+	// In shadow mode, the submission tasks run whether or not the node is on the Oracle DAO, and the tasks that can't be shadowed don't run at all.
+	// With coordination enabled, the standbys still run the submission tasks in shadow mode but skip the tasks that can't be shadowed.
+	submissionEnabled := isOnOdao
+	signingEnabled := isOnOdao
+	if shadowMode {
+		submissionEnabled = func(data interface{}) bool { return true }
+		signingEnabled = func(data interface{}) bool { return false }
+	} else if keeper != nil {
+		signingEnabled = func(data interface{}) bool { return isOnOdao(data) && keeper.isLeaderNow() }
+	}
+
+	// Register the tasks
+	tasks := []scheduler.Task{
+		{
+			Name:     "generate-rewards-tree",
+			Cadence:  scheduler.Cadence_Interval,
+			Interval: manualTreeCheckInterval,
+			Timeout:  treeGenerationTimeout,
+			Run: func(cycle *scheduler.Cycle) error {
+				return generateRewardsTree.run()
+			},
+		},
+		{
+			Name:       "submit-rewards-tree",
+			Cadence:    scheduler.Cadence_Epoch,
+			Timeout:    treeGenerationTimeout,
+			NeedsState: true,
+			Run: func(cycle *scheduler.Cycle) error {
+				data := cycle.Data.(*watchtowerCycle)
+				return submitRewardsTree.run(cycle, data.isOnOdao, cycle.State, data.latestBlock.Slot, data.isAtlasDeployed)
+			},
+		},
+		{
+			Name:              "respond-challenges",
+			Cadence:           scheduler.Cadence_Interval,
+			Interval:          challengeCheckInterval,
+			Timeout:           defaultTaskTimeout,
+			SendsTransactions: true,
+			Enabled:           signingEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return respondChallenges.run(cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			Name:              "submit-rpl-price",
+			Cadence:           scheduler.Cadence_Epoch,
+			Timeout:           defaultTaskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Enabled:           submissionEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return submitRplPrice.run(cycle.State, cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			Name:              "submit-network-balances",
+			Cadence:           scheduler.Cadence_Epoch,
+			Timeout:           defaultTaskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Enabled:           submissionEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return submitNetworkBalances.run(cycle.State, cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			Name:              "submit-scrub-minipools",
+			Cadence:           scheduler.Cadence_Epoch,
+			Timeout:           defaultTaskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Enabled:           submissionEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return submitScrubMinipools.run(cycle.State, cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			// Scrubs take priority over dissolves for the same minipool
+			Name:              "dissolve-timed-out-minipools",
+			Cadence:           scheduler.Cadence_Epoch,
+			DependsOn:         []string{"submit-scrub-minipools"},
+			Timeout:           defaultTaskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Enabled:           signingEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return dissolveTimedOutMinipools.run(cycle.State, cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			Name:              "cancel-bond-reductions",
+			Cadence:           scheduler.Cadence_Epoch,
+			Timeout:           defaultTaskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Enabled:           submissionEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return cancelBondReductions.run(cycle.State, cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			Name:              "check-solo-migrations",
+			Cadence:           scheduler.Cadence_Epoch,
+			Timeout:           defaultTaskTimeout,
+			NeedsState:        true,
+			SendsTransactions: true,
+			Enabled:           submissionEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return checkSoloMigrations.run(cycle.State, cycle.Data.(*watchtowerCycle).isAtlasDeployed)
+			},
+		},
+		{
+			// This only replaces transactions that were already sent, so it doesn't take the transaction lock; the tasks waiting on them need it to finish
+			Name:     "check-pending-transactions",
+			Cadence:  scheduler.Cadence_Interval,
+			Interval: pendingTxCheckInterval,
+			Timeout:  defaultTaskTimeout,
+			Enabled:  signingEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return txManager.CheckPendingTransactions(&pendingTxLog)
+			},
+		},
+		{
+			// Scanning can take a long time, so this only takes the transaction lock while it submits a penalty
+			Name:    "process-penalties",
+			Cadence: scheduler.Cadence_Finalized,
+			Timeout: penaltyScanTimeout,
+			Enabled: signingEnabled,
+			Run: func(cycle *scheduler.Cycle) error {
+				return processPenalties.run(cycle)
+			},
+		},
+	}
+	if shadow != nil {
+		tasks = append(tasks, scheduler.Task{
+			Name:    "compare-shadow-proposals",
+			Cadence: scheduler.Cadence_Epoch,
+			Timeout: defaultTaskTimeout,
+			Run: func(cycle *scheduler.Cycle) error {
+				return shadow.compare()
+			},
+		})
+	}
+	for _, task := range tasks {
+		err = taskScheduler.AddTask(task)
+		if err != nil {
+			return fmt.Errorf("error adding task %s: %w", task.Name, err)
+		}
+	}
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
 
 	// Run task loop
-	isAtlasDeployedMasterFlag := false
 	go func() {
-		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
-
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Check the BC status
-			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Get the Beacon block
-			//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
-			latestBlock, err := m.GetLatestBeaconBlock()
-			if err != nil {
-				errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Check if on the Oracle DAO
-			isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Run the manual rewards tree generation
-			if err := generateRewardsTree.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			if isOnOdao {
-				// Update the network state
-				state, err := updateNetworkState(m, &updateLog, latestBlock)
-				if err != nil {
-					errorLog.Println(err)
-					time.Sleep(taskCooldown)
-					continue
-				}
-
-				// Check for Atlas
-				if !isAtlasDeployedMasterFlag && state.IsAtlasDeployed {
-					printAtlasMessage(&updateLog)
-					isAtlasDeployedMasterFlag = true
-				}
-
-				// Run the rewards tree submission check
-				if err := submitRewardsTree.run(isOnOdao, state, latestBlock.Slot, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the challenge check
-				if err := respondChallenges.run(isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the price submission check
-				if err := submitRplPrice.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the network balance submission check
-				if err := submitNetworkBalances.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the minipool dissolve check
-				if err := dissolveTimedOutMinipools.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the minipool scrub check
-				if err := submitScrubMinipools.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the bond cancel check
-				if err := cancelBondReductions.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the solo migration check
-				if err := checkSoloMigrations.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				/*time.Sleep(taskCooldown)
-
-				// Run the fee recipient penalty check
-				if err := processPenalties.run(); err != nil {
-					errorLog.Println(err)
-				}*/
-				// DISABLED until MEV-Boost can support it
-			} else {
-				// Check for Atlas
-				isAtlasDeployed, err := state.IsAtlasDeployed(rp, &bind.CallOpts{
-					BlockNumber: big.NewInt(0).SetUint64(latestBlock.ExecutionBlockNumber),
-				})
-				if err != nil {
-					errorLog.Println(fmt.Errorf("error checking if Atlas is deployed: %w", err))
-					time.Sleep(taskCooldown)
-					continue
-				}
-
-				// Run the rewards tree submission check
-				if err := submitRewardsTree.run(isOnOdao, nil, latestBlock.Slot, isAtlasDeployed); err != nil {
-					errorLog.Println(err)
-				}
-			}
-
-			time.Sleep(interval)
-		}
+		taskScheduler.Run()
 		wg.Done()
 	}()
 
+	// Keep the lease up to date; this runs for as long as the daemon does
+	if keeper != nil {
+		go keeper.run()
+	}
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, priceCollector, l2PriceCollector, shadowCollector, leaseCollector, taskScheduler.GetCollector())
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -306,6 +441,18 @@ func run(c *cli.Context) error {
 	return nil
 }
 
+// The watchtower's view of the chain for a scheduler cycle
+type watchtowerCycle struct {
+	latestBlock     beacon.BeaconBlock
+	isOnOdao        bool
+	isAtlasDeployed bool
+}
+
+// Check if the node was on the Oracle DAO at the start of the cycle
+func isOnOdao(data interface{}) bool {
+	return data.(*watchtowerCycle).isOnOdao
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -0,0 +1,238 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Settings
+const (
+	// The largest record the reader will accept; rewards snapshots can carry big inputs
+	maxRecordSize int = 16 * 1024 * 1024
+)
+
+// A single transaction made by a watchtower task, along with the evidence it was based on
+type Record struct {
+	// When the record was written
+	Time time.Time `json:"time"`
+
+	// The name of the task that made the transaction
+	Task string `json:"task"`
+
+	// The contract function that was called
+	Action string `json:"action"`
+
+	// The minipool or other object the transaction was about, if there is one
+	Target string `json:"target,omitempty"`
+
+	// The Beacon slot the submission was based on
+	Slot uint64 `json:"slot,omitempty"`
+
+	// The EL block the submission was based on
+	Block uint64 `json:"block,omitempty"`
+
+	// Why the task made the transaction
+	Reason string `json:"reason,omitempty"`
+
+	// The values the task computed to make the transaction
+	Inputs json.RawMessage `json:"inputs,omitempty"`
+
+	// The transaction hash
+	TxHash common.Hash `json:"txHash"`
+
+	// The gas used by the transaction, if it was mined
+	GasUsed uint64 `json:"gasUsed,omitempty"`
+
+	// The ETH paid for the transaction in wei, if it was mined
+	GasPaid *big.Int `json:"gasPaid,omitempty"`
+
+	// The error the transaction failed with, if it did
+	Error string `json:"error,omitempty"`
+}
+
+// Limits which records are returned when reading the log
+type Filter struct {
+	// Only return records from this task
+	Task string
+
+	// Only return records based on this block or later
+	MinBlock uint64
+
+	// Only return this many of the latest matching records; 0 returns all of them
+	Limit int
+}
+
+// An append-only log of the transactions made by the watchtower, one JSON record per line
+type Log struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new audit log that writes to the provided file
+func NewLog(path string) *Log {
+	return &Log{
+		path: path,
+	}
+}
+
+// Get the path of the log file
+func (l *Log) GetPath() string {
+	return l.path
+}
+
+// Add a record for a transaction to the log, filling in its hash and the gas it paid.
+// receipt is the receipt of the transaction that was mined, if any; it can be a replacement of the one sent with hash, so its hash is the one recorded.
+// txErr is the error the transaction failed with while waiting for it to be mined, if any.
+func (l *Log) RecordTransaction(client rocketpool.ExecutionClient, record Record, inputs interface{}, hash common.Hash, receipt *types.Receipt, txErr error) error {
+	record.TxHash = hash
+	if receipt != nil {
+		record.TxHash = receipt.TxHash
+	}
+	if txErr != nil {
+		record.Error = txErr.Error()
+	}
+
+	// Serialize the inputs
+	if inputs != nil {
+		inputBytes, err := json.Marshal(inputs)
+		if err != nil {
+			return fmt.Errorf("error serializing the inputs for transaction %s: %w", record.TxHash.Hex(), err)
+		}
+		record.Inputs = inputBytes
+	}
+
+	// Get the gas paid if the transaction was mined; a failure here shouldn't lose the rest of the record
+	if receipt != nil {
+		gasPaid, err := getGasPaid(client, receipt)
+		if err == nil {
+			record.GasUsed = receipt.GasUsed
+			record.GasPaid = gasPaid
+		} else if record.Error == "" {
+			record.Error = fmt.Sprintf("error getting the gas paid: %s", err.Error())
+		}
+	}
+
+	return l.Append(record)
+}
+
+// Add a record to the log
+func (l *Log) Append(record Record) error {
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Make sure the folder exists
+	err = os.MkdirAll(filepath.Dir(l.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating audit log folder: %w", err)
+	}
+
+	// Append the record and flush it to disk so it survives a crash
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening audit log [%s]: %w", l.path, err)
+	}
+	defer file.Close()
+	_, err = file.Write(line)
+	if err != nil {
+		return fmt.Errorf("error writing to audit log [%s]: %w", l.path, err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("error syncing audit log [%s]: %w", l.path, err)
+	}
+	return nil
+}
+
+// Read the records in an audit log that match the filter, oldest first.
+// Also returns the numbers of any lines that couldn't be parsed, so the caller can report them.
+func ReadRecords(path string, filter Filter) ([]Record, []int, error) {
+	records := []Record{}
+	malformedLines := []int{}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, malformedLines, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening audit log [%s]: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		// A malformed line is skipped rather than failing the whole read, since one interrupted write shouldn't hide every other record
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			malformedLines = append(malformedLines, lineNumber)
+			continue
+		}
+		if filter.Task != "" && record.Task != filter.Task {
+			continue
+		}
+		if record.Block < filter.MinBlock {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error reading audit log [%s]: %w", path, err)
+	}
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records, malformedLines, nil
+}
+
+// Get the ETH a mined transaction paid for its gas
+func getGasPaid(client rocketpool.ExecutionClient, receipt *types.Receipt) (*big.Int, error) {
+	tx, _, err := client.TransactionByHash(context.Background(), receipt.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting transaction: %w", err)
+	}
+	header, err := client.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting header for block %s: %w", receipt.BlockNumber.String(), err)
+	}
+
+	// The price paid per gas is the base fee plus whatever tip the fee cap left room for
+	gasPrice := tx.GasPrice()
+	if header.BaseFee != nil {
+		tip, err := tx.EffectiveGasTip(header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("error getting effective tip: %w", err)
+		}
+		gasPrice = big.NewInt(0).Add(header.BaseFee, tip)
+	}
+	gasPaid := big.NewInt(0).SetUint64(receipt.GasUsed)
+	gasPaid.Mul(gasPaid, gasPrice)
+	return gasPaid, nil
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution client that only knows about one mined transaction
+type fakeExecutionClient struct {
+	rocketpool.ExecutionClient
+	tx      *types.Transaction
+	baseFee *big.Int
+}
+
+func (c *fakeExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if hash != c.tx.Hash() {
+		return nil, false, errors.New("not found")
+	}
+	return c.tx, false, nil
+}
+
+func (c *fakeExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: number, BaseFee: c.baseFee}, nil
+}
+
+func TestRecordTransaction(t *testing.T) {
+	// The transaction manager replaced the sent transaction with one paying a higher tip
+	sent := common.HexToHash("0x01")
+	replacement := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     3,
+		GasTipCap: big.NewInt(2e9),
+		GasFeeCap: big.NewInt(50e9),
+		Gas:       100000,
+	})
+	client := &fakeExecutionClient{tx: replacement, baseFee: big.NewInt(10e9)}
+	mined := &types.Receipt{TxHash: replacement.Hash(), GasUsed: 50000, BlockNumber: big.NewInt(100), Status: types.ReceiptStatusSuccessful}
+
+	tests := []struct {
+		name            string
+		receipt         *types.Receipt
+		txErr           error
+		expectedHash    common.Hash
+		expectedGasPaid *big.Int
+		expectedError   string
+	}{
+		{
+			name:            "replacement was mined",
+			receipt:         mined,
+			expectedHash:    replacement.Hash(),
+			expectedGasPaid: big.NewInt(50000 * 12e9),
+		},
+		{
+			name:          "nothing was mined",
+			txErr:         errors.New("nonce was used by another transaction"),
+			expectedHash:  sent,
+			expectedError: "nonce was used by another transaction",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			err := NewLog(path).RecordTransaction(client, Record{Task: "test", Action: "submit"}, nil, sent, test.receipt, test.txErr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			records, _, err := ReadRecords(path, Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+			record := records[0]
+			if record.TxHash != test.expectedHash {
+				t.Errorf("expected hash %s, got %s", test.expectedHash.Hex(), record.TxHash.Hex())
+			}
+			if (record.GasPaid == nil) != (test.expectedGasPaid == nil) || (record.GasPaid != nil && record.GasPaid.Cmp(test.expectedGasPaid) != 0) {
+				t.Errorf("expected gas paid %v, got %v", test.expectedGasPaid, record.GasPaid)
+			}
+			if record.Error != test.expectedError {
+				t.Errorf("expected error '%s', got '%s'", test.expectedError, record.Error)
+			}
+		})
+	}
+}
+
+func TestReadRecords(t *testing.T) {
+	contents := `{"task":"a","block":10}
+{"task":"b","block":20}
+
+{"task":"a","blo
+{"task":"a","block":30}
+not json
+`
+
+	tests := []struct {
+		name              string
+		filter            Filter
+		expectedBlocks    []uint64
+		expectedMalformed []int
+	}{
+		{name: "all records", filter: Filter{}, expectedBlocks: []uint64{10, 20, 30}, expectedMalformed: []int{4, 6}},
+		{name: "by task", filter: Filter{Task: "a"}, expectedBlocks: []uint64{10, 30}, expectedMalformed: []int{4, 6}},
+		{name: "by block", filter: Filter{MinBlock: 20}, expectedBlocks: []uint64{20, 30}, expectedMalformed: []int{4, 6}},
+		{name: "latest only", filter: Filter{Limit: 1}, expectedBlocks: []uint64{30}, expectedMalformed: []int{4, 6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+			records, malformedLines, err := ReadRecords(path, test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			blocks := []uint64{}
+			for _, record := range records {
+				blocks = append(blocks, record.Block)
+			}
+			if !reflect.DeepEqual(blocks, test.expectedBlocks) {
+				t.Errorf("expected blocks %v, got %v", test.expectedBlocks, blocks)
+			}
+			if !reflect.DeepEqual(malformedLines, test.expectedMalformed) {
+				t.Errorf("expected malformed lines %v, got %v", test.expectedMalformed, malformedLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package balances
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Network balance info
+type NetworkBalances struct {
+	Block                 uint64   `json:"block"`
+	DepositPool           *big.Int `json:"depositPool"`
+	MinipoolsTotal        *big.Int `json:"minipoolsTotal"`
+	MinipoolsStaking      *big.Int `json:"minipoolsStaking"`
+	DistributorShareTotal *big.Int `json:"distributorShareTotal"`
+	SmoothingPoolShare    *big.Int `json:"smoothingPoolShare"`
+	RETHContract          *big.Int `json:"rethContract"`
+	RETHSupply            *big.Int `json:"rethSupply"`
+	NodeCreditBalance     *big.Int `json:"nodeCreditBalance"`
+}
+
+// The share of a minipool's balance that belongs to rETH holders
+type MinipoolBalanceDetails struct {
+	Address     common.Address         `json:"address"`
+	Status      rptypes.MinipoolStatus `json:"status"`
+	IsStaking   bool                   `json:"isStaking"`
+	UserBalance *big.Int               `json:"userBalance"`
+}
+
+// Get the total ETH balance that's submitted for a set of network balances
+func GetTotalEthBalance(balances NetworkBalances) *big.Int {
+	totalEth := big.NewInt(0)
+	totalEth.Sub(totalEth, balances.NodeCreditBalance)
+	totalEth.Add(totalEth, balances.DepositPool)
+	totalEth.Add(totalEth, balances.MinipoolsTotal)
+	totalEth.Add(totalEth, balances.RETHContract)
+	totalEth.Add(totalEth, balances.DistributorShareTotal)
+	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	return totalEth
+}
+
+// Get the Beacon slot that corresponds to an EL block
+func GetSlotForBlock(header *types.Header, eth2Config beacon.Eth2Config) uint64 {
+	blockTime := time.Unix(int64(header.Time), 0)
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	timeSinceGenesis := blockTime.Sub(genesisTime)
+	return uint64(timeSinceGenesis.Seconds()) / eth2Config.SecondsPerSlot
+}
+
+// Get the network balances from a network state, along with the contribution of each minipool.
+// The client must be able to serve the state at the state's EL block.
+func GetNetworkBalances(logger log.ColorLogger, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, state *state.NetworkState, elBlockHeader *types.Header, slotTime time.Time, isAtlasDeployed bool) (NetworkBalances, []MinipoolBalanceDetails, error) {
+
+	// Data
+	var wg errgroup.Group
+	var depositPoolBalance *big.Int
+	var mpBalanceDetails []MinipoolBalanceDetails
+	var distributorShares []*big.Int
+	var smoothingPoolShare *big.Int
+	rethContractBalance := state.NetworkDetails.RETHBalance
+	rethTotalSupply := state.NetworkDetails.TotalRETHSupply
+	beaconBlock := state.BeaconSlotNumber
+
+	// Get deposit pool balance
+	if isAtlasDeployed {
+		depositPoolBalance = state.NetworkDetails.DepositPoolUserBalance
+	} else {
+		depositPoolBalance = state.NetworkDetails.DepositPoolBalance
+	}
+
+	// Get minipool balance details
+	wg.Go(func() error {
+		mpBalanceDetails = make([]MinipoolBalanceDetails, len(state.MinipoolDetails))
+		for i, mpd := range state.MinipoolDetails {
+			mpBalanceDetails[i] = GetMinipoolBalanceDetails(&mpd, state)
+		}
+		return nil
+	})
+
+	// Get distributor balance details
+	wg.Go(func() error {
+		distributorShares = make([]*big.Int, len(state.NodeDetails))
+		for i, node := range state.NodeDetails {
+			distributorShares[i] = node.DistributorBalanceUserETH // Uses the go-lib based off-chain calculation method instead of the contract method
+		}
+
+		return nil
+	})
+
+	// Get the smoothing pool user share
+	wg.Go(func() error {
+
+		// Get the current interval
+		currentIndex := state.NetworkDetails.RewardIndex
+
+		// Get the start time for the current interval, and how long an interval is supposed to take
+		startTime := state.NetworkDetails.IntervalStart
+		intervalTime := state.NetworkDetails.IntervalDuration
+
+		timeSinceStart := slotTime.Sub(startTime)
+		intervalsPassed := timeSinceStart / intervalTime
+		endTime := slotTime
+
+		// Approximate the staker's share of the smoothing pool balance
+		treegen, err := rprewards.NewTreeGenerator(logger, "[Balances]", rp, cfg, bc, currentIndex, startTime, endTime, beaconBlock, elBlockHeader, uint64(intervalsPassed), state)
+		if err != nil {
+			return fmt.Errorf("error creating merkle tree generator to approximate share of smoothing pool: %w", err)
+		}
+		smoothingPoolShare, err = treegen.ApproximateStakerShareOfSmoothingPool()
+		if err != nil {
+			return fmt.Errorf("error getting approximate share of smoothing pool: %w", err)
+		}
+
+		return nil
+
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return NetworkBalances{}, nil, err
+	}
+
+	// Balances
+	balances := NetworkBalances{
+		Block:                 elBlockHeader.Number.Uint64(),
+		DepositPool:           depositPoolBalance,
+		MinipoolsTotal:        big.NewInt(0),
+		MinipoolsStaking:      big.NewInt(0),
+		DistributorShareTotal: big.NewInt(0),
+		SmoothingPoolShare:    smoothingPoolShare,
+		RETHContract:          rethContractBalance,
+		RETHSupply:            rethTotalSupply,
+		NodeCreditBalance:     big.NewInt(0),
+	}
+
+	// Add minipool balances
+	for _, mp := range mpBalanceDetails {
+		balances.MinipoolsTotal.Add(balances.MinipoolsTotal, mp.UserBalance)
+		if mp.IsStaking {
+			balances.MinipoolsStaking.Add(balances.MinipoolsStaking, mp.UserBalance)
+		}
+	}
+
+	// Add node credits
+	if state.IsAtlasDeployed {
+		for _, node := range state.NodeDetails {
+			balances.NodeCreditBalance.Add(balances.NodeCreditBalance, node.DepositCreditBalance)
+		}
+	}
+
+	// Add distributor shares
+	for _, share := range distributorShares {
+		balances.DistributorShareTotal.Add(balances.DistributorShareTotal, share)
+	}
+
+	// Return
+	return balances, mpBalanceDetails, nil
+
+}
+
+// Get minipool balance details
+func GetMinipoolBalanceDetails(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState) MinipoolBalanceDetails {
+
+	status := mpd.Status
+	userDepositBalance := mpd.UserDepositBalance
+	mpType := mpd.DepositType
+	validator := state.ValidatorDetails[mpd.Pubkey]
+
+	blockEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+
+	details := MinipoolBalanceDetails{
+		Address: mpd.MinipoolAddress,
+		Status:  status,
+	}
+
+	// Ignore vacant minipools
+	if mpd.IsVacant {
+		details.UserBalance = big.NewInt(0)
+		return details
+	}
+
+	// Dissolved minipools don't contribute to rETH
+	if status == rptypes.Dissolved {
+		details.UserBalance = big.NewInt(0)
+		return details
+	}
+
+	// Use user deposit balance if initialized or prelaunch
+	if status == rptypes.Initialized || status == rptypes.Prelaunch {
+		details.UserBalance = userDepositBalance
+		return details
+	}
+
+	// "Broken" LEBs with the Redstone delegates report their total balance minus their node deposit balance
+	if mpd.DepositType == rptypes.Variable && mpd.Version == 2 {
+		brokenBalance := big.NewInt(0).Set(mpd.Balance)
+		brokenBalance.Add(brokenBalance, eth.GweiToWei(float64(validator.Balance)))
+		brokenBalance.Sub(brokenBalance, mpd.NodeRefundBalance)
+		brokenBalance.Sub(brokenBalance, mpd.NodeDepositBalance)
+		details.IsStaking = (validator.Exists && validator.ActivationEpoch < blockEpoch && validator.ExitEpoch > blockEpoch)
+		details.UserBalance = brokenBalance
+		return details
+	}
+
+	// Use user deposit balance if validator not yet active on beacon chain at block
+	if !validator.Exists || validator.ActivationEpoch >= blockEpoch {
+		details.UserBalance = userDepositBalance
+		return details
+	}
+
+	// Here userBalance is CalculateUserShare(beaconBalance + minipoolBalance - refund)
+	userBalance := mpd.UserShareOfBalanceIncludingBeacon
+	details.IsStaking = (validator.ExitEpoch > blockEpoch)
+	if userDepositBalance.Cmp(big.NewInt(0)) == 0 && mpType == rptypes.Full {
+		details.UserBalance = big.NewInt(0).Sub(userBalance, eth.EthToWei(16)) // Remove 16 ETH from the user balance for full minipools in the refund queue
+	} else {
+		details.UserBalance = userBalance
+	}
+	return details
+
+}
@@ -2,9 +2,14 @@ package services
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -15,14 +20,27 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Settings
+const (
+	eventStreamRetryDelay             time.Duration = 10 * time.Second
+	eventStreamPrimaryRecheckInterval time.Duration = 5 * time.Minute
+)
+
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
 type BeaconClientManager struct {
 	primaryBc       beacon.Client
 	fallbackBc      beacon.Client
+	primaryBcUrl    string
+	fallbackBcUrl   string
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// Extra clients that critical reads are cross-checked against in quorum mode
+	quorumBcNames   []string
+	quorumBcs       []beacon.Client
+	quorumThreshold int
 }
 
 // This is a signature for a wrapped Beacon client function that only returns an error
@@ -82,6 +100,8 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 	return &BeaconClientManager{
 		primaryBc:     primaryBc,
 		fallbackBc:    fallbackBc,
+		primaryBcUrl:  primaryProvider,
+		fallbackBcUrl: fallbackProvider,
 		logger:        log.NewColorLogger(color.FgHiBlue),
 		primaryReady:  true,
 		fallbackReady: fallbackBc != nil,
@@ -159,7 +179,9 @@ func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock
 	return result1.(beacon.BeaconBlock), result2.(bool), nil
 }
 
-// Get the Beacon chain's head information
+// Get the Beacon chain's head information.
+// In quorum mode, the finalized checkpoint is cross-checked at the start of the previous epoch instead of at the head, which clients that are a few slots behind
+// wouldn't have yet, and the head only reports finality the quorum agrees on.
 func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
 		return client.GetBeaconHead()
@@ -167,14 +189,41 @@ func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
 	if err != nil {
 		return beacon.BeaconHead{}, err
 	}
-	return result.(beacon.BeaconHead), nil
+	head := result.(beacon.BeaconHead)
+	if !m.IsQuorumEnabled() || head.Epoch == 0 {
+		return head, nil
+	}
+
+	// Check the finalized checkpoint at a pinned state
+	eth2Config, err := m.GetEth2Config()
+	if err != nil {
+		return beacon.BeaconHead{}, err
+	}
+	slot := (head.Epoch - 1) * eth2Config.SlotsPerEpoch
+	checkpoints, err := m.GetFinalityCheckpoints(strconv.FormatUint(slot, 10))
+	if err != nil {
+		return beacon.BeaconHead{}, err
+	}
+	if checkpoints.Finalized.Epoch < head.FinalizedEpoch {
+		head.FinalizedEpoch = checkpoints.Finalized.Epoch
+	}
+	return head, nil
 }
 
 // Get a validator's status by its index
 func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	function := func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatusByIndex(index, opts)
-	})
+	}
+
+	// Head states legitimately differ between clients, so only statuses at a specific slot or epoch are compared
+	var result interface{}
+	var err error
+	if opts != nil && m.IsQuorumEnabled() {
+		result, err = m.runQuorumFunction1(fmt.Sprintf("status of validator %s", index), function, compareValidatorStatuses)
+	} else {
+		result, err = m.runFunction1(function)
+	}
 	if err != nil {
 		return beacon.ValidatorStatus{}, err
 	}
@@ -183,9 +232,18 @@ func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beac
 
 // Get a validator's status by its pubkey
 func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	function := func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatus(pubkey, opts)
-	})
+	}
+
+	// Head states legitimately differ between clients, so only statuses at a specific slot or epoch are compared
+	var result interface{}
+	var err error
+	if opts != nil && m.IsQuorumEnabled() {
+		result, err = m.runQuorumFunction1(fmt.Sprintf("status of validator %s", pubkey.Hex()), function, compareValidatorStatuses)
+	} else {
+		result, err = m.runFunction1(function)
+	}
 	if err != nil {
 		return beacon.ValidatorStatus{}, err
 	}
@@ -194,9 +252,18 @@ func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, o
 
 // Get the statuses of multiple validators by their pubkeys
 func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	function := func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatuses(pubkeys, opts)
-	})
+	}
+
+	// Head states legitimately differ between clients, so only statuses at a specific slot or epoch are compared
+	var result interface{}
+	var err error
+	if opts != nil && m.IsQuorumEnabled() {
+		result, err = m.runQuorumFunction1(fmt.Sprintf("statuses of %d validators", len(pubkeys)), function, compareValidatorStatusMaps)
+	} else {
+		result, err = m.runFunction1(function)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +314,37 @@ func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, use
 	return result.([]byte), nil
 }
 
+// Get the fork at the given state
+func (m *BeaconClientManager) GetFork(stateId string) (beacon.Fork, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetFork(stateId)
+	})
+	if err != nil {
+		return beacon.Fork{}, err
+	}
+	return result.(beacon.Fork), nil
+}
+
+// Get the finality checkpoints of a Beacon state
+func (m *BeaconClientManager) GetFinalityCheckpoints(stateId string) (beacon.FinalityCheckpoints, error) {
+	function := func(client beacon.Client) (interface{}, error) {
+		return client.GetFinalityCheckpoints(stateId)
+	}
+
+	// The checkpoints of the head state legitimately differ between clients, so only the ones at a specific slot are compared
+	var result interface{}
+	var err error
+	if _, parseErr := strconv.ParseUint(stateId, 10, 64); parseErr == nil && m.IsQuorumEnabled() {
+		result, err = m.runQuorumFunction1(fmt.Sprintf("finalized checkpoint at slot %s", stateId), function, compareFinalizedCheckpoints)
+	} else {
+		result, err = m.runFunction1(function)
+	}
+	if err != nil {
+		return beacon.FinalityCheckpoints{}, err
+	}
+	return result.(beacon.FinalityCheckpoints), nil
+}
+
 // Voluntarily exit a validator
 func (m *BeaconClientManager) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
 	err := m.runFunction0(func(client beacon.Client) error {
@@ -296,10 +394,125 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex uint64,
 	return nil
 }
 
+// Subscribe to the Beacon node event stream. If the active stream fails, the subscription fails over to the other client and
+// keeps retrying until one of them accepts it; while on the fallback, it periodically tries to move back to the primary.
+func (m *BeaconClientManager) SubscribeEvents(topics []beacon.EventTopic, ch chan<- beacon.Event) (ethereum.Subscription, error) {
+	// Make sure at least one client can serve the stream before handing back the subscription
+	sub, usingPrimary, err := m.subscribeEvents(topics, ch, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		recheckTimer := time.NewTicker(eventStreamPrimaryRecheckInterval)
+		defer recheckTimer.Stop()
+		for {
+			select {
+			case <-quit:
+				sub.Unsubscribe()
+				return nil
+
+			case <-recheckTimer.C:
+				// Move back to the primary once it's accepting streams again
+				if usingPrimary {
+					continue
+				}
+				primarySub, err := m.primaryBc.SubscribeEvents(topics, ch)
+				if err != nil {
+					continue
+				}
+				m.logger.Println("Primary Beacon client event stream is available again, switching back to it.")
+				sub.Unsubscribe()
+				sub = primarySub
+				usingPrimary = true
+
+			case err := <-sub.Err():
+				sub.Unsubscribe()
+				m.logger.Printlnf("WARNING: %s Beacon client event stream failed (%s), reconnecting...", getClientName(usingPrimary), getErrorMessage(err))
+
+				// Try the other client first, then keep retrying until one of them works
+				preferPrimary := !usingPrimary
+				for {
+					sub, usingPrimary, err = m.subscribeEvents(topics, ch, preferPrimary)
+					if err == nil {
+						break
+					}
+					m.logger.Printlnf("WARNING: Could not reconnect to a Beacon client event stream (%s), retrying in %s...", err.Error(), eventStreamRetryDelay)
+					select {
+					case <-quit:
+						return nil
+					case <-time.After(eventStreamRetryDelay):
+					}
+				}
+			}
+		}
+	}), nil
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
 
+// Subscribe to the event stream on the preferred client, trying the other one if it fails
+func (m *BeaconClientManager) subscribeEvents(topics []beacon.EventTopic, ch chan<- beacon.Event, preferPrimary bool) (ethereum.Subscription, bool, error) {
+	clients := []beacon.Client{m.primaryBc}
+	isPrimary := []bool{true}
+	if m.fallbackBc != nil {
+		if preferPrimary {
+			clients = append(clients, m.fallbackBc)
+			isPrimary = append(isPrimary, false)
+		} else {
+			clients = []beacon.Client{m.fallbackBc, m.primaryBc}
+			isPrimary = []bool{false, true}
+		}
+	}
+
+	errs := []string{}
+	for i, client := range clients {
+		sub, err := client.SubscribeEvents(topics, ch)
+		if err == nil {
+			return sub, isPrimary[i], nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", getClientName(isPrimary[i]), err.Error()))
+	}
+	return nil, false, fmt.Errorf("all Beacon client event streams failed [%s]", strings.Join(errs, "; "))
+}
+
+// Get the name of a client for logging
+func getClientName(isPrimary bool) string {
+	if isPrimary {
+		return "Primary"
+	}
+	return "Fallback"
+}
+
+// Get the message of a subscription error, which is nil if the subscription was closed
+func getErrorMessage(err error) string {
+	if err == nil {
+		return "stream closed"
+	}
+	return err.Error()
+}
+
+// Enable quorum mode, where finalized checkpoints and validator statuses at specific slots or epochs are cross-checked against each of the given endpoints
+// and rejected unless at least threshold of them agree
+func (m *BeaconClientManager) EnableQuorum(endpoints []string, threshold int) {
+	names := make([]string, len(endpoints))
+	clients := make([]beacon.Client, len(endpoints))
+	for i, endpoint := range endpoints {
+		names[i] = getEndpointName(endpoint)
+		clients[i] = client.NewStandardHttpClient(endpoint)
+	}
+	m.quorumBcNames = names
+	m.quorumBcs = clients
+	m.quorumThreshold = threshold
+}
+
+// Check if quorum mode is enabled
+func (m *BeaconClientManager) IsQuorumEnabled() bool {
+	return len(m.quorumBcs) > 0
+}
+
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
@@ -487,6 +700,47 @@ func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, i
 
 }
 
+// Runs a function on the primary or fallback client, and in quorum mode on every quorum client as well.
+// Fails if fewer than the threshold of quorum clients agree with the result.
+func (m *BeaconClientManager) runQuorumFunction1(name string, function bcFunction1, compare quorumComparer) (interface{}, error) {
+
+	// Get the result from the usual client first
+	result, err := m.runFunction1(function)
+	if err != nil || !m.IsQuorumEnabled() {
+		return result, err
+	}
+	reference := quorumResult{
+		endpoint: getEndpointName(m.fallbackBcUrl),
+		result:   result,
+	}
+	if m.primaryReady {
+		reference.endpoint = getEndpointName(m.primaryBcUrl)
+	}
+
+	// Get the results from the quorum clients
+	results := make([]quorumResult, len(m.quorumBcs))
+	var wg sync.WaitGroup
+	for i, client := range m.quorumBcs {
+		wg.Add(1)
+		go func(i int, client beacon.Client) {
+			defer wg.Done()
+			result, err := function(client)
+			results[i] = quorumResult{
+				endpoint: m.quorumBcNames[i],
+				result:   result,
+				err:      err,
+			}
+		}(i, client)
+	}
+	wg.Wait()
+
+	err = checkQuorum(&m.logger, name, reference, results, m.quorumThreshold, compare)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Returns true if the error was a connection failure and a backup client is available
 func (m *BeaconClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	beaconTriggerRetryDelay  time.Duration = 30 * time.Second
+	beaconTriggerEventBuffer int           = 16
+)
+
+// Wakes a daemon's task loop as soon as the Beacon node reports a finalized checkpoint or a chain reorg,
+// instead of leaving it to wait for its next timer tick
+type BeaconEventTrigger struct {
+	bc     beacon.Client
+	logger *log.ColorLogger
+	wake   chan struct{}
+}
+
+// Create a new trigger and start listening to the Beacon node's event stream in the background
+func NewBeaconEventTrigger(bc beacon.Client, logger *log.ColorLogger) *BeaconEventTrigger {
+	trigger := &BeaconEventTrigger{
+		bc:     bc,
+		logger: logger,
+		wake:   make(chan struct{}, 1),
+	}
+	go trigger.run()
+	return trigger
+}
+
+// Wait until the next finalization or reorg event, or until the timeout elapses if none arrive first.
+// Returns true if an event ended the wait.
+func (t *BeaconEventTrigger) Wait(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-t.wake:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// Keep a subscription open for the lifetime of the daemon; if one can't be opened, the task loop just runs on its timer until it can
+func (t *BeaconEventTrigger) run() {
+	events := make(chan beacon.Event, beaconTriggerEventBuffer)
+	topics := []beacon.EventTopic{beacon.EventTopic_FinalizedCheckpoint, beacon.EventTopic_ChainReorg}
+	for {
+		sub, err := t.bc.SubscribeEvents(topics, events)
+		if err != nil {
+			t.logger.Printlnf("WARNING: Could not subscribe to Beacon node events, tasks will only run on their regular interval for now (%s)", err.Error())
+			time.Sleep(beaconTriggerRetryDelay)
+			continue
+		}
+		t.listen(sub, events)
+	}
+}
+
+// Signal the task loop for every relevant event until the subscription fails
+func (t *BeaconEventTrigger) listen(sub ethereum.Subscription, events chan beacon.Event) {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case event := <-events:
+			switch event.Topic {
+			case beacon.EventTopic_FinalizedCheckpoint:
+				t.logger.Printlnf("Epoch %d was finalized.", event.FinalizedCheckpoint.Epoch)
+			case beacon.EventTopic_ChainReorg:
+				t.logger.Printlnf("Beacon chain reorg of depth %d at slot %d.", event.ChainReorg.Depth, event.ChainReorg.Slot)
+			default:
+				continue
+			}
+
+			// Only one wakeup needs to be pending at a time
+			select {
+			case t.wake <- struct{}{}:
+			default:
+			}
+
+		case err := <-sub.Err():
+			if err != nil {
+				t.logger.Printlnf("WARNING: Beacon node event subscription failed (%s), resubscribing...", err.Error())
+			}
+			return
+		}
+	}
+}
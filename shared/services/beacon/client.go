@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -65,6 +66,23 @@ type BeaconBlock struct {
 	ExecutionBlockNumber uint64
 }
 
+type Fork struct {
+	PreviousVersion []byte
+	CurrentVersion  []byte
+	Epoch           uint64
+}
+
+type Checkpoint struct {
+	Epoch uint64
+	Root  common.Hash
+}
+
+type FinalityCheckpoints struct {
+	PreviousJustified Checkpoint
+	CurrentJustified  Checkpoint
+	Finalized         Checkpoint
+}
+
 type Committee struct {
 	Index      uint64
 	Slot       uint64
@@ -77,6 +95,58 @@ type AttestationInfo struct {
 	CommitteeIndex  uint64
 }
 
+// Beacon node event stream topics
+type EventTopic string
+
+const (
+	EventTopic_Head                EventTopic = "head"
+	EventTopic_Block               EventTopic = "block"
+	EventTopic_FinalizedCheckpoint EventTopic = "finalized_checkpoint"
+	EventTopic_ChainReorg          EventTopic = "chain_reorg"
+	EventTopic_VoluntaryExit       EventTopic = "voluntary_exit"
+)
+
+// Beacon node event stream payloads
+type HeadEvent struct {
+	Slot            uint64
+	Block           common.Hash
+	State           common.Hash
+	EpochTransition bool
+}
+type BlockEvent struct {
+	Slot  uint64
+	Block common.Hash
+}
+type FinalizedCheckpointEvent struct {
+	Epoch uint64
+	Block common.Hash
+	State common.Hash
+}
+type ChainReorgEvent struct {
+	Slot         uint64
+	Depth        uint64
+	Epoch        uint64
+	OldHeadBlock common.Hash
+	NewHeadBlock common.Hash
+	OldHeadState common.Hash
+	NewHeadState common.Hash
+}
+type VoluntaryExitEvent struct {
+	ValidatorIndex uint64
+	Epoch          uint64
+	Signature      types.ValidatorSignature
+}
+
+// An event from the Beacon node's event stream; only the payload matching the topic is set
+type Event struct {
+	Topic               EventTopic
+	Head                *HeadEvent
+	Block               *BlockEvent
+	FinalizedCheckpoint *FinalizedCheckpointEvent
+	ChainReorg          *ChainReorgEvent
+	VoluntaryExit       *VoluntaryExitEvent
+}
+
 // Beacon client type
 type BeaconClientType int
 
@@ -125,9 +195,12 @@ type Client interface {
 	GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error)
 	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
+	GetFork(stateId string) (Fork, error)
+	GetFinalityCheckpoints(stateId string) (FinalityCheckpoints, error)
 	ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) ([]Committee, error)
 	ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
+	SubscribeEvents(topics []EventTopic, ch chan<- Event) (ethereum.Subscription, error)
 }
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -9,15 +11,19 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	"github.com/rocket-pool/rocketpool-go/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	eth2ssz "github.com/rocket-pool/smartnode/shared/types/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
@@ -41,14 +47,30 @@ const (
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	RequestEventsPath                      = "/eth/v1/events?topics=%s"
+	RequestSszContentType                  = "application/octet-stream"
+	RequestSszAccept                       = "application/octet-stream;q=1.0,application/json;q=0.9"
+	RequestConsensusVersionHeader          = "Eth-Consensus-Version"
+	RequestEventStreamContentType          = "text/event-stream"
 
 	MaxRequestValidatorsCount     = 600
+	MaxPostValidatorsCount        = 5000
 	threadLimit               int = 6
+
+	validatorsMaxAttempts       int           = 4
+	validatorsRetryBudget       int           = 12
+	validatorsRetryDelay        time.Duration = 2 * time.Second
+	fullStateValidatorsMinCount int           = 1000
+	fullStateValidatorsFraction float64       = 0.5
 )
 
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	sszUnsupported  atomic.Bool
+
+	postValidatorsUnsupported atomic.Bool
+	validatorSetSize          atomic.Uint64
 }
 
 // Create a new client instance
@@ -436,6 +458,41 @@ func (c *StandardHttpClient) GetDomainData(domainType []byte, epoch uint64, useG
 
 }
 
+// Get the fork at the given state
+func (c *StandardHttpClient) GetFork(stateId string) (beacon.Fork, error) {
+	fork, err := c.getFork(stateId)
+	if err != nil {
+		return beacon.Fork{}, err
+	}
+	return beacon.Fork{
+		PreviousVersion: fork.Data.PreviousVersion,
+		CurrentVersion:  fork.Data.CurrentVersion,
+		Epoch:           uint64(fork.Data.Epoch),
+	}, nil
+}
+
+// Get the finality checkpoints of a Beacon state
+func (c *StandardHttpClient) GetFinalityCheckpoints(stateId string) (beacon.FinalityCheckpoints, error) {
+	checkpoints, err := c.getFinalityCheckpoints(stateId)
+	if err != nil {
+		return beacon.FinalityCheckpoints{}, err
+	}
+	return beacon.FinalityCheckpoints{
+		PreviousJustified: beacon.Checkpoint{
+			Epoch: uint64(checkpoints.Data.PreviousJustified.Epoch),
+			Root:  common.BytesToHash(checkpoints.Data.PreviousJustified.Root),
+		},
+		CurrentJustified: beacon.Checkpoint{
+			Epoch: uint64(checkpoints.Data.CurrentJustified.Epoch),
+			Root:  common.BytesToHash(checkpoints.Data.CurrentJustified.Root),
+		},
+		Finalized: beacon.Checkpoint{
+			Epoch: uint64(checkpoints.Data.Finalized.Epoch),
+			Root:  common.BytesToHash(checkpoints.Data.Finalized.Root),
+		},
+	}, nil
+}
+
 // Perform a voluntary exit on a validator
 func (c *StandardHttpClient) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
 	return c.postVoluntaryExit(VoluntaryExitRequest{
@@ -450,7 +507,11 @@ func (c *StandardHttpClient) ExitValidator(validatorIndex, epoch uint64, signatu
 // Get the ETH1 data for the target beacon block
 func (c *StandardHttpClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
 
-	// Get the Beacon block
+	// Get the Beacon block, preferring SSZ since it's far cheaper to decode than JSON
+	sszBlock, exists, handled, err := c.getBeaconBlockSsz(blockId)
+	if handled {
+		return sszBlock.eth1Data, exists, err
+	}
 	block, exists, err := c.getBeaconBlock(blockId)
 	if err != nil {
 		return beacon.Eth1Data{}, false, err
@@ -469,6 +530,12 @@ func (c *StandardHttpClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth
 }
 
 func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	// The attestations are part of the block, so take them from its SSZ encoding when possible
+	sszBlock, exists, handled, err := c.getBeaconBlockSsz(blockId)
+	if handled {
+		return sszBlock.block.Attestations, exists, err
+	}
+
 	attestations, exists, err := c.getAttestations(blockId)
 	if err != nil {
 		return nil, false, err
@@ -493,6 +560,12 @@ func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.Attestati
 }
 
 func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	// Prefer SSZ since it's far cheaper to decode than JSON
+	sszBlock, exists, handled, err := c.getBeaconBlockSsz(blockId)
+	if handled {
+		return sszBlock.block, exists, err
+	}
+
 	block, exists, err := c.getBeaconBlock(blockId)
 	if err != nil {
 		return beacon.BeaconBlock{}, false, err
@@ -567,6 +640,76 @@ func (c *StandardHttpClient) ChangeWithdrawalCredentials(validatorIndex uint64,
 	})
 }
 
+// Subscribe to the Beacon node's event stream for the given topics. Events are sent to the channel until the subscription is closed or the stream fails,
+// in which case the error is sent on the subscription's error channel.
+func (c *StandardHttpClient) SubscribeEvents(topics []beacon.EventTopic, ch chan<- beacon.Event) (ethereum.Subscription, error) {
+	topicNames := make([]string, len(topics))
+	for i, topic := range topics {
+		topicNames[i] = string(topic)
+	}
+
+	// Open the stream
+	ctx, cancel := context.WithCancel(context.Background())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, fmt.Sprintf(RequestEventsPath, strings.Join(topicNames, ","))), nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	request.Header.Set("Accept", RequestEventStreamContentType)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("Could not subscribe to events: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	// Read events until the stream fails or the subscription is closed
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer cancel()
+		defer func() {
+			_ = response.Body.Close()
+		}()
+
+		// Closing the request context interrupts any blocked read
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		err := readEventStream(response.Body, func(topic string, data []byte) error {
+			beaconEvent, err := parseEvent(topic, data)
+			if err != nil {
+				return err
+			}
+			select {
+			case ch <- beaconEvent:
+				return nil
+			case <-quit:
+				return context.Canceled
+			}
+		})
+
+		// Closing the subscription isn't a failure
+		select {
+		case <-quit:
+			return nil
+		default:
+		}
+		if err == nil {
+			err = fmt.Errorf("event stream closed by the Beacon node")
+		}
+		return err
+	}), nil
+}
+
 // Get sync status
 func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	responseBody, status, err := c.getRequest(RequestSyncStatusPath)
@@ -663,11 +806,61 @@ func (c *StandardHttpClient) getFork(stateId string) (ForkResponse, error) {
 	return fork, nil
 }
 
-// Get validators
-func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (ValidatorsResponse, error) {
+// Get validators, using POST for the lookup if the Beacon node supports it so batches aren't limited by the URL length
+func (c *StandardHttpClient) getValidators(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	if len(pubkeysOrIndices) == 0 || c.postValidatorsUnsupported.Load() {
+		return c.getValidatorsInChunks(stateId, pubkeysOrIndices)
+	}
+
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorsPath, stateId), ValidatorsRequest{Ids: pubkeysOrIndices})
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType:
+		// Older clients don't have the POST route; only stop using it once GET is confirmed to work so a missing state isn't mistaken for a missing route
+		validators, err := c.getValidatorsInChunks(stateId, pubkeysOrIndices)
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		c.postValidatorsUnsupported.Store(true)
+		return validators, nil
+	default:
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not decode validators: %w", err)
+	}
+	return validators, nil
+}
+
+// Get validators with GET requests, splitting the IDs so each URL stays within the limits Beacon nodes accept
+func (c *StandardHttpClient) getValidatorsInChunks(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	if len(pubkeysOrIndices) <= MaxRequestValidatorsCount {
+		return c.getValidatorsWithQuery(stateId, pubkeysOrIndices)
+	}
+	validators := ValidatorsResponse{}
+	for i := 0; i < len(pubkeysOrIndices); i += MaxRequestValidatorsCount {
+		max := i + MaxRequestValidatorsCount
+		if max > len(pubkeysOrIndices) {
+			max = len(pubkeysOrIndices)
+		}
+		chunk, err := c.getValidatorsWithQuery(stateId, pubkeysOrIndices[i:max])
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		validators.Data = append(validators.Data, chunk.Data...)
+	}
+	return validators, nil
+}
+
+// Get validators with a GET request, or the whole validator set if no IDs are provided
+func (c *StandardHttpClient) getValidatorsWithQuery(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
 	var query string
-	if len(pubkeys) > 0 {
-		query = fmt.Sprintf("?id=%s", strings.Join(pubkeys, ","))
+	if len(pubkeysOrIndices) > 0 {
+		query = fmt.Sprintf("?id=%s", strings.Join(pubkeysOrIndices, ","))
 	}
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, stateId) + query)
 	if err != nil {
@@ -708,29 +901,40 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		return ValidatorsResponse{}, fmt.Errorf("must specify a slot or epoch when calling getValidatorsByOpts")
 	}
 
+	// If the lookup covers most of the validator set, one full download is cheaper than the batches
 	count := len(pubkeysOrIndices)
-	data := make([]Validator, count)
-	validFlags := make([]bool, count)
+	if count >= fullStateValidatorsMinCount && c.coversMostOfValidatorSet(count) {
+		return c.getValidatorsFromFullSet(stateId, pubkeysOrIndices)
+	}
+
+	// POST requests can carry much larger batches than GET requests
+	batchSize := MaxPostValidatorsCount
+	if c.postValidatorsUnsupported.Load() {
+		batchSize = MaxRequestValidatorsCount
+	}
+
+	// Get the batches concurrently, retrying failed ones until the lookup's retry budget runs out
+	batchCount := (count + batchSize - 1) / batchSize
+	batches := make([][]Validator, batchCount)
+	retryBudget := int32(validatorsRetryBudget)
 	var wg errgroup.Group
 	wg.SetLimit(threadLimit)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
-		i := i
-		max := i + MaxRequestValidatorsCount
+	for b := 0; b < batchCount; b++ {
+		b := b
+		min := b * batchSize
+		max := min + batchSize
 		if max > count {
 			max = count
 		}
 
 		wg.Go(func() error {
 			// Get & add validators
-			batch := pubkeysOrIndices[i:max]
-			validators, err := c.getValidators(stateId, batch)
+			batch := pubkeysOrIndices[min:max]
+			validators, err := c.getValidatorsWithRetry(stateId, batch, &retryBudget)
 			if err != nil {
 				return fmt.Errorf("error getting validator statuses: %w", err)
 			}
-			for j, responseData := range validators.Data {
-				data[i+j] = responseData
-				validFlags[i+j] = true
-			}
+			batches[b] = validators.Data
 			return nil
 		})
 	}
@@ -739,15 +943,67 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		return ValidatorsResponse{}, fmt.Errorf("error getting validators by opts: %w", err)
 	}
 
-	// Clip all of the empty responses so only the valid pubkeys get returned
-	trueData := make([]Validator, 0, count)
-	for i, valid := range validFlags {
-		if valid {
-			trueData = append(trueData, data[i])
+	// Combine the batches; unknown pubkeys don't get an entry, so only the valid ones get returned
+	data := make([]Validator, 0, count)
+	for _, batch := range batches {
+		data = append(data, batch...)
+	}
+
+	return ValidatorsResponse{Data: data}, nil
+}
+
+// Get a batch of validators, backing off and retrying on failure as long as the shared retry budget allows
+func (c *StandardHttpClient) getValidatorsWithRetry(stateId string, pubkeysOrIndices []string, retryBudget *int32) (ValidatorsResponse, error) {
+	delay := validatorsRetryDelay
+	for attempt := 1; ; attempt++ {
+		validators, err := c.getValidators(stateId, pubkeysOrIndices)
+		if err == nil {
+			return validators, nil
+		}
+		if attempt >= validatorsMaxAttempts || atomic.AddInt32(retryBudget, -1) < 0 {
+			return ValidatorsResponse{}, fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// Check if a lookup of the given size covers most of the validator set.
+// The set size is estimated from the deposit count until a full download has been done, which errs toward batching since it includes top-ups.
+func (c *StandardHttpClient) coversMostOfValidatorSet(count int) bool {
+	setSize := c.validatorSetSize.Load()
+	if setSize == 0 {
+		eth1Data, exists, err := c.GetEth1DataForEth2Block("head")
+		if err != nil || !exists {
+			return false
 		}
+		setSize = eth1Data.DepositCount
+		c.validatorSetSize.Store(setSize)
+	}
+	return float64(count) >= float64(setSize)*fullStateValidatorsFraction
+}
+
+// Get validators by downloading the whole validator set in one request and filtering it locally
+func (c *StandardHttpClient) getValidatorsFromFullSet(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	allValidators, err := c.getValidatorsWithQuery(stateId, nil)
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("error getting validators by opts: %w", err)
 	}
+	c.validatorSetSize.Store(uint64(len(allValidators.Data)))
 
-	return ValidatorsResponse{Data: trueData}, nil
+	// IDs can be either pubkeys or indices
+	wanted := make(map[string]bool, len(pubkeysOrIndices))
+	for _, id := range pubkeysOrIndices {
+		wanted[strings.ToLower(hexutil.RemovePrefix(id))] = true
+	}
+
+	data := make([]Validator, 0, len(pubkeysOrIndices))
+	for _, validator := range allValidators.Data {
+		if wanted[hex.EncodeToString(validator.Validator.Pubkey)] || wanted[strconv.FormatUint(uint64(validator.Index), 10)] {
+			data = append(data, validator)
+		}
+	}
+	return ValidatorsResponse{Data: data}, nil
 }
 
 // Send voluntary exit request
@@ -800,6 +1056,45 @@ func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse
 	return beaconBlock, true, nil
 }
 
+// Get a beacon block in SSZ format.
+// If the Beacon node doesn't support SSZ or sends a block this client can't decode, handled is false and the caller should fall back to JSON.
+func (c *StandardHttpClient) getBeaconBlockSsz(blockId string) (block sszBeaconBlock, exists bool, handled bool, err error) {
+	if c.sszUnsupported.Load() {
+		return sszBeaconBlock{}, false, false, nil
+	}
+
+	responseBody, status, header, err := c.getRequestWithAccept(fmt.Sprintf(RequestBeaconBlockPath, blockId), RequestSszAccept)
+	if err != nil {
+		return sszBeaconBlock{}, false, true, fmt.Errorf("Could not get beacon block data: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return sszBeaconBlock{}, false, true, nil
+	case http.StatusBadRequest, http.StatusNotAcceptable, http.StatusUnsupportedMediaType:
+		// Some clients reject the Accept header outright instead of falling back to JSON
+		c.sszUnsupported.Store(true)
+		return sszBeaconBlock{}, false, false, nil
+	default:
+		return sszBeaconBlock{}, false, true, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	// Stop asking for SSZ if the Beacon node ignored the Accept header and sent JSON
+	if !strings.HasPrefix(header.Get("Content-Type"), RequestSszContentType) {
+		c.sszUnsupported.Store(true)
+		return sszBeaconBlock{}, false, false, nil
+	}
+
+	// A block that can't be decoded is from a fork newer than this client knows about, and so will every later block be;
+	// stop asking for SSZ so they aren't all downloaded twice
+	block, err = decodeSszBeaconBlock(responseBody, header.Get(RequestConsensusVersionHeader))
+	if err != nil {
+		c.sszUnsupported.Store(true)
+		return sszBeaconBlock{}, false, false, nil
+	}
+	return block, true, true, nil
+}
+
 // Get the committees for the epoch
 func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (CommitteesResponse, error) {
 	query := ""
@@ -885,3 +1180,242 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	return body, response.StatusCode, nil
 
 }
+
+// Read server-sent events from a stream, passing each one to the handler until the stream ends or the handler fails
+func readEventStream(stream io.Reader, handler func(topic string, data []byte) error) error {
+	reader := bufio.NewReader(stream)
+	topic := ""
+	data := []byte{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			// A blank line dispatches the event
+			if topic != "" && len(data) > 0 {
+				if err := handler(topic, data); err != nil {
+					return err
+				}
+			}
+			topic = ""
+			data = []byte{}
+
+		case strings.HasPrefix(line, ":"):
+			// Comments are used as keep-alives
+
+		case strings.HasPrefix(line, "event:"):
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+
+		case strings.HasPrefix(line, "data:"):
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:"))...)
+		}
+	}
+}
+
+// Decode the payload of an event from the Beacon node's event stream
+func parseEvent(topic string, data []byte) (beacon.Event, error) {
+	beaconEvent := beacon.Event{
+		Topic: beacon.EventTopic(topic),
+	}
+	switch beaconEvent.Topic {
+	case beacon.EventTopic_Head:
+		var head HeadEventData
+		if err := json.Unmarshal(data, &head); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode head event: %w", err)
+		}
+		beaconEvent.Head = &beacon.HeadEvent{
+			Slot:            uint64(head.Slot),
+			Block:           common.BytesToHash(head.Block),
+			State:           common.BytesToHash(head.State),
+			EpochTransition: head.EpochTransition,
+		}
+
+	case beacon.EventTopic_Block:
+		var block BlockEventData
+		if err := json.Unmarshal(data, &block); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode block event: %w", err)
+		}
+		beaconEvent.Block = &beacon.BlockEvent{
+			Slot:  uint64(block.Slot),
+			Block: common.BytesToHash(block.Block),
+		}
+
+	case beacon.EventTopic_FinalizedCheckpoint:
+		var checkpoint FinalizedCheckpointEventData
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode finalized checkpoint event: %w", err)
+		}
+		beaconEvent.FinalizedCheckpoint = &beacon.FinalizedCheckpointEvent{
+			Epoch: uint64(checkpoint.Epoch),
+			Block: common.BytesToHash(checkpoint.Block),
+			State: common.BytesToHash(checkpoint.State),
+		}
+
+	case beacon.EventTopic_ChainReorg:
+		var reorg ChainReorgEventData
+		if err := json.Unmarshal(data, &reorg); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode chain reorg event: %w", err)
+		}
+		beaconEvent.ChainReorg = &beacon.ChainReorgEvent{
+			Slot:         uint64(reorg.Slot),
+			Depth:        uint64(reorg.Depth),
+			Epoch:        uint64(reorg.Epoch),
+			OldHeadBlock: common.BytesToHash(reorg.OldHeadBlock),
+			NewHeadBlock: common.BytesToHash(reorg.NewHeadBlock),
+			OldHeadState: common.BytesToHash(reorg.OldHeadState),
+			NewHeadState: common.BytesToHash(reorg.NewHeadState),
+		}
+
+	case beacon.EventTopic_VoluntaryExit:
+		var exit VoluntaryExitEventData
+		if err := json.Unmarshal(data, &exit); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode voluntary exit event: %w", err)
+		}
+		beaconEvent.VoluntaryExit = &beacon.VoluntaryExitEvent{
+			ValidatorIndex: uint64(exit.Message.ValidatorIndex),
+			Epoch:          uint64(exit.Message.Epoch),
+			Signature:      types.BytesToValidatorSignature(exit.Signature),
+		}
+
+	default:
+		return beacon.Event{}, fmt.Errorf("Unknown event topic '%s'", topic)
+	}
+
+	return beaconEvent, nil
+}
+
+// Make a GET request to the beacon node with the given Accept header, returning the response headers too
+func (c *StandardHttpClient) getRequestWithAccept(requestPath string, accept string) ([]byte, int, http.Header, error) {
+
+	// Build the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return []byte{}, 0, nil, err
+	}
+	request.Header.Set("Accept", accept)
+
+	// Send request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte{}, 0, nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, nil, err
+	}
+
+	// Return
+	return body, response.StatusCode, response.Header, nil
+
+}
+
+// A beacon block decoded from SSZ, along with its eth1 data
+type sszBeaconBlock struct {
+	block    beacon.BeaconBlock
+	eth1Data beacon.Eth1Data
+}
+
+// Decode an SSZ-encoded signed beacon block from the given fork
+func decodeSszBeaconBlock(data []byte, fork string) (sszBeaconBlock, error) {
+	var slot, proposerIndex uint64
+	var body struct {
+		eth1Data            *eth2ssz.Eth1Data
+		attestations        []*eth2ssz.Attestation
+		hasExecutionPayload bool
+		feeRecipient        []byte
+		blockNumber         uint64
+	}
+
+	switch strings.ToLower(fork) {
+	case "phase0":
+		signedBlock := new(eth2ssz.SignedBeaconBlockPhase0)
+		if err := signedBlock.UnmarshalSSZ(data); err != nil {
+			return sszBeaconBlock{}, err
+		}
+		block := signedBlock.Block
+		slot, proposerIndex = block.Slot, block.ProposerIndex
+		body.eth1Data, body.attestations = block.Body.Eth1Data, block.Body.Attestations
+	case "altair":
+		signedBlock := new(eth2ssz.SignedBeaconBlockAltair)
+		if err := signedBlock.UnmarshalSSZ(data); err != nil {
+			return sszBeaconBlock{}, err
+		}
+		block := signedBlock.Block
+		slot, proposerIndex = block.Slot, block.ProposerIndex
+		body.eth1Data, body.attestations = block.Body.Eth1Data, block.Body.Attestations
+	case "bellatrix":
+		signedBlock := new(eth2ssz.SignedBeaconBlockBellatrix)
+		if err := signedBlock.UnmarshalSSZ(data); err != nil {
+			return sszBeaconBlock{}, err
+		}
+		block := signedBlock.Block
+		slot, proposerIndex = block.Slot, block.ProposerIndex
+		body.eth1Data, body.attestations = block.Body.Eth1Data, block.Body.Attestations
+		body.hasExecutionPayload = true
+		body.feeRecipient, body.blockNumber = block.Body.ExecutionPayload.FeeRecipient, block.Body.ExecutionPayload.BlockNumber
+	case "capella":
+		signedBlock := new(eth2ssz.SignedBeaconBlockCapella)
+		if err := signedBlock.UnmarshalSSZ(data); err != nil {
+			return sszBeaconBlock{}, err
+		}
+		block := signedBlock.Block
+		slot, proposerIndex = block.Slot, block.ProposerIndex
+		body.eth1Data, body.attestations = block.Body.Eth1Data, block.Body.Attestations
+		body.hasExecutionPayload = true
+		body.feeRecipient, body.blockNumber = block.Body.ExecutionPayload.FeeRecipient, block.Body.ExecutionPayload.BlockNumber
+	case "deneb":
+		signedBlock := new(eth2ssz.SignedBeaconBlockDeneb)
+		if err := signedBlock.UnmarshalSSZ(data); err != nil {
+			return sszBeaconBlock{}, err
+		}
+		block := signedBlock.Block
+		slot, proposerIndex = block.Slot, block.ProposerIndex
+		body.eth1Data, body.attestations = block.Body.Eth1Data, block.Body.Attestations
+		body.hasExecutionPayload = true
+		body.feeRecipient, body.blockNumber = block.Body.ExecutionPayload.FeeRecipient, block.Body.ExecutionPayload.BlockNumber
+	default:
+		return sszBeaconBlock{}, fmt.Errorf("no SSZ block type for fork '%s'", fork)
+	}
+
+	// Blocks from before the merge have no execution payload
+	result := sszBeaconBlock{
+		block: beacon.BeaconBlock{
+			Slot:                slot,
+			ProposerIndex:       proposerIndex,
+			HasExecutionPayload: body.hasExecutionPayload,
+			Attestations:        make([]beacon.AttestationInfo, 0, len(body.attestations)),
+		},
+		eth1Data: beacon.Eth1Data{
+			DepositRoot:  common.BytesToHash(body.eth1Data.DepositRoot),
+			DepositCount: body.eth1Data.DepositCount,
+			BlockHash:    common.BytesToHash(body.eth1Data.BlockHash),
+		},
+	}
+	if body.hasExecutionPayload {
+		result.block.FeeRecipient = common.BytesToAddress(body.feeRecipient)
+		result.block.ExecutionBlockNumber = body.blockNumber
+	}
+	for _, attestation := range body.attestations {
+		result.block.Attestations = append(result.block.Attestations, beacon.AttestationInfo{
+			AggregationBits: attestation.AggregationBits,
+			SlotIndex:       attestation.Data.Slot,
+			CommitteeIndex:  attestation.Data.Index,
+		})
+	}
+	return result, nil
+}
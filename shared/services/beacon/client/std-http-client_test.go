@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth2ssz "github.com/rocket-pool/smartnode/shared/types/eth2"
+)
+
+func TestDecodeSszBeaconBlock(t *testing.T) {
+	eth1Data := &eth2ssz.Eth1Data{
+		DepositRoot:  bytes.Repeat([]byte{0x11}, 32),
+		DepositCount: 42,
+		BlockHash:    bytes.Repeat([]byte{0x22}, 32),
+	}
+	attestations := []*eth2ssz.Attestation{
+		{
+			AggregationBits: []byte{0x0f, 0x01},
+			Data: &eth2ssz.AttestationData{
+				Slot:            99,
+				Index:           3,
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &eth2ssz.Checkpoint{Root: make([]byte, 32)},
+				Target:          &eth2ssz.Checkpoint{Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		},
+	}
+	syncAggregate := &eth2ssz.SyncAggregate{
+		SyncCommitteeBits:      make([]byte, 64),
+		SyncCommitteeSignature: make([]byte, 96),
+	}
+	feeRecipient := bytes.Repeat([]byte{0x33}, 20)
+	executionPayload := &eth2ssz.ExecutionPayloadBellatrix{
+		ParentHash:    make([]byte, 32),
+		FeeRecipient:  feeRecipient,
+		StateRoot:     make([]byte, 32),
+		ReceiptsRoot:  make([]byte, 32),
+		LogsBloom:     make([]byte, 256),
+		PrevRandao:    make([]byte, 32),
+		BlockNumber:   1234,
+		BaseFeePerGas: make([]byte, 32),
+		BlockHash:     make([]byte, 32),
+	}
+
+	phase0Block, err := (&eth2ssz.SignedBeaconBlockPhase0{
+		Block: &eth2ssz.BeaconBlockPhase0{
+			Slot:          100,
+			ProposerIndex: 7,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			Body: &eth2ssz.BeaconBlockBodyPhase0{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data:     eth1Data,
+				Graffiti:     make([]byte, 32),
+				Attestations: attestations,
+			},
+		},
+		Signature: make([]byte, 96),
+	}).MarshalSSZ()
+	if err != nil {
+		t.Fatalf("error encoding phase0 block: %s", err.Error())
+	}
+	altairBlock, err := (&eth2ssz.SignedBeaconBlockAltair{
+		Block: &eth2ssz.BeaconBlockAltair{
+			Slot:          100,
+			ProposerIndex: 7,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			Body: &eth2ssz.BeaconBlockBodyAltair{
+				RandaoReveal:  make([]byte, 96),
+				Eth1Data:      eth1Data,
+				Graffiti:      make([]byte, 32),
+				Attestations:  attestations,
+				SyncAggregate: syncAggregate,
+			},
+		},
+		Signature: make([]byte, 96),
+	}).MarshalSSZ()
+	if err != nil {
+		t.Fatalf("error encoding altair block: %s", err.Error())
+	}
+	bellatrixBlock, err := (&eth2ssz.SignedBeaconBlockBellatrix{
+		Block: &eth2ssz.BeaconBlockBellatrix{
+			Slot:          100,
+			ProposerIndex: 7,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			Body: &eth2ssz.BeaconBlockBodyBellatrix{
+				RandaoReveal:     make([]byte, 96),
+				Eth1Data:         eth1Data,
+				Graffiti:         make([]byte, 32),
+				Attestations:     attestations,
+				SyncAggregate:    syncAggregate,
+				ExecutionPayload: executionPayload,
+			},
+		},
+		Signature: make([]byte, 96),
+	}).MarshalSSZ()
+	if err != nil {
+		t.Fatalf("error encoding bellatrix block: %s", err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		fork             string
+		data             []byte
+		wantErr          bool
+		wantExecution    bool
+		wantFeeRecipient common.Address
+		wantBlockNumber  uint64
+	}{
+		{name: "phase0", fork: "phase0", data: phase0Block},
+		{name: "altair", fork: "altair", data: altairBlock},
+		{name: "bellatrix", fork: "bellatrix", data: bellatrixBlock, wantExecution: true, wantFeeRecipient: common.BytesToAddress(feeRecipient), wantBlockNumber: 1234},
+		{name: "fork header is case-insensitive", fork: "Bellatrix", data: bellatrixBlock, wantExecution: true, wantFeeRecipient: common.BytesToAddress(feeRecipient), wantBlockNumber: 1234},
+		{name: "unknown fork", fork: "future", data: bellatrixBlock, wantErr: true},
+		{name: "mismatched fork", fork: "phase0", data: bellatrixBlock, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := decodeSszBeaconBlock(test.data, test.fork)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			block := result.block
+			if block.Slot != 100 || block.ProposerIndex != 7 {
+				t.Errorf("got slot %d and proposer %d, want 100 and 7", block.Slot, block.ProposerIndex)
+			}
+			if block.HasExecutionPayload != test.wantExecution {
+				t.Errorf("got HasExecutionPayload %t, want %t", block.HasExecutionPayload, test.wantExecution)
+			}
+			if block.FeeRecipient != test.wantFeeRecipient || block.ExecutionBlockNumber != test.wantBlockNumber {
+				t.Errorf("got fee recipient %s and block %d, want %s and %d", block.FeeRecipient.Hex(), block.ExecutionBlockNumber, test.wantFeeRecipient.Hex(), test.wantBlockNumber)
+			}
+			if len(block.Attestations) != 1 {
+				t.Fatalf("got %d attestations, want 1", len(block.Attestations))
+			}
+			attestation := block.Attestations[0]
+			if attestation.SlotIndex != 99 || attestation.CommitteeIndex != 3 || !bytes.Equal(attestation.AggregationBits, []byte{0x0f, 0x01}) {
+				t.Errorf("got attestation %+v", attestation)
+			}
+			if result.eth1Data.DepositCount != 42 || result.eth1Data.BlockHash != common.BytesToHash(eth1Data.BlockHash) {
+				t.Errorf("got eth1 data %+v", result.eth1Data)
+			}
+		})
+	}
+}
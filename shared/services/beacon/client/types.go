@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"strconv"
@@ -27,6 +28,9 @@ type BLSToExecutionChangeRequest struct {
 	Message   BLSToExecutionChangeMessage `json:"message"`
 	Signature byteArray                   `json:"signature"`
 }
+type ValidatorsRequest struct {
+	Ids []string `json:"ids"`
+}
 
 // Response types
 type SyncStatusResponse struct {
@@ -59,13 +63,16 @@ type GenesisResponse struct {
 type FinalityCheckpointsResponse struct {
 	Data struct {
 		PreviousJustified struct {
-			Epoch uinteger `json:"epoch"`
+			Epoch uinteger  `json:"epoch"`
+			Root  byteArray `json:"root"`
 		} `json:"previous_justified"`
 		CurrentJustified struct {
-			Epoch uinteger `json:"epoch"`
+			Epoch uinteger  `json:"epoch"`
+			Root  byteArray `json:"root"`
 		} `json:"current_justified"`
 		Finalized struct {
-			Epoch uinteger `json:"epoch"`
+			Epoch uinteger  `json:"epoch"`
+			Root  byteArray `json:"root"`
 		} `json:"finalized"`
 	} `json:"data"`
 }
@@ -150,6 +157,32 @@ type Attestation struct {
 	} `json:"data"`
 }
 
+type HeadEventData struct {
+	Slot            uinteger  `json:"slot"`
+	Block           byteArray `json:"block"`
+	State           byteArray `json:"state"`
+	EpochTransition bool      `json:"epoch_transition"`
+}
+type BlockEventData struct {
+	Slot  uinteger  `json:"slot"`
+	Block byteArray `json:"block"`
+}
+type FinalizedCheckpointEventData struct {
+	Epoch uinteger  `json:"epoch"`
+	Block byteArray `json:"block"`
+	State byteArray `json:"state"`
+}
+type ChainReorgEventData struct {
+	Slot         uinteger  `json:"slot"`
+	Depth        uinteger  `json:"depth"`
+	Epoch        uinteger  `json:"epoch"`
+	OldHeadBlock byteArray `json:"old_head_block"`
+	NewHeadBlock byteArray `json:"new_head_block"`
+	OldHeadState byteArray `json:"old_head_state"`
+	NewHeadState byteArray `json:"new_head_state"`
+}
+type VoluntaryExitEventData VoluntaryExitRequest
+
 // Unsigned integer type
 type uinteger uint64
 
@@ -158,9 +191,11 @@ func (i uinteger) MarshalJSON() ([]byte, error) {
 }
 func (i *uinteger) UnmarshalJSON(data []byte) error {
 
-	// Unmarshal string
+	// Committee and validator responses contain millions of these, so skip the generic string decoder for plain quoted numbers
 	var dataStr string
-	if err := json.Unmarshal(data, &dataStr); err != nil {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' && !bytes.ContainsAny(data[1:len(data)-1], "\\\"") {
+		dataStr = string(data[1 : len(data)-1])
+	} else if err := json.Unmarshal(data, &dataStr); err != nil {
 		return err
 	}
 
@@ -35,8 +35,9 @@ const (
 	ValidatorContainerName    string = "validator"
 	WatchtowerContainerName   string = "watchtower"
 
-	FeeRecipientFileEnvVar string = "FEE_RECIPIENT_FILE"
-	FeeRecipientEnvVar     string = "FEE_RECIPIENT"
+	FeeRecipientFileEnvVar    string = "FEE_RECIPIENT_FILE"
+	FeeRecipientEnvVar        string = "FEE_RECIPIENT"
+	KeymanagerTokenFileEnvVar string = "VC_KEYMANAGER_TOKEN_FILE"
 )
 
 // Defaults
@@ -824,6 +825,7 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 	envVars["ROCKETPOOL_FOLDER"] = cfg.RocketPoolDirectory
 	envVars["RETH_ADDRESS"] = cfg.Smartnode.GetRethAddress().Hex()
 	envVars[FeeRecipientFileEnvVar] = FeeRecipientFilename // If this is running, we're in Docker mode by definition so use the Docker fee recipient filename
+	envVars[KeymanagerTokenFileEnvVar] = KeymanagerTokenFilename
 	config.AddParametersToEnvVars(cfg.Smartnode.GetParameters(), envVars)
 	config.AddParametersToEnvVars(cfg.GetParameters(), envVars)
 
@@ -1113,6 +1115,11 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
 	}
 
+	// Only allow the password stores the containers can reach in Docker mode
+	if !cfg.IsNativeMode && cfg.Smartnode.IsPasswordStoreNativeOnly() {
+		errors = append(errors, fmt.Sprintf("The %s wallet password store is only supported in Native Mode. Please select the File or Encrypted File store.", cfg.Smartnode.PasswordStoreType.Value))
+	}
+
 	// Ensure there's a MEV-boost URL
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
 		switch cfg.MevBoost.Mode.Value.(config.Mode) {
@@ -25,12 +25,21 @@ const (
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	WatchtowerAuditLogFile             string = "audit-log.jsonl"
+	WatchtowerLeaseFile                string = "lease.json"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
+	RewardsCheckpointFilenameFormat    string = "rp-rewards-checkpoint-%s-%d.json.zst"
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
 	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
 	FeeRecipientFilename               string = "rp-fee-recipient.txt"
 	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	PendingTransactionsFilename        string = "pending-transactions.json"
+	KeymanagerTokenFilename            string = "keymanager-token.txt"
+	SlashingProtectionPendingFilename  string = "slashing-protection-pending.json"
+	SlashingProtectionImportFilename   string = "slashing-protection-import.json"
+	HeldValidatorKeysFolder            string = "held-validators"
+	EncryptedPasswordFilename          string = "password.enc"
 )
 
 // Defaults
@@ -81,9 +90,81 @@ type SmartnodeConfig struct {
 	// URL for an EC with archive mode, for manual rewards tree generation
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
+	// Service for Oracle DAO members to use when uploading Merkle trees
+	RewardsUploader config.Parameter `yaml:"rewardsUploader,omitempty"`
+
 	// Token for Oracle DAO members to use when uploading Merkle trees to Web3.Storage
 	Web3StorageApiToken config.Parameter `yaml:"web3StorageApiToken,omitempty"`
 
+	// URL of an IPFS node's HTTP API, for uploading and downloading Merkle trees
+	IpfsApiUrl config.Parameter `yaml:"ipfsApiUrl,omitempty"`
+
+	// Endpoint of an S3-compatible storage service for Oracle DAO members to mirror Merkle trees to
+	S3Endpoint config.Parameter `yaml:"s3Endpoint,omitempty"`
+
+	// Region of the S3-compatible bucket
+	S3Region config.Parameter `yaml:"s3Region,omitempty"`
+
+	// Name of the S3-compatible bucket
+	S3Bucket config.Parameter `yaml:"s3Bucket,omitempty"`
+
+	// Access key ID for the S3-compatible bucket
+	S3AccessKeyID config.Parameter `yaml:"s3AccessKeyId,omitempty"`
+
+	// Secret access key for the S3-compatible bucket
+	S3SecretAccessKey config.Parameter `yaml:"s3SecretAccessKey,omitempty"`
+
+	// Additional HTTP mirrors to download Merkle trees from
+	RewardsFileMirrors config.Parameter `yaml:"rewardsFileMirrors,omitempty"`
+
+	// Where the node account's key is held
+	NodeSignerType config.Parameter `yaml:"nodeSignerType,omitempty"`
+
+	// The URL of the external signer holding the node account's key
+	NodeSignerUrl config.Parameter `yaml:"nodeSignerUrl,omitempty"`
+
+	// The node account's address in the external signer
+	NodeSignerAddress config.Parameter `yaml:"nodeSignerAddress,omitempty"`
+
+	// Where the node wallet's password is kept
+	PasswordStoreType config.Parameter `yaml:"passwordStoreType,omitempty"`
+
+	// The name the password is kept under in the keyring, Secret Service or systemd credentials
+	PasswordStoreName config.Parameter `yaml:"passwordStoreName,omitempty"`
+
+	// The URL of the Web3Signer instance holding the validator keys, if used
+	Web3SignerUrl config.Parameter `yaml:"web3signerUrl,omitempty"`
+
+	// Toggle for managing the Validator client's keys and settings through its Keymanager API
+	KeymanagerApiEnabled config.Parameter `yaml:"keymanagerApiEnabled,omitempty"`
+
+	// The port the Validator client serves its Keymanager API on
+	KeymanagerApiPort config.Parameter `yaml:"keymanagerApiPort,omitempty"`
+
+	// Additional Execution clients for the watchtower to cross-check critical reads against
+	QuorumEcUrls config.Parameter `yaml:"quorumEcUrls,omitempty"`
+
+	// Additional Beacon nodes for the watchtower to cross-check critical reads against
+	QuorumBcUrls config.Parameter `yaml:"quorumBcUrls,omitempty"`
+
+	// The number of quorum clients that have to agree with the watchtower's own client
+	QuorumThreshold config.Parameter `yaml:"quorumThreshold,omitempty"`
+
+	// Whether the watchtower should coordinate with redundant instances so only one of them sends transactions
+	WatchtowerHaEnabled config.Parameter `yaml:"watchtowerHaEnabled,omitempty"`
+
+	// The name this watchtower instance uses when it takes the lease
+	WatchtowerHaInstanceID config.Parameter `yaml:"watchtowerHaInstanceId,omitempty"`
+
+	// The file the redundant watchtower instances share their lease through
+	WatchtowerHaLeasePath config.Parameter `yaml:"watchtowerHaLeasePath,omitempty"`
+
+	// How long the lease lasts without being renewed, in seconds
+	WatchtowerHaLeaseDuration config.Parameter `yaml:"watchtowerHaLeaseDuration,omitempty"`
+
+	// Whether the watchtower should only log fee recipient penalties instead of submitting them
+	PenaltyDryRun config.Parameter `yaml:"penaltyDryRun,omitempty"`
+
 	// Manual override for the watchtower's max fee
 	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
 
@@ -93,6 +174,21 @@ type SmartnodeConfig struct {
 	// The epoch to switch over to TWAP for RPL price reporting
 	RplTwapEpoch config.Parameter `yaml:"rplTwapEpoch,omitempty"`
 
+	// Additional Uniswap v3 pools to cross-check the RPL price against
+	RplPriceCheckPools config.Parameter `yaml:"rplPriceCheckPools,omitempty"`
+
+	// Whether to cross-check the RPL price against the 1inch oracle after the switch to TWAP
+	RplPriceCheckOneInch config.Parameter `yaml:"rplPriceCheckOneInch,omitempty"`
+
+	// The most the RPL price sources can disagree by before the price submission is stopped
+	RplPriceMaxSourceDeviation config.Parameter `yaml:"rplPriceMaxSourceDeviation,omitempty"`
+
+	// The most the RPL price can change from the previous on-chain price before the submission is stopped
+	RplPriceMaxChange config.Parameter `yaml:"rplPriceMaxChange,omitempty"`
+
+	// Per-messenger overrides for how the RPL rate is submitted to the L2 price messengers
+	L2MessengerPolicies config.Parameter `yaml:"l2MessengerPolicies,omitempty"`
+
 	// The epoch to start using the new network balance calculation implementation
 	BalancesModernizationEpoch config.Parameter `yaml:"balancesModernizationEpoch,omitempty"`
 
@@ -326,6 +422,27 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		RewardsUploader: config.Parameter{
+			ID:                   "rewardsUploader",
+			Name:                 "Rewards Tree Uploader",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Select the service you want to use to publish Merkle rewards trees to IPFS at each rewards interval.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsUploader_Web3Storage},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Web3.Storage",
+				Description: "Upload the files to https://web3.storage/ using the API token below.",
+				Value:       config.RewardsUploader_Web3Storage,
+			}, {
+				Name:        "IPFS Node",
+				Description: "Upload and pin the files with your own IPFS node, using the IPFS API URL below.",
+				Value:       config.RewardsUploader_Ipfs,
+			}},
+		},
+
 		Web3StorageApiToken: config.Parameter{
 			ID:                   "web3StorageApiToken",
 			Name:                 "Web3.Storage API Token",
@@ -338,6 +455,316 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		IpfsApiUrl: config.Parameter{
+			ID:                   "ipfsApiUrl",
+			Name:                 "IPFS API URL",
+			Description:          "The URL of an IPFS node's HTTP API (for example, http://127.0.0.1:5001). If set, Merkle rewards trees will be downloaded from this node before trying any public gateways.\n\n[orange]Oracle DAO members[white] that select the IPFS Node uploader will also use it to add and pin the files they publish.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Endpoint: config.Parameter{
+			ID:                   "s3Endpoint",
+			Name:                 "S3 Endpoint",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The URL of an S3-compatible storage service (for example, https://s3.us-east-1.amazonaws.com). If set along with a bucket, every Merkle rewards tree you publish will also be mirrored to the bucket under its CID.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Region: config.Parameter{
+			ID:                   "s3Region",
+			Name:                 "S3 Region",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The region of the S3-compatible bucket.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "us-east-1"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Bucket: config.Parameter{
+			ID:                   "s3Bucket",
+			Name:                 "S3 Bucket",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The name of the S3-compatible bucket to mirror Merkle rewards trees to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3AccessKeyID: config.Parameter{
+			ID:                   "s3AccessKeyId",
+			Name:                 "S3 Access Key ID",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The access key ID used to write to the S3-compatible bucket.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3SecretAccessKey: config.Parameter{
+			ID:                   "s3SecretAccessKey",
+			Name:                 "S3 Secret Access Key",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The secret access key used to write to the S3-compatible bucket.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsFileMirrors: config.Parameter{
+			ID:                   "rewardsFileMirrors",
+			Name:                 "Rewards Tree Mirrors",
+			Description:          "A comma-separated list of additional HTTP mirrors to download Merkle rewards trees from. Use `{cid}` and `{filename}` in each URL to mark where the file's CID and name go (for example, https://my-gateway.io/ipfs/{cid}/{filename}); if neither is present, `/{cid}/{filename}` will be appended to the URL.\n\nEvery downloaded file is checked against the Merkle root stored on-chain before it is saved, so a mirror can't give you a tampered file.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeSignerType: config.Parameter{
+			ID:                   "nodeSignerType",
+			Name:                 "Node Account Signer",
+			Description:          "Select where your node account's private key is held. By default it's derived from your wallet's mnemonic; with an external signer, the wallet only provides your validator keys and the node account's key never touches this machine's disk.\n\nFor a hardware security module, use an HTTP signer that fronts it (such as Web3Signer in Ethereum signing mode).",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.NodeSignerType_Local},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Wallet",
+				Description: "Derive the node account's key from your wallet's mnemonic.",
+				Value:       config.NodeSignerType_Local,
+			}, {
+				Name:        "Clef",
+				Description: "Have Clef sign for the node account over its JSON-RPC API. Clef will ask you to approve each request unless you give it rules that approve them automatically.",
+				Value:       config.NodeSignerType_Clef,
+			}, {
+				Name:        "HTTP Signer",
+				Description: "Have an HTTP signer that supports the standard `eth_accounts`, `eth_signTransaction` and `personal_sign` JSON-RPC methods sign for the node account.",
+				Value:       config.NodeSignerType_Http,
+			}},
+		},
+
+		NodeSignerUrl: config.Parameter{
+			ID:                   "nodeSignerUrl",
+			Name:                 "Node Account Signer URL",
+			Description:          "The URL of the external signer's JSON-RPC API (for example, http://clef:8550). Only used if the Node Account Signer isn't set to Wallet.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeSignerAddress: config.Parameter{
+			ID:                   "nodeSignerAddress",
+			Name:                 "Node Account Address",
+			Description:          "The address of your node account in the external signer. Leave this blank if the signer only holds one account.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordStoreType: config.Parameter{
+			ID:                   "passwordStoreType",
+			Name:                 "Wallet Password Store",
+			Description:          "Select where the password that decrypts your node wallet is kept. By default it's a plaintext file next to the wallet; the other options keep it off the disk, or encrypted on it, so a copy of your data folder isn't enough to unlock the wallet.\n\nIf you change this after setting up your wallet, move the password into the new store yourself and delete the old `password` file.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.PasswordStoreType_File},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "File",
+				Description: "Keep the password in a plaintext file in your data folder.",
+				Value:       config.PasswordStoreType_File,
+			}, {
+				Name:        "Kernel Keyring",
+				Description: "Keep the password in the Linux kernel keyring of the user running the daemons. It's only held in memory, so it has to be added again (e.g. with `keyctl padd user <name> @u`) every time the machine restarts. Native Mode only.",
+				Value:       config.PasswordStoreType_KernelKeyring,
+			}, {
+				Name:        "Secret Service",
+				Description: "Keep the password in your desktop's Secret Service (such as GNOME Keyring or KWallet) through `secret-tool`. Native Mode only.",
+				Value:       config.PasswordStoreType_SecretService,
+			}, {
+				Name:        "systemd Credential",
+				Description: "Read the password from a credential that systemd passes to the daemons with `LoadCredential=` or `LoadCredentialEncrypted=`. It can't be set by the Smartnode; create it yourself (e.g. with `systemd-creds encrypt`). Native Mode only.",
+				Value:       config.PasswordStoreType_SystemdCredential,
+			}, {
+				Name:        "Encrypted File",
+				Description: "Keep the password in a file encrypted with a passphrase. `rocketpool service start` will ask you for the passphrase and write it to a tmpfs inside each daemon's container, so it's never stored on disk; you'll have to run it again whenever those containers restart. In Native Mode, write it to /dev/shm/rocketpool-password-unlock-key yourself, readable only by the user running the daemons.",
+				Value:       config.PasswordStoreType_Encrypted,
+			}},
+		},
+
+		PasswordStoreName: config.Parameter{
+			ID:                   "passwordStoreName",
+			Name:                 "Wallet Password Name",
+			Description:          "The name your node wallet's password is kept under in the kernel keyring, Secret Service or systemd credentials. Not used by the File or Encrypted File stores.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "rocketpool-password"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3SignerUrl: config.Parameter{
+			ID:                   "web3signerUrl",
+			Name:                 "Web3Signer URL",
+			Description:          "If you want your validator keys held in a Web3Signer remote signer instead of keystore files on this machine, enter the URL of its HTTP API here (for example, http://web3signer:9000).\n\nNew validator keys will be imported into the signer through its Keymanager API instead of being written to disk, your Validator client will be configured to sign through it so it can apply its own slashing protection, and voluntary exits will be signed by it. Leave this blank to keep your validator keys in local keystore files.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"WEB3SIGNER_URL"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiEnabled: config.Parameter{
+			ID:                   "keymanagerApiEnabled",
+			Name:                 "Enable Keymanager API",
+			Description:          "Enable the standard Keymanager API on your Validator client, so the Smartnode can load new validator keys and change fee recipients while it's running instead of restarting it.\n\nNew validator keys will be imported into the Validator client directly instead of being written to keystore files for every client, and voluntary exits will be signed by it. The API is protected with a token the Validator client keeps in your validators folder.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_API_ENABLED"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiPort: config.Parameter{
+			ID:                   "keymanagerApiPort",
+			Name:                 "Keymanager API Port",
+			Description:          "The port your Validator client should serve its Keymanager API on.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(5062)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_KEYMANAGER_API_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		QuorumEcUrls: config.Parameter{
+			ID:                   "quorumEcUrls",
+			Name:                 "Quorum Execution Clients",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of additional Execution client HTTP URLs. If set, the watchtower will check every block header and contract call at a specific block it reads against each of these clients, and will refuse to submit anything unless enough of them agree with your own client (see Quorum Threshold). Each mismatch or error is logged along with the clients involved.\n\nThese should be run by different providers or client teams than your main client, so one buggy or compromised client can't cause a wrong vote.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		QuorumBcUrls: config.Parameter{
+			ID:                   "quorumBcUrls",
+			Name:                 "Quorum Beacon Nodes",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of additional Beacon node HTTP URLs. If set, the watchtower will check the finalized checkpoint and every validator status and balance it reads at a specific slot or epoch against each of these nodes, and will refuse to submit anything unless enough of them agree with your own node (see Quorum Threshold). Each mismatch or error is logged along with the nodes involved.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		QuorumThreshold: config.Parameter{
+			ID:                   "quorumThreshold",
+			Name:                 "Quorum Threshold",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The number of Quorum Execution Clients and Quorum Beacon Nodes that have to agree with your own clients before the watchtower uses a result. Clients that are offline or return an error don't count towards it, so a threshold below the number of clients lets the watchtower keep working while one of them is down.\n\nSet this to 0 to require a majority of them.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerHaEnabled: config.Parameter{
+			ID:                   "watchtowerHaEnabled",
+			Name:                 "Enable Watchtower Coordination",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Enable this if you run more than one watchtower with the same Oracle DAO node wallet for redundancy. The instances take turns holding a lease: only the instance holding it (the leader) sends transactions, and the others compute the same submissions in shadow mode and take over when the leader's lease expires. This prevents duplicate submissions and nonce collisions between the instances.\n\nEvery instance must use the same lease file and have its system clock synchronized.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerHaInstanceID: config.Parameter{
+			ID:                   "watchtowerHaInstanceId",
+			Name:                 "Watchtower Instance Name",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The name this watchtower uses when it holds the lease, shown in the logs, metrics and `rocketpool service status`. It must be different on each instance. Leave it blank to use the machine's hostname.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerHaLeasePath: config.Parameter{
+			ID:                   "watchtowerHaLeasePath",
+			Name:                 "Watchtower Lease File",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]The file the watchtower instances share their lease through. It should be on storage every instance can reach, such as an NFS share. A relative path is resolved against the watchtower's data folder; leave it blank to use `%s` there.\n\nIn Docker mode, an absolute path must be mounted into the watchtower container at the same location.", WatchtowerLeaseFile),
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerHaLeaseDuration: config.Parameter{
+			ID:                   "watchtowerHaLeaseDuration",
+			Name:                 "Watchtower Lease Duration",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]How long the leader's lease lasts, in seconds, if it isn't renewed. The leader renews it three times per period, and a standby takes over once it expires, so this is roughly how long the Oracle DAO duties can go unattended if the leader goes down.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(60)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PenaltyDryRun: config.Parameter{
+			ID:                   "penaltyDryRun",
+			Name:                 "Penalty Dry Run",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Enable this to have the watchtower log the fee recipient penalties it would submit instead of submitting them. The blocks are still checked and the scan still moves forward, so penalties found in dry run mode won't be submitted later.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		WatchtowerMaxFeeOverride: config.Parameter{
 			ID:                   "watchtowerMaxFeeOverride",
 			Name:                 "Watchtower Max Fee Override",
@@ -378,6 +805,66 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   true,
 		},
 
+		RplPriceCheckPools: config.Parameter{
+			ID:                   "rplPriceCheckPools",
+			Name:                 "RPL Price Check Pools",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of additional RPL/WETH Uniswap v3 pool addresses. The watchtower will read the RPL TWAP from each of these pools alongside the main pool, and will refuse to submit the RPL price if any of them disagree with the others by more than the Max Source Deviation. The main pool's price is still the one that gets submitted.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RplPriceCheckOneInch: config.Parameter{
+			ID:                   "rplPriceCheckOneInch",
+			Name:                 "Check RPL Price with 1inch",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Enable this to also cross-check the RPL TWAP against the 1inch oracle's spot price. Spot prices move more than the TWAP does, so you may need to raise the Max Source Deviation if you enable this.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RplPriceMaxSourceDeviation: config.Parameter{
+			ID:                   "rplPriceMaxSourceDeviation",
+			Name:                 "RPL Price Max Source Deviation",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The most (in percent) any RPL price source can differ from the others before the watchtower refuses to submit the price. With two sources they are compared to each other; with three or more, each is compared to the median of all of them. This only applies when at least one cross-check source is enabled with the `RPL Price Check Pools` or `Check RPL Price with 1inch` settings, since by default only one source is used.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(2)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RplPriceMaxChange: config.Parameter{
+			ID:                   "rplPriceMaxChange",
+			Name:                 "RPL Price Max Change",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The most (in percent) the RPL price can rise or fall from the previous on-chain price before the watchtower refuses to submit it.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(50)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		L2MessengerPolicies: config.Parameter{
+			ID:                   "l2MessengerPolicies",
+			Name:                 "L2 Messenger Policies",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of overrides for how the RPL rate is submitted to each L2 price messenger, in the form `messenger:attempts:retryDelay:gasLimitMultiplier` (for example, `arbitrum:3:5m:1.5`). The messengers are `optimism`, `polygon`, `arbitrum` and `zksyncEra`. `attempts` is how many times a submission is tried during one of this node's turns, `retryDelay` is how long to wait before trying again on a later check, and `gasLimitMultiplier` is applied to the estimated gas limit. Leave a field blank to keep its default of 2 attempts, 1 minute and 1.5.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		BalancesModernizationEpoch: config.Parameter{
 			ID:          "balancesModernizationEpoch",
 			Name:        "Balances Modernization Epoch",
@@ -617,10 +1104,39 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.DistributeThreshold,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
+		&cfg.RewardsUploader,
 		&cfg.Web3StorageApiToken,
+		&cfg.IpfsApiUrl,
+		&cfg.S3Endpoint,
+		&cfg.S3Region,
+		&cfg.S3Bucket,
+		&cfg.S3AccessKeyID,
+		&cfg.S3SecretAccessKey,
+		&cfg.RewardsFileMirrors,
+		&cfg.NodeSignerType,
+		&cfg.NodeSignerUrl,
+		&cfg.NodeSignerAddress,
+		&cfg.PasswordStoreType,
+		&cfg.PasswordStoreName,
+		&cfg.Web3SignerUrl,
+		&cfg.KeymanagerApiEnabled,
+		&cfg.KeymanagerApiPort,
+		&cfg.QuorumEcUrls,
+		&cfg.QuorumBcUrls,
+		&cfg.QuorumThreshold,
+		&cfg.WatchtowerHaEnabled,
+		&cfg.WatchtowerHaInstanceID,
+		&cfg.WatchtowerHaLeasePath,
+		&cfg.WatchtowerHaLeaseDuration,
+		&cfg.PenaltyDryRun,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
 		&cfg.RplTwapEpoch,
+		&cfg.RplPriceCheckPools,
+		&cfg.RplPriceCheckOneInch,
+		&cfg.RplPriceMaxSourceDeviation,
+		&cfg.RplPriceMaxChange,
+		&cfg.L2MessengerPolicies,
 		&cfg.BalancesModernizationEpoch,
 	}
 }
@@ -655,6 +1171,25 @@ func (cfg *SmartnodeConfig) GetPasswordPath() string {
 	return filepath.Join(DaemonDataPath, "password")
 }
 
+func (cfg *SmartnodeConfig) GetEncryptedPasswordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), EncryptedPasswordFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, EncryptedPasswordFilename)
+}
+
+// Check if the selected wallet password store only works in Native Mode.
+// The Docker containers can't reach the kernel keyring, the Secret Service or systemd credentials, so the daemons would wait for the password forever.
+func (cfg *SmartnodeConfig) IsPasswordStoreNativeOnly() bool {
+	switch cfg.PasswordStoreType.Value.(config.PasswordStoreType) {
+	case config.PasswordStoreType_KernelKeyring, config.PasswordStoreType_SecretService, config.PasswordStoreType_SystemdCredential:
+		return true
+	default:
+		return false
+	}
+}
+
 func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "validators")
@@ -663,6 +1198,39 @@ func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
 	return filepath.Join(DaemonDataPath, "validators")
 }
 
+func (cfg *SmartnodeConfig) GetPendingTransactionsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), PendingTransactionsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, PendingTransactionsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSlashingProtectionPendingPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionPendingFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, SlashingProtectionPendingFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSlashingProtectionImportPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionImportFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, SlashingProtectionImportFilename)
+}
+
+// Validator keys waiting for their slashing protection data are held here, outside of the folder the VC loads keys from
+func (cfg *SmartnodeConfig) GetHeldValidatorKeysPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), HeldValidatorKeysFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, HeldValidatorKeysFolder)
+}
+
 func (cfg *SmartnodeConfig) GetWalletPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "wallet")
 }
@@ -671,10 +1239,22 @@ func (cfg *SmartnodeConfig) GetPasswordPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "password")
 }
 
+func (cfg *SmartnodeConfig) GetEncryptedPasswordPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), EncryptedPasswordFilename)
+}
+
 func (cfg *SmartnodeConfig) GetValidatorKeychainPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators")
 }
 
+func (cfg *SmartnodeConfig) GetSlashingProtectionPendingPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionPendingFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSlashingProtectionImportPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionImportFilename)
+}
+
 func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	if config.parent.IsNativeMode {
 		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder, "state.yml")
@@ -683,6 +1263,46 @@ func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	return filepath.Join(DaemonDataPath, WatchtowerFolder, "state.yml")
 }
 
+func (config *SmartnodeConfig) GetWatchtowerAuditLogPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder, WatchtowerAuditLogFile)
+	}
+
+	return filepath.Join(DaemonDataPath, WatchtowerFolder, WatchtowerAuditLogFile)
+}
+
+func (cfg *SmartnodeConfig) GetWatchtowerLeasePath(daemon bool) string {
+	path := cfg.WatchtowerHaLeasePath.Value.(string)
+	if path == "" {
+		path = WatchtowerLeaseFile
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), path)
+}
+
+func (cfg *SmartnodeConfig) IsWeb3SignerEnabled() bool {
+	return cfg.Web3SignerUrl.Value.(string) != ""
+}
+
+func (cfg *SmartnodeConfig) IsKeymanagerApiEnabled() bool {
+	return cfg.KeymanagerApiEnabled.Value.(bool)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerApiUrl() string {
+	if cfg.parent.IsNativeMode {
+		return fmt.Sprintf("http://localhost:%d", cfg.KeymanagerApiPort.Value)
+	}
+
+	return fmt.Sprintf("http://%s:%d", ValidatorContainerName, cfg.KeymanagerApiPort.Value)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerTokenPath() string {
+	return filepath.Join(cfg.GetValidatorKeychainPath(), KeymanagerTokenFilename)
+}
+
 func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "custom-keys")
@@ -784,6 +1404,14 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+func (cfg *SmartnodeConfig) GetRewardsCheckpointPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RewardsCheckpointFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RewardsCheckpointFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
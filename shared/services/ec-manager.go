@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -29,6 +30,14 @@ type ExecutionClientManager struct {
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// Extra clients that critical reads are cross-checked against in quorum mode
+	quorumEcNames   []string
+	quorumEcs       []*ethclient.Client
+	quorumThreshold int
+
+	// Called with the result of every transaction sent through the manager
+	sendHandler func(tx *types.Transaction, err error)
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -108,9 +117,18 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	function := func(client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
-	})
+	}
+
+	// Only calls pinned to a block can be compared across clients
+	var result interface{}
+	var err error
+	if blockNumber != nil && p.IsQuorumEnabled() {
+		result, err = p.runQuorumFunction(fmt.Sprintf("call to %s at block %s", getCallTarget(call), blockNumber.String()), function, compareCallResults)
+	} else {
+		result, err = p.runFunction(function)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -123,9 +141,9 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByHash returns the block header with the given hash.
 func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runQuorumFunction(fmt.Sprintf("header %s", hash.Hex()), func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByHash(ctx, hash)
-	})
+	}, compareHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -135,9 +153,18 @@ func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.H
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	function := func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
-	})
+	}
+
+	// The latest header legitimately differs between clients, so only specific blocks are compared
+	var result interface{}
+	var err error
+	if number != nil && p.IsQuorumEnabled() {
+		result, err = p.runQuorumFunction(fmt.Sprintf("header of block %s", number.String()), function, compareHeaders)
+	} else {
+		result, err = p.runFunction(function)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -210,9 +237,17 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
+	if p.sendHandler != nil {
+		p.sendHandler(tx, err)
+	}
 	return err
 }
 
+// Set the function to call with the result of every transaction sent through the manager
+func (p *ExecutionClientManager) SetSendHandler(handler func(tx *types.Transaction, err error)) {
+	p.sendHandler = handler
+}
+
 /// ==========================
 /// ContractFilterer Functions
 /// ==========================
@@ -274,6 +309,18 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 	return result.(uint64), err
 }
 
+// BlockByNumber returns a block from the current canonical chain, including its transactions.
+// If number is nil, the latest known block is returned.
+func (p *ExecutionClientManager) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Block), err
+}
+
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
@@ -332,6 +379,30 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 /// Internal functions
 /// ==================
 
+// Enable quorum mode, where block headers and contract calls at pinned blocks are cross-checked against each of the given endpoints
+// and rejected unless at least threshold of them agree
+func (p *ExecutionClientManager) EnableQuorum(endpoints []string, threshold int) error {
+	names := make([]string, len(endpoints))
+	clients := make([]*ethclient.Client, len(endpoints))
+	for i, endpoint := range endpoints {
+		names[i] = getEndpointName(endpoint)
+		client, err := ethclient.Dial(endpoint)
+		if err != nil {
+			return fmt.Errorf("error connecting to quorum EC at [%s]: %w", names[i], err)
+		}
+		clients[i] = client
+	}
+	p.quorumEcNames = names
+	p.quorumEcs = clients
+	p.quorumThreshold = threshold
+	return nil
+}
+
+// Check if quorum mode is enabled
+func (p *ExecutionClientManager) IsQuorumEnabled() bool {
+	return len(p.quorumEcs) > 0
+}
+
 func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
@@ -500,6 +571,47 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 	return nil, fmt.Errorf("no Execution clients were ready")
 }
 
+// Runs a function on the primary or fallback client, and in quorum mode on every quorum client as well.
+// Fails if fewer than the threshold of quorum clients agree with the result.
+func (p *ExecutionClientManager) runQuorumFunction(name string, function ecFunction, compare quorumComparer) (interface{}, error) {
+
+	// Get the result from the usual client first
+	result, err := p.runFunction(function)
+	if err != nil || !p.IsQuorumEnabled() {
+		return result, err
+	}
+	reference := quorumResult{
+		endpoint: getEndpointName(p.fallbackEcUrl),
+		result:   result,
+	}
+	if p.primaryReady {
+		reference.endpoint = getEndpointName(p.primaryEcUrl)
+	}
+
+	// Get the results from the quorum clients
+	results := make([]quorumResult, len(p.quorumEcs))
+	var wg sync.WaitGroup
+	for i, client := range p.quorumEcs {
+		wg.Add(1)
+		go func(i int, client *ethclient.Client) {
+			defer wg.Done()
+			result, err := function(client)
+			results[i] = quorumResult{
+				endpoint: p.quorumEcNames[i],
+				result:   result,
+				err:      err,
+			}
+		}(i, client)
+	}
+	wg.Wait()
+
+	err = checkQuorum(&p.logger, name, reference, results, p.quorumThreshold, compare)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Returns true if the error was a connection failure and a backup client is available
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
@@ -0,0 +1,301 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	KeystoresPath           string        = "/eth/v1/keystores"
+	RemoteKeysPath          string        = "/eth/v1/remotekeys"
+	FeeRecipientPathFormat  string        = "/eth/v1/validator/%s/feerecipient"
+	GraffitiPathFormat      string        = "/eth/v1/validator/%s/graffiti"
+	VoluntaryExitPathFormat string        = "/eth/v1/validator/%s/voluntary_exit"
+	RequestContentType      string        = "application/json"
+	RequestTimeout          time.Duration = 60 * time.Second
+)
+
+// Client for the standard Keymanager API, which validator clients and remote signers use to manage keys and per-validator settings while running
+type Client struct {
+	url       string
+	tokenPath string
+	client    *http.Client
+}
+
+// Create a new Keymanager API client.
+// If tokenPath is set, the bearer token is read from that file on every request so a token rotated by the client is picked up.
+func NewClient(url string, tokenPath string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		tokenPath: tokenPath,
+		client: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}
+}
+
+// Get the pubkeys of the local keystores the client has loaded
+func (c *Client) ListKeystores() ([]types.ValidatorPubkey, error) {
+	responseBody, status, err := c.sendRequest(http.MethodGet, KeystoresPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list keystores: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not list keystores: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response listKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode keystore list response: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(response.Data))
+	for i, keystore := range response.Data {
+		pubkeys[i], err = types.HexToValidatorPubkey(hexutil.RemovePrefix(keystore.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Client returned invalid keystore pubkey %s: %w", keystore.ValidatingPubkey, err)
+		}
+	}
+	return pubkeys, nil
+}
+
+// Import EIP-2335 keystores, along with the password for each one and optional EIP-3076 slashing protection data
+func (c *Client) ImportKeystores(keystores []string, passwords []string, slashingProtection string) ([]ImportResult, error) {
+	request := importKeystoresRequest{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: slashingProtection,
+	}
+	responseBody, status, err := c.sendRequest(http.MethodPost, KeystoresPath, request)
+	if err != nil {
+		return nil, fmt.Errorf("Could not import keystores: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not import keystores: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response importResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode keystore import response: %w", err)
+	}
+	if len(response.Data) != len(keystores) {
+		return nil, fmt.Errorf("Client returned %d import results for %d keystores", len(response.Data), len(keystores))
+	}
+	return response.Data, nil
+}
+
+// Get the pubkeys of the remote keys the client has loaded.
+// Clients that don't support remote keys return an empty list.
+func (c *Client) ListRemoteKeys() ([]types.ValidatorPubkey, error) {
+	responseBody, status, err := c.sendRequest(http.MethodGet, RemoteKeysPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list remote keys: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return []types.ValidatorPubkey{}, nil
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not list remote keys: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response listRemoteKeysResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode remote key list response: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(response.Data))
+	for i, key := range response.Data {
+		pubkeys[i], err = types.HexToValidatorPubkey(hexutil.RemovePrefix(key.Pubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Client returned invalid remote key pubkey %s: %w", key.Pubkey, err)
+		}
+	}
+	return pubkeys, nil
+}
+
+// Register keys held by a remote signer, so the client signs with them through that signer
+func (c *Client) ImportRemoteKeys(pubkeys []types.ValidatorPubkey, signerUrl string) ([]ImportResult, error) {
+	request := importRemoteKeysRequest{
+		RemoteKeys: make([]remoteKey, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.RemoteKeys[i] = remoteKey{
+			Pubkey: hexutil.AddPrefix(pubkey.Hex()),
+			Url:    signerUrl,
+		}
+	}
+	responseBody, status, err := c.sendRequest(http.MethodPost, RemoteKeysPath, request)
+	if err != nil {
+		return nil, fmt.Errorf("Could not import remote keys: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not import remote keys: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response importResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode remote key import response: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, fmt.Errorf("Client returned %d import results for %d remote keys", len(response.Data), len(pubkeys))
+	}
+	return response.Data, nil
+}
+
+// Get the fee recipient the client uses for a validator
+func (c *Client) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	responseBody, status, err := c.sendRequest(http.MethodGet, fmt.Sprintf(FeeRecipientPathFormat, hexutil.AddPrefix(pubkey.Hex())), nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("Could not get fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	if status != http.StatusOK {
+		return common.Address{}, fmt.Errorf("Could not get fee recipient for validator %s: HTTP status %d; response body: '%s'", pubkey.Hex(), status, string(responseBody))
+	}
+
+	var response feeRecipientResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return common.Address{}, fmt.Errorf("Could not decode fee recipient response: %w", err)
+	}
+	return response.Data.EthAddress, nil
+}
+
+// Set the fee recipient the client uses for a validator
+func (c *Client) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	request := setFeeRecipientRequest{
+		EthAddress: feeRecipient,
+	}
+	responseBody, status, err := c.sendRequest(http.MethodPost, fmt.Sprintf(FeeRecipientPathFormat, hexutil.AddPrefix(pubkey.Hex())), request)
+	if err != nil {
+		return fmt.Errorf("Could not set fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	if status != http.StatusAccepted && status != http.StatusOK {
+		return fmt.Errorf("Could not set fee recipient for validator %s: HTTP status %d; response body: '%s'", pubkey.Hex(), status, string(responseBody))
+	}
+	return nil
+}
+
+// Get the graffiti the client uses for a validator
+func (c *Client) GetGraffiti(pubkey types.ValidatorPubkey) (string, error) {
+	responseBody, status, err := c.sendRequest(http.MethodGet, fmt.Sprintf(GraffitiPathFormat, hexutil.AddPrefix(pubkey.Hex())), nil)
+	if err != nil {
+		return "", fmt.Errorf("Could not get graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("Could not get graffiti for validator %s: HTTP status %d; response body: '%s'", pubkey.Hex(), status, string(responseBody))
+	}
+
+	var response graffitiResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return "", fmt.Errorf("Could not decode graffiti response: %w", err)
+	}
+	return response.Data.Graffiti, nil
+}
+
+// Set the graffiti the client uses for a validator
+func (c *Client) SetGraffiti(pubkey types.ValidatorPubkey, graffiti string) error {
+	request := setGraffitiRequest{
+		Graffiti: graffiti,
+	}
+	responseBody, status, err := c.sendRequest(http.MethodPost, fmt.Sprintf(GraffitiPathFormat, hexutil.AddPrefix(pubkey.Hex())), request)
+	if err != nil {
+		return fmt.Errorf("Could not set graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	if status != http.StatusAccepted && status != http.StatusOK {
+		return fmt.Errorf("Could not set graffiti for validator %s: HTTP status %d; response body: '%s'", pubkey.Hex(), status, string(responseBody))
+	}
+	return nil
+}
+
+// Have the client create and sign a voluntary exit for a validator at the given epoch
+func (c *Client) SignVoluntaryExit(pubkey types.ValidatorPubkey, epoch uint64) (SignedVoluntaryExit, error) {
+	requestPath := fmt.Sprintf(VoluntaryExitPathFormat, hexutil.AddPrefix(pubkey.Hex())) + "?epoch=" + strconv.FormatUint(epoch, 10)
+	responseBody, status, err := c.sendRequest(http.MethodPost, requestPath, nil)
+	if err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("Could not sign voluntary exit for validator %s: %w", pubkey.Hex(), err)
+	}
+	if status == http.StatusNotFound {
+		return SignedVoluntaryExit{}, fmt.Errorf("The client does not have the key for validator %s", pubkey.Hex())
+	}
+	if status != http.StatusOK {
+		return SignedVoluntaryExit{}, fmt.Errorf("Could not sign voluntary exit for validator %s: HTTP status %d; response body: '%s'", pubkey.Hex(), status, string(responseBody))
+	}
+
+	// Decode the signed exit
+	var response voluntaryExitResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("Could not decode voluntary exit response: %w", err)
+	}
+	var exit SignedVoluntaryExit
+	exit.Epoch, err = strconv.ParseUint(response.Data.Message.Epoch, 10, 64)
+	if err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("Client returned invalid voluntary exit epoch %s: %w", response.Data.Message.Epoch, err)
+	}
+	exit.ValidatorIndex, err = strconv.ParseUint(response.Data.Message.ValidatorIndex, 10, 64)
+	if err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("Client returned invalid voluntary exit validator index %s: %w", response.Data.Message.ValidatorIndex, err)
+	}
+	exit.Signature, err = types.HexToValidatorSignature(hexutil.RemovePrefix(response.Data.Signature))
+	if err != nil {
+		return SignedVoluntaryExit{}, fmt.Errorf("Client returned invalid voluntary exit signature %s: %w", response.Data.Signature, err)
+	}
+	return exit, nil
+}
+
+// Send a request to the client, returning the response body and status code
+func (c *Client) sendRequest(method string, requestPath string, requestBody interface{}) ([]byte, int, error) {
+
+	// Build the request
+	var bodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return []byte{}, 0, err
+		}
+		bodyReader = bytes.NewReader(requestBodyBytes)
+	}
+	request, err := http.NewRequest(method, c.url+requestPath, bodyReader)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	request.Header.Set("Accept", RequestContentType)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+
+	// Add the bearer token
+	if c.tokenPath != "" {
+		token, err := os.ReadFile(c.tokenPath)
+		if err != nil {
+			return []byte{}, 0, fmt.Errorf("error reading Keymanager API token from %s: %w", c.tokenPath, err)
+		}
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	// Send the request
+	response, err := c.client.Do(request)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get the response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	return body, response.StatusCode, nil
+
+}
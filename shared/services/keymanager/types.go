@@ -0,0 +1,82 @@
+package keymanager
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Request types
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type importRemoteKeysRequest struct {
+	RemoteKeys []remoteKey `json:"remote_keys"`
+}
+type remoteKey struct {
+	Pubkey string `json:"pubkey"`
+	Url    string `json:"url"`
+}
+type setFeeRecipientRequest struct {
+	EthAddress common.Address `json:"ethaddress"`
+}
+type setGraffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
+
+// Response types
+type importResponse struct {
+	Data []ImportResult `json:"data"`
+}
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+type listRemoteKeysResponse struct {
+	Data []struct {
+		Pubkey string `json:"pubkey"`
+	} `json:"data"`
+}
+type feeRecipientResponse struct {
+	Data struct {
+		EthAddress common.Address `json:"ethaddress"`
+	} `json:"data"`
+}
+type graffitiResponse struct {
+	Data struct {
+		Graffiti string `json:"graffiti"`
+	} `json:"data"`
+}
+type voluntaryExitResponse struct {
+	Data struct {
+		Message struct {
+			Epoch          string `json:"epoch"`
+			ValidatorIndex string `json:"validator_index"`
+		} `json:"message"`
+		Signature string `json:"signature"`
+	} `json:"data"`
+}
+
+// The outcome of importing a single keystore or remote key
+type ImportResult struct {
+	Status  ImportStatus `json:"status"`
+	Message string       `json:"message"`
+}
+
+// The status of an imported keystore or remote key
+type ImportStatus string
+
+const (
+	ImportStatus_Imported  ImportStatus = "imported"
+	ImportStatus_Duplicate ImportStatus = "duplicate"
+	ImportStatus_Error     ImportStatus = "error"
+)
+
+// A voluntary exit message signed by the client
+type SignedVoluntaryExit struct {
+	Epoch          uint64
+	ValidatorIndex uint64
+	Signature      types.ValidatorSignature
+}
@@ -0,0 +1,240 @@
+package lease
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Settings
+const (
+	// How old a lock file can get before it's considered abandoned by an instance that stopped while holding it
+	lockStaleAfter time.Duration = 30 * time.Second
+
+	// How long to wait between attempts to take the lock file
+	lockRetryDelay time.Duration = 100 * time.Millisecond
+
+	// How many times to try taking the lock file before giving up
+	lockAttempts int = 50
+)
+
+// The contents of a lease file
+type Record struct {
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	RenewedAt  time.Time `json:"renewedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// Check if the lease has expired, so another instance can take it
+func (r *Record) IsExpired() bool {
+	return !time.Now().Before(r.ExpiresAt)
+}
+
+// A lease kept in a file, which a set of instances sharing the file take turns holding.
+// The file can be on shared storage, such as an NFS mount, or on local storage for instances on the same machine.
+// Updates are guarded by a lock file next to it, so instances don't overwrite each other's changes.
+type FileLease struct {
+	path       string
+	instanceID string
+	duration   time.Duration
+	record     *Record
+	lock       sync.Mutex
+}
+
+// Create a new file lease for an instance
+func NewFileLease(path string, instanceID string, duration time.Duration) (*FileLease, error) {
+	if instanceID == "" {
+		return nil, fmt.Errorf("the instance ID can't be blank")
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("the lease duration must be greater than zero")
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the folder for lease file %s: %w", path, err)
+	}
+	return &FileLease{
+		path:       path,
+		instanceID: instanceID,
+		duration:   duration,
+	}, nil
+}
+
+// Get the name this instance uses when it holds the lease
+func (l *FileLease) GetInstanceID() string {
+	return l.instanceID
+}
+
+// Get how long the lease lasts without being renewed
+func (l *FileLease) GetDuration() time.Duration {
+	return l.duration
+}
+
+// Take the lease if it's free or expired, or renew it if this instance already holds it.
+// Returns true if this instance holds the lease afterwards.
+func (l *FileLease) Refresh() (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Take the lock file so no other instance changes the lease at the same time
+	token, err := l.takeLockFile()
+	if err != nil {
+		return false, err
+	}
+	defer l.releaseLockFile(token)
+
+	// Check the current holder
+	record, err := ReadLease(l.path)
+	if err != nil {
+		return false, err
+	}
+	if record != nil && record.Holder != l.instanceID && !record.IsExpired() {
+		l.record = record
+		return false, nil
+	}
+
+	// Take or renew the lease
+	now := time.Now()
+	newRecord := &Record{
+		Holder:     l.instanceID,
+		AcquiredAt: now,
+		RenewedAt:  now,
+		ExpiresAt:  now.Add(l.duration),
+	}
+	if record != nil && record.Holder == l.instanceID && !record.IsExpired() {
+		newRecord.AcquiredAt = record.AcquiredAt
+	}
+	err = l.writeRecord(newRecord)
+	if err != nil {
+		return false, err
+	}
+	l.record = newRecord
+	return true, nil
+}
+
+// Check if this instance holds the lease with enough time left on it to act as the leader.
+// A leader stops acting a quarter of the lease duration before its lease expires, so it can't overlap with the instance that takes over even if the clocks drift a little.
+func (l *FileLease) IsLeader() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.record == nil || l.record.Holder != l.instanceID {
+		return false
+	}
+	return time.Now().Before(l.record.ExpiresAt.Add(-l.duration / 4))
+}
+
+// Get the latest lease record this instance has seen, or nil if it hasn't seen one yet
+func (l *FileLease) GetRecord() *Record {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.record == nil {
+		return nil
+	}
+	record := *l.record
+	return &record
+}
+
+// Read a lease file, returning nil if no instance has taken the lease yet
+func ReadLease(path string) (*Record, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading lease file %s: %w", path, err)
+	}
+
+	var record Record
+	err = json.Unmarshal(bytes, &record)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing lease file %s: %w", path, err)
+	}
+	return &record, nil
+}
+
+// Get the path of the lock file that guards the lease file
+func (l *FileLease) getLockFilePath() string {
+	return l.path + ".lock"
+}
+
+// Create the lock file, waiting for any other instance holding it to finish.
+// Returns the token written to the lock file, which identifies this particular hold of the lock.
+func (l *FileLease) takeLockFile() (string, error) {
+	nonce := make([]byte, 8)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("error generating lock token: %w", err)
+	}
+	token := fmt.Sprintf("%s:%s", l.instanceID, hex.EncodeToString(nonce))
+
+	lockPath := l.getLockFilePath()
+	for i := 0; i < lockAttempts; i++ {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = file.WriteString(token)
+			file.Close()
+			if err != nil {
+				os.Remove(lockPath)
+				return "", fmt.Errorf("error writing lock file %s: %w", lockPath, err)
+			}
+			return token, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("error creating lock file %s: %w", lockPath, err)
+		}
+
+		// Clear the lock file if its owner stopped without removing it
+		info, err := os.Stat(lockPath)
+		if err == nil && time.Since(info.ModTime()) > lockStaleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+		time.Sleep(lockRetryDelay)
+	}
+	return "", fmt.Errorf("timed out waiting for lock file %s", lockPath)
+}
+
+// Remove the lock file, but only if it's still the one this instance created.
+// If this instance took long enough for its lock to go stale, another instance may have replaced it, and removing that one would let a third instance in alongside it.
+func (l *FileLease) releaseLockFile(token string) {
+	lockPath := l.getLockFilePath()
+	bytes, err := os.ReadFile(lockPath)
+	if err != nil || string(bytes) != token {
+		return
+	}
+	os.Remove(lockPath)
+}
+
+// Write a lease record, replacing the file in one step so other instances never read a partial record
+func (l *FileLease) writeRecord(record *Record) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing lease record: %w", err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary lease file: %w", err)
+	}
+	tempPath := file.Name()
+	_, err = file.Write(bytes)
+	file.Close()
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error writing lease file %s: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, l.path)
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error replacing lease file %s: %w", l.path, err)
+	}
+	return nil
+}
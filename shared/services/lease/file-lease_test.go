@@ -0,0 +1,186 @@
+package lease
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Create two instances sharing one lease file
+func newTestLeases(t *testing.T, duration time.Duration) (*FileLease, *FileLease) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "leader.lease")
+	first, err := NewFileLease(path, "first", duration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewFileLease(path, "second", duration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return first, second
+}
+
+func refresh(t *testing.T, lease *FileLease, expected bool) {
+	t.Helper()
+	held, err := lease.Refresh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if held != expected {
+		t.Fatalf("%s: expected to hold the lease = %t, got %t", lease.GetInstanceID(), expected, held)
+	}
+}
+
+func TestFileLease(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		run      func(t *testing.T, first *FileLease, second *FileLease)
+	}{
+		{
+			name:     "first instance acquires and the other waits",
+			duration: time.Minute,
+			run: func(t *testing.T, first *FileLease, second *FileLease) {
+				refresh(t, first, true)
+				refresh(t, second, false)
+				if !first.IsLeader() || second.IsLeader() {
+					t.Errorf("expected only the first instance to lead")
+				}
+				if record := second.GetRecord(); record == nil || record.Holder != "first" {
+					t.Errorf("expected the second instance to see the first as the holder, got %+v", record)
+				}
+			},
+		},
+		{
+			name:     "refresh renews without changing the acquired time",
+			duration: time.Minute,
+			run: func(t *testing.T, first *FileLease, second *FileLease) {
+				refresh(t, first, true)
+				acquired := first.GetRecord()
+				time.Sleep(10 * time.Millisecond)
+				refresh(t, first, true)
+				renewed := first.GetRecord()
+				if !renewed.AcquiredAt.Equal(acquired.AcquiredAt) {
+					t.Errorf("expected the acquired time to stay at %s, got %s", acquired.AcquiredAt, renewed.AcquiredAt)
+				}
+				if !renewed.ExpiresAt.After(acquired.ExpiresAt) {
+					t.Errorf("expected the renewal to extend the lease past %s, got %s", acquired.ExpiresAt, renewed.ExpiresAt)
+				}
+				refresh(t, second, false)
+			},
+		},
+		{
+			name:     "expired lease is taken over",
+			duration: 100 * time.Millisecond,
+			run: func(t *testing.T, first *FileLease, second *FileLease) {
+				refresh(t, first, true)
+				time.Sleep(150 * time.Millisecond)
+				if first.IsLeader() {
+					t.Errorf("expected the first instance to stop leading once its lease expired")
+				}
+				refresh(t, second, true)
+				refresh(t, first, false)
+				if !second.IsLeader() || first.IsLeader() {
+					t.Errorf("expected only the second instance to lead")
+				}
+			},
+		},
+		{
+			name:     "leader stops acting before its lease expires",
+			duration: 200 * time.Millisecond,
+			run: func(t *testing.T, first *FileLease, second *FileLease) {
+				refresh(t, first, true)
+				time.Sleep(160 * time.Millisecond)
+				if first.IsLeader() {
+					t.Errorf("expected the first instance to stop leading in the last quarter of its lease")
+				}
+				refresh(t, second, false)
+			},
+		},
+		{
+			name:     "stale lock file is cleared",
+			duration: time.Minute,
+			run: func(t *testing.T, first *FileLease, second *FileLease) {
+				lockPath := first.getLockFilePath()
+				if err := os.WriteFile(lockPath, []byte("crashed"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				stale := time.Now().Add(-2 * lockStaleAfter)
+				if err := os.Chtimes(lockPath, stale, stale); err != nil {
+					t.Fatal(err)
+				}
+				refresh(t, first, true)
+				if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+					t.Errorf("expected the lock file to be removed after the refresh, got %v", err)
+				}
+			},
+		},
+		{
+			name:     "lock file taken over by another instance is left alone",
+			duration: time.Minute,
+			run: func(t *testing.T, first *FileLease, second *FileLease) {
+				token, err := first.takeLockFile()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Simulate the second instance clearing the first's stale lock and taking its own
+				lockPath := first.getLockFilePath()
+				if err := os.Remove(lockPath); err != nil {
+					t.Fatal(err)
+				}
+				secondToken, err := second.takeLockFile()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				first.releaseLockFile(token)
+				bytes, err := os.ReadFile(lockPath)
+				if err != nil {
+					t.Fatalf("expected the second instance's lock file to survive, got %v", err)
+				}
+				if string(bytes) != secondToken {
+					t.Errorf("expected the lock file to hold %s, got %s", secondToken, string(bytes))
+				}
+
+				second.releaseLockFile(secondToken)
+				if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+					t.Errorf("expected the second instance to remove its own lock file, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, second := newTestLeases(t, test.duration)
+			test.run(t, first, second)
+		})
+	}
+}
+
+func TestFileLeaseConcurrentRefresh(t *testing.T) {
+	first, second := newTestLeases(t, time.Minute)
+	results := make(chan bool, 2)
+	for _, lease := range []*FileLease{first, second} {
+		go func(lease *FileLease) {
+			held, err := lease.Refresh()
+			if err != nil {
+				t.Error(err)
+			}
+			results <- held
+		}(lease)
+	}
+
+	holders := 0
+	for i := 0; i < 2; i++ {
+		if <-results {
+			holders++
+		}
+	}
+	if holders != 1 {
+		t.Errorf("expected exactly one instance to hold the lease, got %d", holders)
+	}
+}
@@ -0,0 +1,151 @@
+package passwords
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// The file `rocketpool service start` hands the encrypted password file's passphrase to the daemons in.
+// It's on a tmpfs, so it's never written to disk and is gone once the daemon's container or machine restarts.
+const UnlockKeyPath string = "/dev/shm/rocketpool-password-unlock-key"
+
+// Returned when the encrypted password file can't be decrypted with the passphrase
+var ErrIncorrectUnlockKey = errors.New("Could not decrypt password file, the passphrase is incorrect")
+
+// Returned when the passphrase for the encrypted password file hasn't been provided yet
+var ErrPasswordLocked = errors.New("The password file is locked; start the Smartnode with `rocketpool service start` to unlock it")
+
+// Encrypted password file
+type encryptedPasswordFile struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Name    string                 `json:"name"`
+	Version uint                   `json:"version"`
+}
+
+// Keeps the password in a file encrypted with a passphrase, which is handed to the daemon after it starts so it's never stored on disk
+type EncryptedFileStore struct {
+	passwordPath  string
+	unlockKey     string
+	unlockKeyPath string
+	encryptor     *eth2ks.Encryptor
+}
+
+// Create a new encrypted file password store with a known passphrase
+func NewEncryptedFileStore(passwordPath string, unlockKey string) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		passwordPath: passwordPath,
+		unlockKey:    unlockKey,
+		encryptor:    eth2ks.New(),
+	}
+}
+
+// Create a new encrypted file password store that reads its passphrase from a file whenever it's needed, so it can be unlocked after the daemon starts
+func NewEncryptedFileStoreWithUnlockKeyFile(passwordPath string, unlockKeyPath string) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		passwordPath:  passwordPath,
+		unlockKeyPath: unlockKeyPath,
+		encryptor:     eth2ks.New(),
+	}
+}
+
+// Check if the encrypted password file exists
+func (s *EncryptedFileStore) IsSet() (bool, error) {
+	_, err := os.Stat(s.passwordPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking encrypted password file path: %w", err)
+	}
+	return true, nil
+}
+
+// Get the password
+func (s *EncryptedFileStore) Get() (string, error) {
+
+	// Read from disk
+	bytes, err := os.ReadFile(s.passwordPath)
+	if err != nil {
+		return "", fmt.Errorf("Could not read encrypted password from disk: %w", err)
+	}
+	var file encryptedPasswordFile
+	if err := json.Unmarshal(bytes, &file); err != nil {
+		return "", fmt.Errorf("Could not decode encrypted password file: %w", err)
+	}
+
+	// Decrypt it
+	unlockKey, err := s.getUnlockKey()
+	if err != nil {
+		return "", err
+	}
+	if unlockKey == "" {
+		return "", ErrPasswordLocked
+	}
+	password, err := s.encryptor.Decrypt(file.Crypto, unlockKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrIncorrectUnlockKey, err.Error())
+	}
+	return string(password), nil
+
+}
+
+// Set the password
+func (s *EncryptedFileStore) Set(password string) error {
+
+	// Encrypt it
+	unlockKey, err := s.getUnlockKey()
+	if err != nil {
+		return err
+	}
+	if unlockKey == "" {
+		return errors.New("The password file has no passphrase to encrypt it with; start the Smartnode with `rocketpool service start` to provide one")
+	}
+	crypto, err := s.encryptor.Encrypt([]byte(password), unlockKey)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt password: %w", err)
+	}
+	bytes, err := json.Marshal(encryptedPasswordFile{
+		Crypto:  crypto,
+		Name:    s.encryptor.Name(),
+		Version: s.encryptor.Version(),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode encrypted password file: %w", err)
+	}
+
+	// Write to disk
+	if err := os.WriteFile(s.passwordPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write encrypted password to disk: %w", err)
+	}
+	return nil
+
+}
+
+// Delete the encrypted password file
+func (s *EncryptedFileStore) Delete() error {
+	err := os.Remove(s.passwordPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting encrypted password file: %w", err)
+	}
+	return nil
+}
+
+// Get the passphrase, or an empty string if it hasn't been provided
+func (s *EncryptedFileStore) getUnlockKey() (string, error) {
+	if s.unlockKey != "" || s.unlockKeyPath == "" {
+		return s.unlockKey, nil
+	}
+	bytes, err := os.ReadFile(s.unlockKeyPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("Could not read password file passphrase: %w", err)
+	}
+	return strings.TrimSuffix(string(bytes), "\n"), nil
+}
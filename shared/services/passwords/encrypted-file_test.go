@@ -0,0 +1,60 @@
+package passwords
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptedFileStoreUnlockKeyFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		unlockKeyFile *string
+		wantErr       error
+	}{
+		{name: "locked", wantErr: ErrPasswordLocked},
+		{name: "unlocked", unlockKeyFile: stringPtr("passphrase")},
+		{name: "unlocked with a trailing newline", unlockKeyFile: stringPtr("passphrase\n")},
+		{name: "wrong passphrase", unlockKeyFile: stringPtr("wrong passphrase"), wantErr: ErrIncorrectUnlockKey},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			passwordPath := filepath.Join(dir, "password")
+			unlockKeyPath := filepath.Join(dir, "unlock-key")
+			if err := NewEncryptedFileStore(passwordPath, "passphrase").Set("node password"); err != nil {
+				t.Fatal(err)
+			}
+			if test.unlockKeyFile != nil {
+				if err := os.WriteFile(unlockKeyPath, []byte(*test.unlockKeyFile), FileMode); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			store := NewEncryptedFileStoreWithUnlockKeyFile(passwordPath, unlockKeyPath)
+			isSet, err := store.IsSet()
+			if err != nil || !isSet {
+				t.Errorf("got set %t (%v), want the password to be set even while it's locked", isSet, err)
+			}
+			password, err := store.Get()
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("got error %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if password != "node password" {
+				t.Errorf("got password '%s'", password)
+			}
+		})
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
@@ -0,0 +1,68 @@
+package passwords
+
+import (
+	"fmt"
+	"os"
+)
+
+// Keeps the password in a plaintext file
+type FileStore struct {
+	passwordPath string
+}
+
+// Create a new file password store
+func NewFileStore(passwordPath string) *FileStore {
+	return &FileStore{
+		passwordPath: passwordPath,
+	}
+}
+
+// Check if the password file exists
+func (s *FileStore) IsSet() (bool, error) {
+	_, err := os.ReadFile(s.passwordPath)
+	return (err == nil), nil
+}
+
+// Get the password
+func (s *FileStore) Get() (string, error) {
+
+	// Read from disk
+	password, err := os.ReadFile(s.passwordPath)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	}
+
+	// Return
+	return string(password), nil
+
+}
+
+// Set the password
+func (s *FileStore) Set(password string) error {
+
+	// Write to disk
+	if err := os.WriteFile(s.passwordPath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Delete the password file
+func (s *FileStore) Delete() error {
+
+	// Check if it exists
+	_, err := os.Stat(s.passwordPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking password file path: %w", err)
+	}
+
+	// Delete it
+	err = os.Remove(s.passwordPath)
+	return err
+
+}
@@ -0,0 +1,96 @@
+//go:build linux
+// +build linux
+
+package passwords
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// The kernel's key type for arbitrary user data
+const keyringKeyType string = "user"
+
+// Keeps the password in the Linux kernel keyring of the user running the daemon.
+// The keyring only lives in memory, so the password has to be added again after every restart.
+type KernelKeyringStore struct {
+	description string
+}
+
+// Create a new kernel keyring password store
+func NewKernelKeyringStore(description string) *KernelKeyringStore {
+	return &KernelKeyringStore{
+		description: description,
+	}
+}
+
+// Check if the password is in the keyring
+func (s *KernelKeyringStore) IsSet() (bool, error) {
+	_, found, err := s.findKey()
+	return found, err
+}
+
+// Get the password
+func (s *KernelKeyringStore) Get() (string, error) {
+
+	// Find the key
+	id, found, err := s.findKey()
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("Password '%s' is not in the kernel keyring; add it with `keyctl padd %s %s @u`", s.description, keyringKeyType, s.description)
+	}
+
+	// Read it, growing the buffer if the key changed size in between
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from the kernel keyring: %w", err)
+	}
+	for {
+		buffer := make([]byte, size)
+		length, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, buffer, 0)
+		if err != nil {
+			return "", fmt.Errorf("Could not read password from the kernel keyring: %w", err)
+		}
+		if length <= size {
+			return string(buffer[:length]), nil
+		}
+		size = length
+	}
+
+}
+
+// Set the password
+func (s *KernelKeyringStore) Set(password string) error {
+	if _, err := unix.AddKey(keyringKeyType, s.description, []byte(password), unix.KEY_SPEC_USER_KEYRING); err != nil {
+		return fmt.Errorf("Could not add password to the kernel keyring: %w", err)
+	}
+	return nil
+}
+
+// Remove the password from the keyring
+func (s *KernelKeyringStore) Delete() error {
+	id, found, err := s.findKey()
+	if err != nil || !found {
+		return err
+	}
+	if _, err := unix.KeyctlInt(unix.KEYCTL_UNLINK, id, unix.KEY_SPEC_USER_KEYRING, 0, 0); err != nil {
+		return fmt.Errorf("Could not remove password from the kernel keyring: %w", err)
+	}
+	return nil
+}
+
+// Find the password's key in the user keyring
+func (s *KernelKeyringStore) findKey() (int, bool, error) {
+	id, err := unix.KeyctlSearch(unix.KEY_SPEC_USER_KEYRING, keyringKeyType, s.description, 0)
+	if errors.Is(err, unix.ENOKEY) || errors.Is(err, unix.EKEYEXPIRED) || errors.Is(err, unix.EKEYREVOKED) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("Could not search the kernel keyring for the password: %w", err)
+	}
+	return id, true, nil
+}
@@ -0,0 +1,41 @@
+//go:build !linux
+// +build !linux
+
+package passwords
+
+import "errors"
+
+// The kernel keyring is only available on Linux
+var errKernelKeyringUnsupported = errors.New("The kernel keyring password store is only supported on Linux")
+
+// Keeps the password in the Linux kernel keyring of the user running the daemon
+type KernelKeyringStore struct {
+	description string
+}
+
+// Create a new kernel keyring password store
+func NewKernelKeyringStore(description string) *KernelKeyringStore {
+	return &KernelKeyringStore{
+		description: description,
+	}
+}
+
+// Check if the password is in the keyring
+func (s *KernelKeyringStore) IsSet() (bool, error) {
+	return false, errKernelKeyringUnsupported
+}
+
+// Get the password
+func (s *KernelKeyringStore) Get() (string, error) {
+	return "", errKernelKeyringUnsupported
+}
+
+// Set the password
+func (s *KernelKeyringStore) Set(password string) error {
+	return errKernelKeyringUnsupported
+}
+
+// Remove the password from the keyring
+func (s *KernelKeyringStore) Delete() error {
+	return errKernelKeyringUnsupported
+}
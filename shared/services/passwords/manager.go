@@ -3,7 +3,6 @@ package passwords
 import (
 	"errors"
 	"fmt"
-	"os"
 )
 
 // Config
@@ -12,36 +11,42 @@ const (
 	FileMode          = 0600
 )
 
+// Password store interface, so the wallet password can be kept somewhere other than a plaintext file
+type PasswordStore interface {
+	// Check if the password has been stored
+	IsSet() (bool, error)
+
+	// Get the password
+	Get() (string, error)
+
+	// Store the password
+	Set(password string) error
+
+	// Delete the password
+	Delete() error
+}
+
 // Password manager
 type PasswordManager struct {
-	passwordPath string
+	store PasswordStore
 }
 
 // Create new password manager
-func NewPasswordManager(passwordPath string) *PasswordManager {
+func NewPasswordManager(store PasswordStore) *PasswordManager {
 	return &PasswordManager{
-		passwordPath: passwordPath,
+		store: store,
 	}
 }
 
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
-	_, err := os.ReadFile(pm.passwordPath)
-	return (err == nil)
+	isSet, err := pm.store.IsSet()
+	return (err == nil && isSet)
 }
 
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
-
-	// Read from disk
-	password, err := os.ReadFile(pm.passwordPath)
-	if err != nil {
-		return "", fmt.Errorf("Could not read password from disk: %w", err)
-	}
-
-	// Return
-	return string(password), nil
-
+	return pm.store.Get()
 }
 
 // Set the password
@@ -57,29 +62,12 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
-	// Write to disk
-	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
-		return fmt.Errorf("Could not write password to disk: %w", err)
-	}
-
-	// Return
-	return nil
+	// Store it
+	return pm.store.Set(password)
 
 }
 
 // Delete the password
 func (pm *PasswordManager) DeletePassword() error {
-
-	// Check if it exists
-	_, err := os.Stat(pm.passwordPath)
-	if os.IsNotExist(err) {
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error checking password file path: %w", err)
-	}
-
-	// Delete it
-	err = os.Remove(pm.passwordPath)
-	return err
-
+	return pm.store.Delete()
 }
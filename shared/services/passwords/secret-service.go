@@ -0,0 +1,103 @@
+package passwords
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Secret Service settings
+const (
+	secretToolCommand    string = "secret-tool"
+	secretServiceLabel   string = "Rocket Pool node wallet password"
+	secretServiceService string = "rocketpool"
+)
+
+// Keeps the password in the desktop's Secret Service (such as GNOME Keyring or KWallet), through libsecret's secret-tool
+type SecretServiceStore struct {
+	name string
+}
+
+// Create a new Secret Service password store
+func NewSecretServiceStore(name string) *SecretServiceStore {
+	return &SecretServiceStore{
+		name: name,
+	}
+}
+
+// Check if the password is in the Secret Service
+func (s *SecretServiceStore) IsSet() (bool, error) {
+	_, found, err := s.lookup()
+	return found, err
+}
+
+// Get the password
+func (s *SecretServiceStore) Get() (string, error) {
+	password, found, err := s.lookup()
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("Password '%s' is not in the Secret Service", s.name)
+	}
+	return password, nil
+}
+
+// Set the password
+func (s *SecretServiceStore) Set(password string) error {
+	cmd := exec.Command(secretToolCommand, append([]string{"store", "--label=" + secretServiceLabel}, s.attributes()...)...)
+	cmd.Stdin = strings.NewReader(password)
+	if _, err := runSecretTool(cmd); err != nil {
+		return fmt.Errorf("Could not store password in the Secret Service: %w", err)
+	}
+	return nil
+}
+
+// Remove the password from the Secret Service
+func (s *SecretServiceStore) Delete() error {
+	cmd := exec.Command(secretToolCommand, append([]string{"clear"}, s.attributes()...)...)
+	if _, err := runSecretTool(cmd); err != nil {
+		return fmt.Errorf("Could not remove password from the Secret Service: %w", err)
+	}
+	return nil
+}
+
+// Look up the password; secret-tool exits with 1 and prints nothing if it isn't there
+func (s *SecretServiceStore) lookup() (string, bool, error) {
+	cmd := exec.Command(secretToolCommand, append([]string{"lookup"}, s.attributes()...)...)
+	output, err := runSecretTool(cmd)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(exitErr.Stderr) == 0 {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("Could not look up password in the Secret Service: %w", err)
+	}
+	return string(output), true, nil
+}
+
+// Get the attributes the password is stored under
+func (s *SecretServiceStore) attributes() []string {
+	return []string{"service", secretServiceService, "name", s.name}
+}
+
+// Run secret-tool, including what it printed to stderr in any error
+func runSecretTool(cmd *exec.Cmd) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Stderr = stderr.Bytes()
+			message := strings.TrimSpace(stderr.String())
+			if message != "" {
+				return nil, fmt.Errorf("%w: %s", err, message)
+			}
+		}
+		return nil, err
+	}
+	return output, nil
+}
@@ -0,0 +1,74 @@
+package passwords
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The environment variable systemd sets to the folder holding a service's credentials
+const credentialsDirectoryEnvVar string = "CREDENTIALS_DIRECTORY"
+
+// Reads the password from a credential systemd passes to the daemon with LoadCredential= or LoadCredentialEncrypted=.
+// systemd owns the credential, so it can't be set or deleted here.
+type SystemdCredentialStore struct {
+	name string
+}
+
+// Create a new systemd credential password store
+func NewSystemdCredentialStore(name string) *SystemdCredentialStore {
+	return &SystemdCredentialStore{
+		name: name,
+	}
+}
+
+// Check if systemd passed the credential to the daemon
+func (s *SystemdCredentialStore) IsSet() (bool, error) {
+	path, err := s.getPath()
+	if err != nil {
+		return false, nil
+	}
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking credential '%s': %w", s.name, err)
+	}
+	return true, nil
+}
+
+// Get the password
+func (s *SystemdCredentialStore) Get() (string, error) {
+	path, err := s.getPath()
+	if err != nil {
+		return "", err
+	}
+	password, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from credential '%s': %w", s.name, err)
+	}
+
+	// Credentials are often created with a trailing newline
+	return strings.TrimRight(string(password), "\r\n"), nil
+}
+
+// The credential can't be set by the daemon
+func (s *SystemdCredentialStore) Set(password string) error {
+	return fmt.Errorf("The password is read from the systemd credential '%s' and can't be set by the Smartnode; create it with `systemd-creds encrypt` and add `LoadCredentialEncrypted=%s:<file>` to the daemons' service units", s.name, s.name)
+}
+
+// The credential can't be deleted by the daemon
+func (s *SystemdCredentialStore) Delete() error {
+	return fmt.Errorf("The password is read from the systemd credential '%s' and can't be deleted by the Smartnode; remove it from the daemons' service units instead", s.name)
+}
+
+// Get the path of the credential
+func (s *SystemdCredentialStore) getPath() (string, error) {
+	credentialsDirectory := os.Getenv(credentialsDirectoryEnvVar)
+	if credentialsDirectory == "" {
+		return "", fmt.Errorf("%s is not set; the daemon must be started by systemd with a LoadCredential= or LoadCredentialEncrypted= setting for '%s'", credentialsDirectoryEnvVar, s.name)
+	}
+	return filepath.Join(credentialsDirectory, s.name), nil
+}
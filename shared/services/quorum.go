@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A single endpoint's answer to a quorum read
+type quorumResult struct {
+	endpoint string
+	result   interface{}
+	err      error
+}
+
+// Compares two results of a quorum read, returning a description of how they differ or an empty string if they agree
+type quorumComparer func(expected interface{}, actual interface{}) string
+
+// Make sure enough of the quorum endpoints returned the same result as the reference client, logging each one that didn't.
+// Endpoints that return an error don't count towards the threshold, but don't fail the check on their own either.
+func checkQuorum(logger *log.ColorLogger, name string, reference quorumResult, results []quorumResult, threshold int, compare quorumComparer) error {
+	agreeing := 0
+	problems := []string{}
+	for _, result := range results {
+		if result.err != nil {
+			logger.Printlnf("WARNING: Quorum check for %s could not use %s, it returned an error (%s)", name, result.endpoint, result.err.Error())
+			problems = append(problems, fmt.Sprintf("%s returned an error", result.endpoint))
+			continue
+		}
+		difference := compare(reference.result, result.result)
+		if difference != "" {
+			logger.Printlnf("WARNING: Quorum mismatch for %s between %s and %s: %s", name, reference.endpoint, result.endpoint, difference)
+			problems = append(problems, fmt.Sprintf("%s disagreed (%s)", result.endpoint, difference))
+			continue
+		}
+		agreeing++
+	}
+
+	if agreeing < threshold {
+		return fmt.Errorf("quorum check for %s failed: only %d of %d endpoints agreed with %s but %d are required; %s", name, agreeing, len(results), reference.endpoint, threshold, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// Get the number of quorum endpoints that have to agree with the reference client; 0 means a majority of them
+func getQuorumThreshold(configured uint16, endpoints int) int {
+	if configured == 0 {
+		return endpoints/2 + 1
+	}
+	if int(configured) > endpoints {
+		return endpoints
+	}
+	return int(configured)
+}
+
+// Get a name for an endpoint that's safe to log, since URLs often carry API keys in their path or query
+func getEndpointName(endpoint string) string {
+	parsedUrl, err := url.Parse(endpoint)
+	if err != nil || parsedUrl.Host == "" {
+		return "<invalid URL>"
+	}
+	return fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
+}
+
+// Parse a comma-separated list of endpoints
+func parseQuorumEndpoints(value string) []string {
+	endpoints := []string{}
+	for _, endpoint := range strings.Split(value, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// Get the address a contract call was made to, for logging
+func getCallTarget(call ethereum.CallMsg) string {
+	if call.To == nil {
+		return "<contract creation>"
+	}
+	return call.To.Hex()
+}
+
+// Compare the results of contract calls
+func compareCallResults(expected interface{}, actual interface{}) string {
+	expectedBytes := expected.([]byte)
+	actualBytes := actual.([]byte)
+	if !bytes.Equal(expectedBytes, actualBytes) {
+		return fmt.Sprintf("call returned 0x%x instead of 0x%x", actualBytes, expectedBytes)
+	}
+	return ""
+}
+
+// Compare block headers by their hashes
+func compareHeaders(expected interface{}, actual interface{}) string {
+	expectedHeader := expected.(*types.Header)
+	actualHeader := actual.(*types.Header)
+	if expectedHeader.Hash() != actualHeader.Hash() {
+		return fmt.Sprintf("block %s has hash %s instead of %s", actualHeader.Number.String(), actualHeader.Hash().Hex(), expectedHeader.Hash().Hex())
+	}
+	return ""
+}
+
+// Compare the finalized checkpoints of two states by epoch and root
+func compareFinalizedCheckpoints(expected interface{}, actual interface{}) string {
+	expectedCheckpoint := expected.(beacon.FinalityCheckpoints).Finalized
+	actualCheckpoint := actual.(beacon.FinalityCheckpoints).Finalized
+	if expectedCheckpoint != actualCheckpoint {
+		return fmt.Sprintf("finalized checkpoint is epoch %d (root %s) instead of epoch %d (root %s)", actualCheckpoint.Epoch, actualCheckpoint.Root.Hex(), expectedCheckpoint.Epoch, expectedCheckpoint.Root.Hex())
+	}
+	return ""
+}
+
+// Compare two validator statuses
+func compareValidatorStatuses(expected interface{}, actual interface{}) string {
+	expectedStatus := expected.(beacon.ValidatorStatus)
+	actualStatus := actual.(beacon.ValidatorStatus)
+	return getValidatorStatusDifference(expectedStatus, actualStatus)
+}
+
+// Compare two sets of validator statuses
+func compareValidatorStatusMaps(expected interface{}, actual interface{}) string {
+	expectedStatuses := expected.(map[rptypes.ValidatorPubkey]beacon.ValidatorStatus)
+	actualStatuses := actual.(map[rptypes.ValidatorPubkey]beacon.ValidatorStatus)
+	if len(expectedStatuses) != len(actualStatuses) {
+		return fmt.Sprintf("returned %d validators instead of %d", len(actualStatuses), len(expectedStatuses))
+	}
+	for pubkey, expectedStatus := range expectedStatuses {
+		difference := getValidatorStatusDifference(expectedStatus, actualStatuses[pubkey])
+		if difference != "" {
+			return fmt.Sprintf("validator %s %s", pubkey.Hex(), difference)
+		}
+	}
+	return ""
+}
+
+// Describe how a validator's status differs from what was expected
+func getValidatorStatusDifference(expected beacon.ValidatorStatus, actual beacon.ValidatorStatus) string {
+	if expected == actual {
+		return ""
+	}
+	if expected.Balance != actual.Balance {
+		return fmt.Sprintf("has balance %d instead of %d", actual.Balance, expected.Balance)
+	}
+	if expected.Status != actual.Status {
+		return fmt.Sprintf("has status %s instead of %s", actual.Status, expected.Status)
+	}
+	return fmt.Sprintf("has status %+v instead of %+v", actual, expected)
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestCheckQuorum(t *testing.T) {
+	reference := quorumResult{endpoint: "http://primary:5052", result: []byte{0x01}}
+	agree := func(endpoint string) quorumResult {
+		return quorumResult{endpoint: endpoint, result: []byte{0x01}}
+	}
+	disagree := func(endpoint string) quorumResult {
+		return quorumResult{endpoint: endpoint, result: []byte{0x02}}
+	}
+	fail := func(endpoint string) quorumResult {
+		return quorumResult{endpoint: endpoint, err: errors.New("connection refused")}
+	}
+
+	tests := []struct {
+		name      string
+		results   []quorumResult
+		threshold int
+		wantErr   bool
+		errParts  []string
+	}{
+		{
+			name:      "all agree",
+			results:   []quorumResult{agree("http://a"), agree("http://b"), agree("http://c")},
+			threshold: 3,
+		},
+		{
+			name:      "one down within threshold",
+			results:   []quorumResult{agree("http://a"), fail("http://b"), agree("http://c")},
+			threshold: 2,
+		},
+		{
+			name:      "one disagreeing within threshold",
+			results:   []quorumResult{agree("http://a"), disagree("http://b"), agree("http://c")},
+			threshold: 2,
+		},
+		{
+			name:      "one down below threshold",
+			results:   []quorumResult{agree("http://a"), fail("http://b")},
+			threshold: 2,
+			wantErr:   true,
+			errParts:  []string{"only 1 of 2", "http://primary:5052", "http://b returned an error"},
+		},
+		{
+			name:      "disagreement below threshold",
+			results:   []quorumResult{disagree("http://a"), disagree("http://b"), agree("http://c")},
+			threshold: 2,
+			wantErr:   true,
+			errParts:  []string{"only 1 of 3", "http://a disagreed", "http://b disagreed"},
+		},
+	}
+
+	logger := log.NewColorLogger(color.FgWhite)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkQuorum(&logger, "test read", reference, test.results, test.threshold, compareCallResults)
+			if !test.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, part := range test.errParts {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("error %q does not contain %q", err.Error(), part)
+				}
+			}
+		})
+	}
+}
+
+func TestGetQuorumThreshold(t *testing.T) {
+	tests := []struct {
+		configured uint16
+		endpoints  int
+		want       int
+	}{
+		{configured: 0, endpoints: 1, want: 1},
+		{configured: 0, endpoints: 2, want: 2},
+		{configured: 0, endpoints: 3, want: 2},
+		{configured: 0, endpoints: 4, want: 3},
+		{configured: 1, endpoints: 3, want: 1},
+		{configured: 5, endpoints: 3, want: 3},
+	}
+
+	for _, test := range tests {
+		got := getQuorumThreshold(test.configured, test.endpoints)
+		if got != test.want {
+			t.Errorf("getQuorumThreshold(%d, %d) = %d, want %d", test.configured, test.endpoints, got, test.want)
+		}
+	}
+}
+
+func TestGetEndpointName(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "http://eth1:8545", want: "http://eth1:8545"},
+		{endpoint: "https://mainnet.provider.io/v3/secretkey", want: "https://mainnet.provider.io"},
+		{endpoint: "https://node.provider.io/?apikey=secret", want: "https://node.provider.io"},
+		{endpoint: "not a url", want: "<invalid URL>"},
+	}
+
+	for _, test := range tests {
+		got := getEndpointName(test.endpoint)
+		if got != test.want {
+			t.Errorf("getEndpointName(%q) = %q, want %q", test.endpoint, got, test.want)
+		}
+	}
+}
+
+// A Beacon client that reports a fixed head and finality checkpoints at a single pinned slot
+type fakeFinalityClient struct {
+	beacon.Client
+	head        beacon.BeaconHead
+	pinnedSlot  string
+	checkpoints beacon.FinalityCheckpoints
+	err         error
+}
+
+func (c *fakeFinalityClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	return c.head, nil
+}
+
+func (c *fakeFinalityClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return beacon.Eth2Config{SlotsPerEpoch: 32}, nil
+}
+
+func (c *fakeFinalityClient) GetFinalityCheckpoints(stateId string) (beacon.FinalityCheckpoints, error) {
+	if c.err != nil {
+		return beacon.FinalityCheckpoints{}, c.err
+	}
+	if stateId != c.pinnedSlot {
+		return beacon.FinalityCheckpoints{}, fmt.Errorf("unexpected state %s", stateId)
+	}
+	return c.checkpoints, nil
+}
+
+func TestGetBeaconHeadQuorum(t *testing.T) {
+	rootA := common.HexToHash("0xaa")
+	rootB := common.HexToHash("0xbb")
+	head := beacon.BeaconHead{Epoch: 10, FinalizedEpoch: 8}
+	finalized := func(epoch uint64, root common.Hash) *fakeFinalityClient {
+		return &fakeFinalityClient{
+			head:        head,
+			pinnedSlot:  "288",
+			checkpoints: beacon.FinalityCheckpoints{Finalized: beacon.Checkpoint{Epoch: epoch, Root: root}},
+		}
+	}
+	lagging := &fakeFinalityClient{err: errors.New("state not found")}
+
+	tests := []struct {
+		name              string
+		primary           *fakeFinalityClient
+		quorum            []*fakeFinalityClient
+		threshold         int
+		expectedFinalized uint64
+		wantErr           bool
+	}{
+		{
+			name:              "quorum agrees",
+			primary:           finalized(8, rootA),
+			quorum:            []*fakeFinalityClient{finalized(8, rootA), finalized(8, rootA)},
+			threshold:         2,
+			expectedFinalized: 8,
+		},
+		{
+			name:              "lagging client tolerated by the threshold",
+			primary:           finalized(8, rootA),
+			quorum:            []*fakeFinalityClient{finalized(8, rootA), lagging},
+			threshold:         1,
+			expectedFinalized: 8,
+		},
+		{
+			name:      "different root",
+			primary:   finalized(8, rootA),
+			quorum:    []*fakeFinalityClient{finalized(8, rootB)},
+			threshold: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "different epoch",
+			primary:   finalized(8, rootA),
+			quorum:    []*fakeFinalityClient{finalized(7, rootA)},
+			threshold: 1,
+			wantErr:   true,
+		},
+		{
+			name:              "head only reports finality the quorum checked",
+			primary:           finalized(7, rootA),
+			quorum:            []*fakeFinalityClient{finalized(7, rootA)},
+			threshold:         1,
+			expectedFinalized: 7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := &BeaconClientManager{
+				primaryBc:       test.primary,
+				primaryBcUrl:    "http://primary:5052",
+				primaryReady:    true,
+				logger:          log.NewColorLogger(color.FgWhite),
+				quorumThreshold: test.threshold,
+			}
+			for i, client := range test.quorum {
+				manager.quorumBcNames = append(manager.quorumBcNames, fmt.Sprintf("http://quorum%d:5052", i))
+				manager.quorumBcs = append(manager.quorumBcs, client)
+			}
+
+			result, err := manager.GetBeaconHead()
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if result.FinalizedEpoch != test.expectedFinalized {
+				t.Errorf("expected finalized epoch %d, got %d", test.expectedFinalized, result.FinalizedEpoch)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package replay
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Serializable wrappers for Beacon client calls with multiple return values
+type attestationsResponse struct {
+	Attestations []beacon.AttestationInfo `json:"attestations"`
+	Exists       bool                     `json:"exists"`
+}
+type beaconBlockResponse struct {
+	Block  beacon.BeaconBlock `json:"block"`
+	Exists bool               `json:"exists"`
+}
+type eth1DataResponse struct {
+	Eth1Data beacon.Eth1Data `json:"eth1Data"`
+	Exists   bool            `json:"exists"`
+}
+type validatorStatusesResponse struct {
+	Pubkeys  []types.ValidatorPubkey  `json:"pubkeys"`
+	Statuses []beacon.ValidatorStatus `json:"statuses"`
+}
+
+// Wraps a Beacon client and records every response into a fixture
+type BeaconClientRecorder struct {
+	bc      beacon.Client
+	fixture *Fixture
+}
+
+// Creates a new Beacon client recorder
+func NewBeaconClientRecorder(bc beacon.Client, fixture *Fixture) *BeaconClientRecorder {
+	return &BeaconClientRecorder{
+		bc:      bc,
+		fixture: fixture,
+	}
+}
+
+func (r *BeaconClientRecorder) GetClientType() (beacon.BeaconClientType, error) {
+	clientType, err := r.bc.GetClientType()
+	return clientType, r.record(clientType, err, "GetClientType")
+}
+
+func (r *BeaconClientRecorder) GetSyncStatus() (beacon.SyncStatus, error) {
+	// The chain head moves during a recording, so only the first read is kept
+	if r.fixture.hasRecorded(r.fixture.BeaconResponses, "GetSyncStatus") {
+		var status beacon.SyncStatus
+		err := r.fixture.replay(r.fixture.BeaconResponses, "GetSyncStatus", &status)
+		return status, err
+	}
+	status, err := r.bc.GetSyncStatus()
+	return status, r.record(status, err, "GetSyncStatus")
+}
+
+func (r *BeaconClientRecorder) GetEth2Config() (beacon.Eth2Config, error) {
+	config, err := r.bc.GetEth2Config()
+	return config, r.record(config, err, "GetEth2Config")
+}
+
+func (r *BeaconClientRecorder) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	contract, err := r.bc.GetEth2DepositContract()
+	return contract, r.record(contract, err, "GetEth2DepositContract")
+}
+
+func (r *BeaconClientRecorder) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	attestations, exists, err := r.bc.GetAttestations(blockId)
+	return attestations, exists, r.record(attestationsResponse{Attestations: attestations, Exists: exists}, err, "GetAttestations", blockId)
+}
+
+func (r *BeaconClientRecorder) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	block, exists, err := r.bc.GetBeaconBlock(blockId)
+	return block, exists, r.record(beaconBlockResponse{Block: block, Exists: exists}, err, "GetBeaconBlock", blockId)
+}
+
+func (r *BeaconClientRecorder) GetBeaconHead() (beacon.BeaconHead, error) {
+	// The chain head moves during a recording, so only the first read is kept
+	if r.fixture.hasRecorded(r.fixture.BeaconResponses, "GetBeaconHead") {
+		var head beacon.BeaconHead
+		err := r.fixture.replay(r.fixture.BeaconResponses, "GetBeaconHead", &head)
+		return head, err
+	}
+	head, err := r.bc.GetBeaconHead()
+	return head, r.record(head, err, "GetBeaconHead")
+}
+
+func (r *BeaconClientRecorder) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	status, err := r.bc.GetValidatorStatusByIndex(index, opts)
+	return status, r.record(status, err, "GetValidatorStatusByIndex", index, opts)
+}
+
+func (r *BeaconClientRecorder) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	status, err := r.bc.GetValidatorStatus(pubkey, opts)
+	return status, r.record(status, err, "GetValidatorStatus", pubkey, opts)
+}
+
+func (r *BeaconClientRecorder) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	statuses, err := r.bc.GetValidatorStatuses(pubkeys, opts)
+
+	// JSON doesn't support array map keys, so store the statuses as a list
+	response := validatorStatusesResponse{
+		Pubkeys:  make([]types.ValidatorPubkey, 0, len(statuses)),
+		Statuses: make([]beacon.ValidatorStatus, 0, len(statuses)),
+	}
+	for pubkey, status := range statuses {
+		response.Pubkeys = append(response.Pubkeys, pubkey)
+		response.Statuses = append(response.Statuses, status)
+	}
+	return statuses, r.record(response, err, "GetValidatorStatuses", pubkeys, opts)
+}
+
+func (r *BeaconClientRecorder) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
+	index, err := r.bc.GetValidatorIndex(pubkey)
+	return index, r.record(index, err, "GetValidatorIndex", pubkey)
+}
+
+func (r *BeaconClientRecorder) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	duties, err := r.bc.GetValidatorSyncDuties(indices, epoch)
+	return duties, r.record(duties, err, "GetValidatorSyncDuties", indices, epoch)
+}
+
+func (r *BeaconClientRecorder) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	duties, err := r.bc.GetValidatorProposerDuties(indices, epoch)
+	return duties, r.record(duties, err, "GetValidatorProposerDuties", indices, epoch)
+}
+
+func (r *BeaconClientRecorder) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	domainData, err := r.bc.GetDomainData(domainType, epoch, useGenesisFork)
+	return domainData, r.record(domainData, err, "GetDomainData", domainType, epoch, useGenesisFork)
+}
+
+func (r *BeaconClientRecorder) GetFork(stateId string) (beacon.Fork, error) {
+	fork, err := r.bc.GetFork(stateId)
+	return fork, r.record(fork, err, "GetFork", stateId)
+}
+
+func (r *BeaconClientRecorder) GetFinalityCheckpoints(stateId string) (beacon.FinalityCheckpoints, error) {
+	checkpoints, err := r.bc.GetFinalityCheckpoints(stateId)
+	return checkpoints, r.record(checkpoints, err, "GetFinalityCheckpoints", stateId)
+}
+
+func (r *BeaconClientRecorder) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	return r.bc.ExitValidator(validatorIndex, epoch, signature)
+}
+
+func (r *BeaconClientRecorder) Close() error {
+	return r.bc.Close()
+}
+
+func (r *BeaconClientRecorder) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	eth1Data, exists, err := r.bc.GetEth1DataForEth2Block(blockId)
+	return eth1Data, exists, r.record(eth1DataResponse{Eth1Data: eth1Data, Exists: exists}, err, "GetEth1DataForEth2Block", blockId)
+}
+
+func (r *BeaconClientRecorder) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
+	committees, err := r.bc.GetCommitteesForEpoch(epoch)
+	return committees, r.record(committees, err, "GetCommitteesForEpoch", epoch)
+}
+
+func (r *BeaconClientRecorder) ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	return r.bc.ChangeWithdrawalCredentials(validatorIndex, fromBlsPubkey, toExecutionAddress, signature)
+}
+
+func (r *BeaconClientRecorder) SubscribeEvents(topics []beacon.EventTopic, ch chan<- beacon.Event) (ethereum.Subscription, error) {
+	return r.bc.SubscribeEvents(topics, ch)
+}
+
+// Records a response in the fixture
+func (r *BeaconClientRecorder) record(response interface{}, callErr error, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return r.fixture.record(r.fixture.BeaconResponses, key, response, callErr)
+}
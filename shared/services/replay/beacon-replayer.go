@@ -0,0 +1,166 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A Beacon client that serves responses from a recorded fixture instead of a live node
+type BeaconClientReplayer struct {
+	fixture *Fixture
+}
+
+// Creates a new Beacon client replayer
+func NewBeaconClientReplayer(fixture *Fixture) *BeaconClientReplayer {
+	return &BeaconClientReplayer{
+		fixture: fixture,
+	}
+}
+
+func (r *BeaconClientReplayer) GetClientType() (beacon.BeaconClientType, error) {
+	var clientType beacon.BeaconClientType
+	err := r.replay(&clientType, "GetClientType")
+	return clientType, err
+}
+
+func (r *BeaconClientReplayer) GetSyncStatus() (beacon.SyncStatus, error) {
+	var status beacon.SyncStatus
+	err := r.replay(&status, "GetSyncStatus")
+	return status, err
+}
+
+func (r *BeaconClientReplayer) GetEth2Config() (beacon.Eth2Config, error) {
+	var config beacon.Eth2Config
+	err := r.replay(&config, "GetEth2Config")
+	return config, err
+}
+
+func (r *BeaconClientReplayer) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	var contract beacon.Eth2DepositContract
+	err := r.replay(&contract, "GetEth2DepositContract")
+	return contract, err
+}
+
+func (r *BeaconClientReplayer) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	var response attestationsResponse
+	err := r.replay(&response, "GetAttestations", blockId)
+	return response.Attestations, response.Exists, err
+}
+
+func (r *BeaconClientReplayer) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	var response beaconBlockResponse
+	err := r.replay(&response, "GetBeaconBlock", blockId)
+	return response.Block, response.Exists, err
+}
+
+func (r *BeaconClientReplayer) GetBeaconHead() (beacon.BeaconHead, error) {
+	var head beacon.BeaconHead
+	err := r.replay(&head, "GetBeaconHead")
+	return head, err
+}
+
+func (r *BeaconClientReplayer) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	var status beacon.ValidatorStatus
+	err := r.replay(&status, "GetValidatorStatusByIndex", index, opts)
+	return status, err
+}
+
+func (r *BeaconClientReplayer) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	var status beacon.ValidatorStatus
+	err := r.replay(&status, "GetValidatorStatus", pubkey, opts)
+	return status, err
+}
+
+func (r *BeaconClientReplayer) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	var response validatorStatusesResponse
+	err := r.replay(&response, "GetValidatorStatuses", pubkeys, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Pubkeys) != len(response.Statuses) {
+		return nil, fmt.Errorf("recorded validator statuses have %d pubkeys but %d statuses", len(response.Pubkeys), len(response.Statuses))
+	}
+
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(response.Pubkeys))
+	for i, pubkey := range response.Pubkeys {
+		statuses[pubkey] = response.Statuses[i]
+	}
+	return statuses, nil
+}
+
+func (r *BeaconClientReplayer) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
+	var index uint64
+	err := r.replay(&index, "GetValidatorIndex", pubkey)
+	return index, err
+}
+
+func (r *BeaconClientReplayer) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	var duties map[uint64]bool
+	err := r.replay(&duties, "GetValidatorSyncDuties", indices, epoch)
+	return duties, err
+}
+
+func (r *BeaconClientReplayer) GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	var duties map[uint64]uint64
+	err := r.replay(&duties, "GetValidatorProposerDuties", indices, epoch)
+	return duties, err
+}
+
+func (r *BeaconClientReplayer) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	var domainData []byte
+	err := r.replay(&domainData, "GetDomainData", domainType, epoch, useGenesisFork)
+	return domainData, err
+}
+
+func (r *BeaconClientReplayer) GetFork(stateId string) (beacon.Fork, error) {
+	var fork beacon.Fork
+	err := r.replay(&fork, "GetFork", stateId)
+	return fork, err
+}
+
+func (r *BeaconClientReplayer) GetFinalityCheckpoints(stateId string) (beacon.FinalityCheckpoints, error) {
+	var checkpoints beacon.FinalityCheckpoints
+	err := r.replay(&checkpoints, "GetFinalityCheckpoints", stateId)
+	return checkpoints, err
+}
+
+func (r *BeaconClientReplayer) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	return fmt.Errorf("exiting validators is not supported in replay mode")
+}
+
+func (r *BeaconClientReplayer) Close() error {
+	return nil
+}
+
+func (r *BeaconClientReplayer) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	var response eth1DataResponse
+	err := r.replay(&response, "GetEth1DataForEth2Block", blockId)
+	return response.Eth1Data, response.Exists, err
+}
+
+func (r *BeaconClientReplayer) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
+	var committees []beacon.Committee
+	err := r.replay(&committees, "GetCommitteesForEpoch", epoch)
+	return committees, err
+}
+
+func (r *BeaconClientReplayer) ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	return fmt.Errorf("changing withdrawal credentials is not supported in replay mode")
+}
+
+func (r *BeaconClientReplayer) SubscribeEvents(topics []beacon.EventTopic, ch chan<- beacon.Event) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("event subscriptions are not supported in replay mode")
+}
+
+// Retrieves a response from the fixture
+func (r *BeaconClientReplayer) replay(response interface{}, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return r.fixture.replay(r.fixture.BeaconResponses, key, response)
+}
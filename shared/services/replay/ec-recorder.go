@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Serializable wrapper for TransactionByHash, which has multiple return values
+type transactionResponse struct {
+	Transaction *types.Transaction `json:"transaction"`
+	IsPending   bool               `json:"isPending"`
+}
+
+// Wraps an Execution client and records every read into a fixture
+type ExecutionClientRecorder struct {
+	ec      rocketpool.ExecutionClient
+	fixture *Fixture
+}
+
+// Creates a new Execution client recorder
+func NewExecutionClientRecorder(ec rocketpool.ExecutionClient, fixture *Fixture) *ExecutionClientRecorder {
+	return &ExecutionClientRecorder{
+		ec:      ec,
+		fixture: fixture,
+	}
+}
+
+func (r *ExecutionClientRecorder) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	code, err := r.ec.CodeAt(ctx, contract, blockNumber)
+	return code, r.record(code, err, "CodeAt", contract, blockNumber)
+}
+
+func (r *ExecutionClientRecorder) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	result, err := r.ec.CallContract(ctx, call, blockNumber)
+	return result, r.record(result, err, "CallContract", call, blockNumber)
+}
+
+func (r *ExecutionClientRecorder) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	header, err := r.ec.HeaderByHash(ctx, hash)
+	return header, r.record(header, err, "HeaderByHash", hash)
+}
+
+func (r *ExecutionClientRecorder) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	// The latest block moves during a recording, so only its first read is kept
+	if number == nil && r.fixture.hasRecorded(r.fixture.ExecutionResponses, "HeaderByNumber/null") {
+		var header *types.Header
+		err := r.fixture.replay(r.fixture.ExecutionResponses, "HeaderByNumber/null", &header)
+		return header, err
+	}
+	header, err := r.ec.HeaderByNumber(ctx, number)
+	return header, r.record(header, err, "HeaderByNumber", number)
+}
+
+func (r *ExecutionClientRecorder) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	code, err := r.ec.PendingCodeAt(ctx, account)
+	return code, r.record(code, err, "PendingCodeAt", account)
+}
+
+func (r *ExecutionClientRecorder) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	nonce, err := r.ec.PendingNonceAt(ctx, account)
+	return nonce, r.record(nonce, err, "PendingNonceAt", account)
+}
+
+func (r *ExecutionClientRecorder) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	price, err := r.ec.SuggestGasPrice(ctx)
+	return price, r.record(price, err, "SuggestGasPrice")
+}
+
+func (r *ExecutionClientRecorder) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	tipCap, err := r.ec.SuggestGasTipCap(ctx)
+	return tipCap, r.record(tipCap, err, "SuggestGasTipCap")
+}
+
+func (r *ExecutionClientRecorder) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	gas, err := r.ec.EstimateGas(ctx, call)
+	return gas, r.record(gas, err, "EstimateGas", call)
+}
+
+func (r *ExecutionClientRecorder) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return r.ec.SendTransaction(ctx, tx)
+}
+
+func (r *ExecutionClientRecorder) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := r.ec.FilterLogs(ctx, query)
+	return logs, r.record(logs, err, "FilterLogs", query)
+}
+
+func (r *ExecutionClientRecorder) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return r.ec.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (r *ExecutionClientRecorder) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := r.ec.TransactionReceipt(ctx, txHash)
+	return receipt, r.record(receipt, err, "TransactionReceipt", txHash)
+}
+
+func (r *ExecutionClientRecorder) BlockNumber(ctx context.Context) (uint64, error) {
+	// The latest block moves during a recording, so only its first read is kept
+	if r.fixture.hasRecorded(r.fixture.ExecutionResponses, "BlockNumber") {
+		var blockNumber uint64
+		err := r.fixture.replay(r.fixture.ExecutionResponses, "BlockNumber", &blockNumber)
+		return blockNumber, err
+	}
+	blockNumber, err := r.ec.BlockNumber(ctx)
+	return blockNumber, r.record(blockNumber, err, "BlockNumber")
+}
+
+func (r *ExecutionClientRecorder) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	balance, err := r.ec.BalanceAt(ctx, account, blockNumber)
+	return balance, r.record(balance, err, "BalanceAt", account, blockNumber)
+}
+
+func (r *ExecutionClientRecorder) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, isPending, err := r.ec.TransactionByHash(ctx, hash)
+	return tx, isPending, r.record(transactionResponse{Transaction: tx, IsPending: isPending}, err, "TransactionByHash", hash)
+}
+
+func (r *ExecutionClientRecorder) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	nonce, err := r.ec.NonceAt(ctx, account, blockNumber)
+	return nonce, r.record(nonce, err, "NonceAt", account, blockNumber)
+}
+
+func (r *ExecutionClientRecorder) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	progress, err := r.ec.SyncProgress(ctx)
+	return progress, r.record(progress, err, "SyncProgress")
+}
+
+// Records a response in the fixture
+func (r *ExecutionClientRecorder) record(response interface{}, callErr error, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return r.fixture.record(r.fixture.ExecutionResponses, key, response, callErr)
+}
@@ -0,0 +1,142 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// An Execution client that serves responses from a recorded fixture instead of a live node
+type ExecutionClientReplayer struct {
+	fixture *Fixture
+}
+
+// Creates a new Execution client replayer
+func NewExecutionClientReplayer(fixture *Fixture) *ExecutionClientReplayer {
+	return &ExecutionClientReplayer{
+		fixture: fixture,
+	}
+}
+
+func (r *ExecutionClientReplayer) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := r.replay(&code, "CodeAt", contract, blockNumber)
+	return code, err
+}
+
+func (r *ExecutionClientReplayer) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := r.replay(&result, "CallContract", call, blockNumber)
+	return result, err
+}
+
+func (r *ExecutionClientReplayer) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var header *types.Header
+	err := r.replay(&header, "HeaderByHash", hash)
+	return header, err
+}
+
+func (r *ExecutionClientReplayer) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := r.replay(&header, "HeaderByNumber", number)
+	return header, err
+}
+
+func (r *ExecutionClientReplayer) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var code []byte
+	err := r.replay(&code, "PendingCodeAt", account)
+	return code, err
+}
+
+func (r *ExecutionClientReplayer) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	err := r.replay(&nonce, "PendingNonceAt", account)
+	return nonce, err
+}
+
+func (r *ExecutionClientReplayer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := r.replay(&price, "SuggestGasPrice")
+	return price, err
+}
+
+func (r *ExecutionClientReplayer) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tipCap *big.Int
+	err := r.replay(&tipCap, "SuggestGasTipCap")
+	return tipCap, err
+}
+
+func (r *ExecutionClientReplayer) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := r.replay(&gas, "EstimateGas", call)
+	return gas, err
+}
+
+func (r *ExecutionClientReplayer) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return fmt.Errorf("sending transactions is not supported in replay mode")
+}
+
+func (r *ExecutionClientReplayer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := r.replay(&logs, "FilterLogs", query)
+	return logs, err
+}
+
+func (r *ExecutionClientReplayer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("log subscriptions are not supported in replay mode")
+}
+
+func (r *ExecutionClientReplayer) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := r.replay(&receipt, "TransactionReceipt", txHash)
+	return receipt, err
+}
+
+func (r *ExecutionClientReplayer) BlockNumber(ctx context.Context) (uint64, error) {
+	var blockNumber uint64
+	err := r.replay(&blockNumber, "BlockNumber")
+	return blockNumber, err
+}
+
+func (r *ExecutionClientReplayer) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := r.replay(&balance, "BalanceAt", account, blockNumber)
+	return balance, err
+}
+
+func (r *ExecutionClientReplayer) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var response transactionResponse
+	err := r.replay(&response, "TransactionByHash", hash)
+	return response.Transaction, response.IsPending, err
+}
+
+func (r *ExecutionClientReplayer) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var nonce uint64
+	err := r.replay(&nonce, "NonceAt", account, blockNumber)
+	return nonce, err
+}
+
+func (r *ExecutionClientReplayer) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	var progress *ethereum.SyncProgress
+	err := r.replay(&progress, "SyncProgress")
+	return progress, err
+}
+
+// Retrieves a response from the fixture
+func (r *ExecutionClientReplayer) replay(response interface{}, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	err = r.fixture.replay(r.fixture.ExecutionResponses, key, response)
+
+	// Callers compare against this sentinel directly, so restore it
+	if err != nil && err.Error() == ethereum.NotFound.Error() {
+		return ethereum.NotFound
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The version of the fixture file format
+const FixtureVersion uint64 = 1
+
+// A single recorded call result
+type recordedResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// A recording of every Beacon and Execution client response used during a rewards tree generation
+type Fixture struct {
+	Version             uint64                      `json:"version"`
+	Network             string                      `json:"network"`
+	Index               uint64                      `json:"index"`
+	RulesetVersion      uint64                      `json:"rulesetVersion"`
+	CanonicalMerkleRoot common.Hash                 `json:"canonicalMerkleRoot"`
+	GoldenMerkleRoot    common.Hash                 `json:"goldenMerkleRoot"`
+	BeaconResponses     map[string]recordedResponse `json:"beaconResponses"`
+	ExecutionResponses  map[string]recordedResponse `json:"executionResponses"`
+
+	// Keys that were recorded more than once with different responses, which can't be replayed faithfully
+	collisions map[string]bool
+	lock       *sync.Mutex
+}
+
+// Creates a new, empty fixture for recording
+func NewFixture(network string, index uint64) *Fixture {
+	return &Fixture{
+		Version:            FixtureVersion,
+		Network:            network,
+		Index:              index,
+		BeaconResponses:    map[string]recordedResponse{},
+		ExecutionResponses: map[string]recordedResponse{},
+		collisions:         map[string]bool{},
+		lock:               &sync.Mutex{},
+	}
+}
+
+// Loads a compressed fixture from disk
+func LoadFixture(path string) (*Fixture, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %w", path, err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating compression decoder: %w", err)
+	}
+	defer decoder.Close()
+	fixtureBytes, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing fixture %s: %w", path, err)
+	}
+
+	fixture := &Fixture{}
+	err = json.Unmarshal(fixtureBytes, fixture)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing fixture %s: %w", path, err)
+	}
+	if fixture.Version != FixtureVersion {
+		return nil, fmt.Errorf("fixture %s has version %d but only version %d is supported", path, fixture.Version, FixtureVersion)
+	}
+	fixture.collisions = map[string]bool{}
+	fixture.lock = &sync.Mutex{}
+	return fixture, nil
+}
+
+// Get the keys that were recorded more than once with different responses
+func (f *Fixture) GetCollisions() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	collisions := make([]string, 0, len(f.collisions))
+	for key := range f.collisions {
+		collisions = append(collisions, key)
+	}
+	sort.Strings(collisions)
+	return collisions
+}
+
+// Saves the fixture to disk with zstd compression.
+// Fails if any key was recorded with different responses, since replaying it would only return the last one.
+func (f *Fixture) Save(path string) error {
+	collisions := f.GetCollisions()
+	if len(collisions) > 0 {
+		return fmt.Errorf("fixture can't be saved because %d calls returned different responses when they were repeated: %s", len(collisions), strings.Join(collisions, ", "))
+	}
+
+	f.lock.Lock()
+	fixtureBytes, err := json.Marshal(f)
+	f.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("error serializing fixture: %w", err)
+	}
+
+	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	compressedBytes := encoder.EncodeAll(fixtureBytes, make([]byte, 0, len(fixtureBytes)))
+	err = os.WriteFile(path, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving fixture to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Records the result of a call under the given key. If the call failed, its error is recorded and returned.
+func (f *Fixture) record(responses map[string]recordedResponse, key string, result interface{}, callErr error) error {
+	response := recordedResponse{}
+	if callErr != nil {
+		response.Error = callErr.Error()
+	} else {
+		resultBytes, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("error serializing recorded response for %s: %w", key, err)
+		}
+		response.Result = resultBytes
+	}
+
+	f.lock.Lock()
+	existing, exists := responses[key]
+	if exists && (existing.Error != response.Error || !bytes.Equal(existing.Result, response.Result)) {
+		f.collisions[key] = true
+	}
+	responses[key] = response
+	f.lock.Unlock()
+	return callErr
+}
+
+// Check if a response has already been recorded for the given key
+func (f *Fixture) hasRecorded(responses map[string]recordedResponse, key string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	_, exists := responses[key]
+	return exists
+}
+
+// Retrieves the recorded result for the given key, returning the recorded error if the original call failed
+func (f *Fixture) replay(responses map[string]recordedResponse, key string, result interface{}) error {
+	f.lock.Lock()
+	response, exists := responses[key]
+	f.lock.Unlock()
+	if !exists {
+		return fmt.Errorf("fixture does not contain a recorded response for %s", key)
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	err := json.Unmarshal(response.Result, result)
+	if err != nil {
+		return fmt.Errorf("error deserializing recorded response for %s: %w", key, err)
+	}
+	return nil
+}
+
+// Creates a stable key for a call from its method name and arguments
+func getKey(method string, args ...interface{}) (string, error) {
+	builder := strings.Builder{}
+	builder.WriteString(method)
+	for _, arg := range args {
+		argBytes, err := json.Marshal(arg)
+		if err != nil {
+			return "", fmt.Errorf("error serializing argument for %s: %w", method, err)
+		}
+		builder.WriteString("/")
+		builder.Write(argBytes)
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,117 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestFixtureCollisions(t *testing.T) {
+	tests := []struct {
+		name          string
+		first         interface{}
+		firstErr      error
+		second        interface{}
+		secondErr     error
+		wantCollision bool
+	}{
+		{name: "same result", first: uint64(1), second: uint64(1)},
+		{name: "same error", firstErr: errors.New("not found"), secondErr: errors.New("not found")},
+		{name: "different result", first: uint64(1), second: uint64(2), wantCollision: true},
+		{name: "result then error", first: uint64(1), secondErr: errors.New("not found"), wantCollision: true},
+		{name: "different errors", firstErr: errors.New("timeout"), secondErr: errors.New("not found"), wantCollision: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fixture := NewFixture("mainnet", 1)
+			_ = fixture.record(fixture.ExecutionResponses, "key", test.first, test.firstErr)
+			_ = fixture.record(fixture.ExecutionResponses, "key", test.second, test.secondErr)
+
+			collisions := fixture.GetCollisions()
+			if test.wantCollision != (len(collisions) == 1) {
+				t.Fatalf("got collisions %v, want collision %t", collisions, test.wantCollision)
+			}
+
+			err := fixture.Save(filepath.Join(t.TempDir(), "fixture.json.zst"))
+			if test.wantCollision {
+				if err == nil || !strings.Contains(err.Error(), "key") {
+					t.Errorf("expected saving to fail and name the colliding key, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error saving fixture: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestFixtureRoundTrip(t *testing.T) {
+	fixture := NewFixture("mainnet", 7)
+	fixture.RulesetVersion = 8
+	_ = fixture.record(fixture.BeaconResponses, "GetBeaconBlock/\"100\"", beaconBlockResponse{Exists: true}, nil)
+	_ = fixture.record(fixture.ExecutionResponses, "BlockNumber", uint64(1234), nil)
+	_ = fixture.record(fixture.ExecutionResponses, "PendingNonceAt", nil, errors.New("unavailable"))
+
+	path := filepath.Join(t.TempDir(), "fixture.json.zst")
+	if err := fixture.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFixture(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Network != "mainnet" || loaded.Index != 7 || loaded.RulesetVersion != 8 {
+		t.Errorf("got fixture header %s/%d/%d", loaded.Network, loaded.Index, loaded.RulesetVersion)
+	}
+
+	var blockNumber uint64
+	if err := loaded.replay(loaded.ExecutionResponses, "BlockNumber", &blockNumber); err != nil || blockNumber != 1234 {
+		t.Errorf("got block number %d (%v), want 1234", blockNumber, err)
+	}
+	var nonce uint64
+	if err := loaded.replay(loaded.ExecutionResponses, "PendingNonceAt", &nonce); err == nil || err.Error() != "unavailable" {
+		t.Errorf("got error %v, want the recorded error", err)
+	}
+	if err := loaded.replay(loaded.ExecutionResponses, "Missing", &nonce); err == nil {
+		t.Error("expected an error for a call that wasn't recorded")
+	}
+}
+
+// An Execution client that only answers HeaderByNumber, with a new block every time
+type movingHeadClient struct {
+	ExecutionClientRecorder
+	calls int64
+}
+
+func (c *movingHeadClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.calls++
+	if number == nil {
+		return &types.Header{Number: big.NewInt(100 + c.calls), Difficulty: big.NewInt(0)}, nil
+	}
+	return &types.Header{Number: number, Difficulty: big.NewInt(0)}, nil
+}
+
+func TestRecorderKeepsFirstLatestHeader(t *testing.T) {
+	fixture := NewFixture("mainnet", 1)
+	recorder := NewExecutionClientRecorder(&movingHeadClient{}, fixture)
+
+	first, err := recorder.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := recorder.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Number.Cmp(second.Number) != 0 {
+		t.Errorf("latest header moved from %s to %s during the recording", first.Number.String(), second.Number.String())
+	}
+	if collisions := fixture.GetCollisions(); len(collisions) != 0 {
+		t.Errorf("got collisions %v", collisions)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/urfave/cli"
 )
 
@@ -166,13 +167,32 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 			return err
 		}
 		if nodePasswordSet {
-			return nil
+			break
 		}
 		if verbose {
 			log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
 		}
 		time.Sleep(checkNodePasswordInterval)
 	}
+	for {
+		nodePasswordLocked, err := getNodePasswordLocked(c)
+		if errors.Is(err, passwords.ErrIncorrectUnlockKey) {
+			// The passphrase can be provided again without restarting this process, so keep waiting for it
+			log.Printf("The passphrase provided for the node password is incorrect; re-run `rocketpool service start` to enter it again. Retrying in %s...\n", checkNodePasswordInterval.String())
+			time.Sleep(checkNodePasswordInterval)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !nodePasswordLocked {
+			return nil
+		}
+		if verbose {
+			log.Printf("The node password is locked until its passphrase is provided with `rocketpool service start`, retrying in %s...\n", checkNodePasswordInterval.String())
+		}
+		time.Sleep(checkNodePasswordInterval)
+	}
 }
 
 func WaitNodeWallet(c *cli.Context, verbose bool) error {
@@ -258,6 +278,22 @@ func getNodePasswordSet(c *cli.Context) (bool, error) {
 	return pm.IsPasswordSet(), nil
 }
 
+// Check if the node password is set but can't be read until its passphrase is provided
+func getNodePasswordLocked(c *cli.Context) (bool, error) {
+	pm, err := GetPasswordManager(c)
+	if err != nil {
+		return false, err
+	}
+	_, err = pm.GetPassword()
+	if errors.Is(err, passwords.ErrPasswordLocked) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error getting node password: %w", err)
+	}
+	return false, nil
+}
+
 // Check if the node wallet is initialized
 func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	w, err := GetWallet(c)
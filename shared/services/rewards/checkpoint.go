@@ -0,0 +1,256 @@
+package rewards
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Settings
+const (
+	RewardsCheckpointVersion       uint64 = 2
+	RewardsCheckpointEpochInterval uint64 = 100
+)
+
+// The attestation progress of a single minipool at the time of a checkpoint.
+// Only the number of completed attestations is kept, since the slots themselves are never used again and would make every checkpoint grow with the interval.
+type checkpointMinipoolInfo struct {
+	MissingAttestationSlots []uint64      `json:"missingAttestationSlots"`
+	CompletedAttestations   uint64        `json:"completedAttestations"`
+	AttestationScore        *QuotedBigInt `json:"attestationScore"`
+}
+
+// A committee that still has outstanding Rocket Pool attestations, with minipools referenced by address
+type checkpointCommitteeInfo struct {
+	Index     uint64                 `json:"index"`
+	Positions map[int]common.Address `json:"positions"`
+}
+
+// A slot that still has outstanding Rocket Pool attestations
+type checkpointSlotInfo struct {
+	Index      uint64                              `json:"index"`
+	Committees map[uint64]*checkpointCommitteeInfo `json:"committees"`
+}
+
+// The saved progress of a tree generator's attestation processing, used to resume after a restart
+type rewardsCheckpoint struct {
+	Version                uint64                                     `json:"version"`
+	Checksum               string                                     `json:"checksum"`
+	NextEpoch              uint64                                     `json:"nextEpoch"`
+	TotalAttestationScore  *QuotedBigInt                              `json:"totalAttestationScore"`
+	SuccessfulAttestations uint64                                     `json:"successfulAttestations"`
+	Slots                  map[uint64]*checkpointSlotInfo             `json:"slots"`
+	Minipools              map[common.Address]*checkpointMinipoolInfo `json:"minipools"`
+}
+
+// Creates a checksum that identifies the interval and snapshot a checkpoint belongs to, so checkpoints for a different snapshot are never resumed
+func getCheckpointChecksum(rewardsFile *RewardsFile, elSnapshotBlockHash common.Hash) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(rewardsFile.Network))
+	for _, value := range []uint64{
+		RewardsCheckpointVersion,
+		rewardsFile.RulesetVersion,
+		rewardsFile.Index,
+		rewardsFile.ConsensusStartBlock,
+		rewardsFile.ConsensusEndBlock,
+		rewardsFile.ExecutionStartBlock,
+		rewardsFile.ExecutionEndBlock,
+	} {
+		buffer := make([]byte, 8)
+		binary.BigEndian.PutUint64(buffer, value)
+		hasher.Write(buffer)
+	}
+	hasher.Write(elSnapshotBlockHash.Bytes())
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// Creates a checkpoint from the current attestation processing state
+func createRewardsCheckpoint(checksum string, nextEpoch uint64, intervalDutiesInfo *IntervalDutiesInfo, validatorIndexMap map[uint64]*MinipoolInfo, totalAttestationScore *big.Int, successfulAttestations uint64) *rewardsCheckpoint {
+	checkpoint := &rewardsCheckpoint{
+		Version:                RewardsCheckpointVersion,
+		Checksum:               checksum,
+		NextEpoch:              nextEpoch,
+		TotalAttestationScore:  &QuotedBigInt{Int: *totalAttestationScore},
+		SuccessfulAttestations: successfulAttestations,
+		Slots:                  map[uint64]*checkpointSlotInfo{},
+		Minipools:              map[common.Address]*checkpointMinipoolInfo{},
+	}
+
+	for _, minipoolInfo := range validatorIndexMap {
+		checkpoint.Minipools[minipoolInfo.Address] = &checkpointMinipoolInfo{
+			MissingAttestationSlots: getSortedSlots(minipoolInfo.MissingAttestationSlots),
+			CompletedAttestations:   uint64(len(minipoolInfo.CompletedAttestations)),
+			AttestationScore:        &QuotedBigInt{Int: *minipoolInfo.AttestationScore},
+		}
+	}
+
+	for slotIndex, slotInfo := range intervalDutiesInfo.Slots {
+		checkpointSlot := &checkpointSlotInfo{
+			Index:      slotInfo.Index,
+			Committees: map[uint64]*checkpointCommitteeInfo{},
+		}
+		for committeeIndex, committeeInfo := range slotInfo.Committees {
+			checkpointCommittee := &checkpointCommitteeInfo{
+				Index:     committeeInfo.Index,
+				Positions: map[int]common.Address{},
+			}
+			for position, minipoolInfo := range committeeInfo.Positions {
+				checkpointCommittee.Positions[position] = minipoolInfo.Address
+			}
+			checkpointSlot.Committees[committeeIndex] = checkpointCommittee
+		}
+		checkpoint.Slots[slotIndex] = checkpointSlot
+	}
+
+	return checkpoint
+}
+
+// Restores a checkpoint's attestation progress into the minipools of the given index map, returning the restored duties
+func (c *rewardsCheckpoint) restore(index uint64, consensusStartSlot uint64, validatorIndexMap map[uint64]*MinipoolInfo) (*IntervalDutiesInfo, error) {
+	// Map the live minipools by address so the duties can point to them again
+	minipoolsByAddress := map[common.Address]*MinipoolInfo{}
+	for _, minipoolInfo := range validatorIndexMap {
+		minipoolsByAddress[minipoolInfo.Address] = minipoolInfo
+	}
+	if len(minipoolsByAddress) != len(c.Minipools) {
+		return nil, fmt.Errorf("checkpoint has %d minipools but the interval has %d", len(c.Minipools), len(minipoolsByAddress))
+	}
+
+	// Validate everything before modifying any minipools
+	for address, checkpointMinipool := range c.Minipools {
+		if _, exists := minipoolsByAddress[address]; !exists {
+			return nil, fmt.Errorf("checkpoint has minipool %s which is not part of the interval", address.Hex())
+		}
+		if checkpointMinipool.CompletedAttestations > consensusStartSlot {
+			return nil, fmt.Errorf("checkpoint has %d completed attestations for minipool %s, which is more than the interval can have", checkpointMinipool.CompletedAttestations, address.Hex())
+		}
+	}
+	intervalDutiesInfo := &IntervalDutiesInfo{
+		Index: index,
+		Slots: map[uint64]*SlotInfo{},
+	}
+	for slotIndex, checkpointSlot := range c.Slots {
+		slotInfo := &SlotInfo{
+			Index:      checkpointSlot.Index,
+			Committees: map[uint64]*CommitteeInfo{},
+		}
+		for committeeIndex, checkpointCommittee := range checkpointSlot.Committees {
+			committeeInfo := &CommitteeInfo{
+				Index:     checkpointCommittee.Index,
+				Positions: map[int]*MinipoolInfo{},
+			}
+			for position, address := range checkpointCommittee.Positions {
+				minipoolInfo, exists := minipoolsByAddress[address]
+				if !exists {
+					return nil, fmt.Errorf("checkpoint has a duty in slot %d for minipool %s which is not part of the interval", slotIndex, address.Hex())
+				}
+				committeeInfo.Positions[position] = minipoolInfo
+			}
+			slotInfo.Committees[committeeIndex] = committeeInfo
+		}
+		intervalDutiesInfo.Slots[slotIndex] = slotInfo
+	}
+
+	// Restore the minipool progress
+	for address, checkpointMinipool := range c.Minipools {
+		minipoolInfo := minipoolsByAddress[address]
+		minipoolInfo.MissingAttestationSlots = map[uint64]bool{}
+		for _, slot := range checkpointMinipool.MissingAttestationSlots {
+			minipoolInfo.MissingAttestationSlots[slot] = true
+		}
+		// Only the number of completed attestations matters, so they're restored as placeholder slots from before the interval that can't collide with the ones still to be found
+		minipoolInfo.CompletedAttestations = make(map[uint64]bool, checkpointMinipool.CompletedAttestations)
+		for slot := uint64(0); slot < checkpointMinipool.CompletedAttestations; slot++ {
+			minipoolInfo.CompletedAttestations[slot] = true
+		}
+		minipoolInfo.AttestationScore.Set(&checkpointMinipool.AttestationScore.Int)
+	}
+
+	return intervalDutiesInfo, nil
+}
+
+// Loads a checkpoint from disk, returning nil if there isn't one
+func loadRewardsCheckpoint(path string) (*rewardsCheckpoint, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint %s: %w", path, err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating compression decoder: %w", err)
+	}
+	defer decoder.Close()
+	checkpointBytes, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing checkpoint %s: %w", path, err)
+	}
+
+	checkpoint := &rewardsCheckpoint{}
+	err = json.Unmarshal(checkpointBytes, checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing checkpoint %s: %w", path, err)
+	}
+	if checkpoint.Version != RewardsCheckpointVersion {
+		return nil, fmt.Errorf("checkpoint %s has version %d but only version %d is supported", path, checkpoint.Version, RewardsCheckpointVersion)
+	}
+	return checkpoint, nil
+}
+
+// Saves a checkpoint to disk, replacing the previous one atomically so a crash mid-write can't corrupt it
+func (c *rewardsCheckpoint) save(path string) error {
+	checkpointBytes, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error serializing checkpoint: %w", err)
+	}
+	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	compressedBytes := encoder.EncodeAll(checkpointBytes, make([]byte, 0, len(checkpointBytes)))
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating checkpoint directory: %w", err)
+	}
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing checkpoint to %s: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving checkpoint to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Deletes the checkpoint at the given path if it exists
+func deleteRewardsCheckpoint(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting checkpoint %s: %w", path, err)
+	}
+	return nil
+}
+
+// Converts a set of slots into a sorted list
+func getSortedSlots(slots map[uint64]bool) []uint64 {
+	sortedSlots := make([]uint64, 0, len(slots))
+	for slot := range slots {
+		sortedSlots = append(sortedSlots, slot)
+	}
+	sort.Slice(sortedSlots, func(i, j int) bool {
+		return sortedSlots[i] < sortedSlots[j]
+	})
+	return sortedSlots
+}
@@ -0,0 +1,124 @@
+package rewards
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testConsensusStartSlot uint64 = 1000
+
+func newCheckpointTestMinipools() map[uint64]*MinipoolInfo {
+	return map[uint64]*MinipoolInfo{
+		1: {
+			Address:                 common.HexToAddress("0x01"),
+			MissingAttestationSlots: map[uint64]bool{1010: true, 1040: true},
+			CompletedAttestations:   map[uint64]bool{1001: true, 1033: true, 1065: true},
+			AttestationScore:        big.NewInt(3),
+		},
+		2: {
+			Address:                 common.HexToAddress("0x02"),
+			MissingAttestationSlots: map[uint64]bool{},
+			CompletedAttestations:   map[uint64]bool{},
+			AttestationScore:        big.NewInt(0),
+		},
+	}
+}
+
+func TestRewardsCheckpointRoundTrip(t *testing.T) {
+	minipools := newCheckpointTestMinipools()
+	duties := &IntervalDutiesInfo{
+		Index: 7,
+		Slots: map[uint64]*SlotInfo{
+			1040: {Index: 1040, Committees: map[uint64]*CommitteeInfo{3: {Index: 3, Positions: map[int]*MinipoolInfo{12: minipools[1]}}}},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.zst")
+	if err := createRewardsCheckpoint("checksum", 100, duties, minipools, big.NewInt(3), 3).save(path); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := loadRewardsCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoredMinipools := newCheckpointTestMinipools()
+	for _, minipool := range restoredMinipools {
+		minipool.MissingAttestationSlots = map[uint64]bool{}
+		minipool.CompletedAttestations = map[uint64]bool{}
+		minipool.AttestationScore = big.NewInt(0)
+	}
+	restoredDuties, err := checkpoint.restore(7, testConsensusStartSlot, restoredMinipools)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for index, minipool := range minipools {
+		restored := restoredMinipools[index]
+		if len(restored.CompletedAttestations) != len(minipool.CompletedAttestations) {
+			t.Errorf("minipool %d has %d completed attestations, want %d", index, len(restored.CompletedAttestations), len(minipool.CompletedAttestations))
+		}
+		for slot := range restored.CompletedAttestations {
+			if slot >= testConsensusStartSlot {
+				t.Errorf("minipool %d has placeholder slot %d inside the interval", index, slot)
+			}
+		}
+		if len(restored.MissingAttestationSlots) != len(minipool.MissingAttestationSlots) {
+			t.Errorf("minipool %d has missing slots %v, want %v", index, restored.MissingAttestationSlots, minipool.MissingAttestationSlots)
+		}
+		for slot := range minipool.MissingAttestationSlots {
+			if !restored.MissingAttestationSlots[slot] {
+				t.Errorf("minipool %d lost missing slot %d", index, slot)
+			}
+		}
+		if restored.AttestationScore.Cmp(minipool.AttestationScore) != 0 {
+			t.Errorf("minipool %d has score %s, want %s", index, restored.AttestationScore.String(), minipool.AttestationScore.String())
+		}
+	}
+	if restoredDuties.Slots[1040].Committees[3].Positions[12] != restoredMinipools[1] {
+		t.Error("restored duty doesn't point to the live minipool")
+	}
+}
+
+func TestRewardsCheckpointRestoreErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		minipools map[common.Address]*checkpointMinipoolInfo
+		wantErr   string
+	}{
+		{
+			name:      "missing minipool",
+			minipools: map[common.Address]*checkpointMinipoolInfo{common.HexToAddress("0x01"): {AttestationScore: &QuotedBigInt{}}},
+			wantErr:   "checkpoint has 1 minipools",
+		},
+		{
+			name: "unknown minipool",
+			minipools: map[common.Address]*checkpointMinipoolInfo{
+				common.HexToAddress("0x01"): {AttestationScore: &QuotedBigInt{}},
+				common.HexToAddress("0x03"): {AttestationScore: &QuotedBigInt{}},
+			},
+			wantErr: "not part of the interval",
+		},
+		{
+			name: "too many completed attestations",
+			minipools: map[common.Address]*checkpointMinipoolInfo{
+				common.HexToAddress("0x01"): {CompletedAttestations: testConsensusStartSlot + 1, AttestationScore: &QuotedBigInt{}},
+				common.HexToAddress("0x02"): {AttestationScore: &QuotedBigInt{}},
+			},
+			wantErr: "more than the interval can have",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkpoint := &rewardsCheckpoint{Minipools: test.minipools}
+			_, err := checkpoint.restore(7, testConsensusStartSlot, newCheckpointTestMinipools())
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -54,6 +54,7 @@ type treeGeneratorImpl_v5 struct {
 	totalAttestationScore  *big.Int
 	successfulAttestations uint64
 	zero                   *big.Int
+	checkpointPath         string
 }
 
 // Create a new tree generator
@@ -104,6 +105,11 @@ func (r *treeGeneratorImpl_v5) getRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
+// Set the path to save attestation progress to, so an interrupted generation can resume from it
+func (r *treeGeneratorImpl_v5) setCheckpointPath(path string) {
+	r.checkpointPath = path
+}
+
 func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*RewardsFile, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
@@ -163,6 +169,14 @@ func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *conf
 		})
 	}
 
+	// The checkpoint isn't needed anymore once the tree is done
+	if r.checkpointPath != "" {
+		err = deleteRewardsCheckpoint(r.checkpointPath)
+		if err != nil {
+			r.log.Printlnf("%s WARNING: %s", r.logPrefix, err.Error())
+		}
+	}
+
 	return r.rewardsFile, nil
 
 }
@@ -742,9 +756,17 @@ func (r *treeGeneratorImpl_v5) processAttestationsForInterval() error {
 	r.log.Printlnf("%s Checking participation of %d minipools for epochs %d to %d", r.logPrefix, len(r.validatorIndexMap), startEpoch, endEpoch)
 	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every 100 epochs", r.logPrefix)
 
+	// Resume from the last checkpoint if there is one
+	firstEpoch := startEpoch
+	checksum := ""
+	if r.checkpointPath != "" {
+		checksum = getCheckpointChecksum(r.rewardsFile, r.elSnapshotHeader.Hash())
+		firstEpoch = r.restoreCheckpoint(checksum, startEpoch, endEpoch)
+	}
+
 	epochsDone := 0
 	reportStartTime := time.Now()
-	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+	for epoch := firstEpoch; epoch < endEpoch+1; epoch++ {
 		if epochsDone == 100 {
 			timeTaken := time.Since(reportStartTime)
 			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
@@ -756,6 +778,15 @@ func (r *treeGeneratorImpl_v5) processAttestationsForInterval() error {
 			return err
 		}
 
+		// Save the progress periodically
+		if r.checkpointPath != "" && (epoch-startEpoch+1)%RewardsCheckpointEpochInterval == 0 {
+			checkpoint := createRewardsCheckpoint(checksum, epoch+1, r.intervalDutiesInfo, r.validatorIndexMap, r.totalAttestationScore, r.successfulAttestations)
+			err = checkpoint.save(r.checkpointPath)
+			if err != nil {
+				r.log.Printlnf("%s WARNING: couldn't save checkpoint: %s", r.logPrefix, err.Error())
+			}
+		}
+
 		epochsDone++
 	}
 
@@ -771,6 +802,40 @@ func (r *treeGeneratorImpl_v5) processAttestationsForInterval() error {
 
 }
 
+// Restores the attestation progress from a checkpoint if a valid one exists, returning the first epoch that still needs to be processed
+func (r *treeGeneratorImpl_v5) restoreCheckpoint(checksum string, startEpoch uint64, endEpoch uint64) uint64 {
+
+	checkpoint, err := loadRewardsCheckpoint(r.checkpointPath)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: ignoring checkpoint: %s", r.logPrefix, err.Error())
+		return startEpoch
+	}
+	if checkpoint == nil {
+		return startEpoch
+	}
+	if checkpoint.Checksum != checksum {
+		r.log.Printlnf("%s Ignoring checkpoint at %s because it was created for a different snapshot.", r.logPrefix, r.checkpointPath)
+		return startEpoch
+	}
+	if checkpoint.NextEpoch <= startEpoch || checkpoint.NextEpoch > endEpoch+1 {
+		r.log.Printlnf("%s Ignoring checkpoint at %s because its next epoch (%d) is outside of the interval (%d to %d).", r.logPrefix, r.checkpointPath, checkpoint.NextEpoch, startEpoch, endEpoch)
+		return startEpoch
+	}
+
+	intervalDutiesInfo, err := checkpoint.restore(r.rewardsFile.Index, r.rewardsFile.ConsensusStartBlock, r.validatorIndexMap)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: ignoring checkpoint: %s", r.logPrefix, err.Error())
+		return startEpoch
+	}
+	r.intervalDutiesInfo = intervalDutiesInfo
+	r.totalAttestationScore.Set(&checkpoint.TotalAttestationScore.Int)
+	r.successfulAttestations = checkpoint.SuccessfulAttestations
+
+	r.log.Printlnf("%s Resuming participation check from the checkpoint at epoch %d.", r.logPrefix, checkpoint.NextEpoch)
+	return checkpoint.NextEpoch
+
+}
+
 // Process an epoch, optionally getting the duties for all eligible minipools in it and checking each one's attestation performance
 func (r *treeGeneratorImpl_v5) processEpoch(getDuties bool, epoch uint64) error {
 
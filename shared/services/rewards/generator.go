@@ -55,6 +55,11 @@ type treeGeneratorImpl interface {
 	getRulesetVersion() uint64
 }
 
+// Implemented by generators that can save their attestation progress and resume it after a restart
+type checkpointingTreeGeneratorImpl interface {
+	setCheckpointPath(path string)
+}
+
 func NewTreeGenerator(logger log.ColorLogger, logPrefix string, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState) (*TreeGenerator, error) {
 	t := &TreeGenerator{
 		logger:           logger,
@@ -150,6 +155,16 @@ func NewTreeGenerator(logger log.ColorLogger, logPrefix string, rp *rocketpool.R
 	return t, nil
 }
 
+// Saves the attestation progress of generators that support it to the given path, so an interrupted generation can resume from it
+func (t *TreeGenerator) EnableCheckpoints(path string) {
+	for _, info := range t.rewardsIntervalInfos {
+		generator, ok := info.generator.(checkpointingTreeGeneratorImpl)
+		if ok {
+			generator.setCheckpointPath(path)
+		}
+	}
+}
+
 func (t *TreeGenerator) GenerateTree() (*RewardsFile, error) {
 	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc)
 }
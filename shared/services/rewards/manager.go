@@ -5,10 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
 	"math/big"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +20,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/storage"
 )
 
 const (
@@ -92,6 +91,7 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	}
 
 	info.CID = event.MerkleTreeCID
+	info.MerkleRoot = event.MerkleRoot
 	info.StartTime = event.IntervalStartTime
 	info.EndTime = event.IntervalEndTime
 	merkleRootCanon := event.MerkleRoot
@@ -119,9 +119,8 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 		return
 	}
 
-	// Make sure the Merkle root has the expected value
-	merkleRootFromFile := common.HexToHash(proofWrapper.MerkleRoot)
-	if merkleRootCanon != merkleRootFromFile {
+	// Make sure the file matches the canonical event, including the tree rebuilt from its rewards
+	if VerifyRewardsFile(&proofWrapper, interval, fmt.Sprint(cfg.Smartnode.Network.Value), merkleRootCanon) != nil {
 		info.MerkleRootValid = false
 		return
 	}
@@ -331,61 +330,94 @@ func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*
 	}
 }
 
-// Downloads a single rewards file
-func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
+// Downloads a single rewards file, rebuilding its Merkle tree and making sure it matches the canonical rewards event before saving it.
+// Files that fail verification are skipped in favor of the next source, and are returned so they can be reported.
+func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, merkleRoot common.Hash, isDaemon bool) ([]RewardsFileRejection, error) {
 
 	// Determine file name and path
 	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(interval, isDaemon))
 	if err != nil {
-		return fmt.Errorf("error expanding rewards tree path: %w", err)
+		return nil, fmt.Errorf("error expanding rewards tree path: %w", err)
 	}
-	rewardsTreeFilename := filepath.Base(rewardsTreePath)
-	ipfsFilename := rewardsTreeFilename + config.RewardsTreeIpfsExtension
+	network := fmt.Sprint(cfg.Smartnode.Network.Value)
+	return downloadFile(cfg, rewardsTreePath, cid, fmt.Sprintf("interval %d file", interval), func(fileBytes []byte) error {
+		var rewardsFile RewardsFile
+		err := json.Unmarshal(fileBytes, &rewardsFile)
+		if err != nil {
+			return fmt.Errorf("error deserializing file: %w", err)
+		}
+		return VerifyRewardsFile(&rewardsFile, interval, network, merkleRoot)
+	})
+
+}
 
-	// Create URL list
-	urls := []string{
-		fmt.Sprintf(config.PrimaryRewardsFileUrl, cid, ipfsFilename),
-		fmt.Sprintf(config.SecondaryRewardsFileUrl, cid, ipfsFilename),
+// Downloads a single minipool performance file
+func DownloadMinipoolPerformanceFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
+
+	// Determine file name and path
+	minipoolPerformancePath, err := homedir.Expand(cfg.Smartnode.GetMinipoolPerformancePath(interval, isDaemon))
+	if err != nil {
+		return fmt.Errorf("error expanding minipool performance path: %w", err)
 	}
+	_, err = downloadFile(cfg, minipoolPerformancePath, cid, fmt.Sprintf("interval %d minipool performance file", interval), func(fileBytes []byte) error {
+		var performanceFile MinipoolPerformanceFile
+		err := json.Unmarshal(fileBytes, &performanceFile)
+		if err != nil {
+			return fmt.Errorf("error deserializing file: %w", err)
+		}
+		if performanceFile.Index != interval {
+			return fmt.Errorf("file is for interval %d instead of %d", performanceFile.Index, interval)
+		}
+		return nil
+	})
+	return err
+
+}
+
+// Downloads a compressed file from each configured source in turn, decompresses it, and saves it to the provided path once it passes verification.
+// Returns the files that were rejected by the verification along the way.
+func downloadFile(cfg *config.RocketPoolConfig, path string, cid string, description string, verify func([]byte) error) ([]RewardsFileRejection, error) {
+
+	filename := filepath.Base(path)
+	ipfsFilename := filename + config.RewardsTreeIpfsExtension
 
 	// Attempt downloads
 	errBuilder := strings.Builder{}
-	for _, url := range urls {
-		resp, err := http.Get(url)
+	rejections := []RewardsFileRejection{}
+	for _, downloader := range storage.GetDownloaders(cfg) {
+		compressedBytes, err := downloader.Download(cid, ipfsFilename)
 		if err != nil {
-			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
+			errBuilder.WriteString(fmt.Sprintf("%s\n", err.Error()))
 			continue
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed with status %s\n", url, resp.Status))
+		// Decompress it
+		decompressedBytes, err := decompressFile(compressedBytes)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error decompressing %s from %s: %s\n", ipfsFilename, downloader.GetName(), err.Error()))
 			continue
-		} else {
-			// If we got here, we have a successful download
-			bytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Error reading response bytes from %s: %s\n", url, err.Error()))
-				continue
-			}
+		}
 
-			// Decompress it
-			decompressedBytes, err := decompressFile(bytes)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
-				continue
-			}
+		// Make sure it's the right file before saving it
+		err = verify(decompressedBytes)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Rejected %s from %s: %s\n", ipfsFilename, downloader.GetName(), err.Error()))
+			rejections = append(rejections, RewardsFileRejection{
+				Source: downloader.GetName(),
+				Reason: err.Error(),
+			})
+			continue
+		}
 
-			// Write the file
-			err = os.WriteFile(rewardsTreePath, decompressedBytes, 0644)
-			if err != nil {
-				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
-			}
-			return nil
+		// Write the file
+		err = os.WriteFile(path, decompressedBytes, 0644)
+		if err != nil {
+			return rejections, fmt.Errorf("error saving %s to %s: %w", description, path, err)
 		}
+		return rejections, nil
 	}
 
-	return fmt.Errorf(errBuilder.String())
+	return rejections, fmt.Errorf(errBuilder.String())
 
 }
 
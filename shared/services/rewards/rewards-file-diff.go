@@ -0,0 +1,208 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A single field that differs between the canonical and the generated file
+type FieldDiff struct {
+	Name      string `json:"name"`
+	Canonical string `json:"canonical"`
+	Generated string `json:"generated"`
+}
+
+// The differences in a node's rewards between the canonical and the generated file
+type NodeRewardsDiff struct {
+	Address     common.Address `json:"address"`
+	InCanonical bool           `json:"inCanonical"`
+	InGenerated bool           `json:"inGenerated"`
+	Fields      []FieldDiff    `json:"fields"`
+}
+
+// The differences in a minipool's performance between the canonical and the generated file
+type MinipoolPerformanceDiff struct {
+	Address     common.Address `json:"address"`
+	Pubkey      string         `json:"pubkey"`
+	InCanonical bool           `json:"inCanonical"`
+	InGenerated bool           `json:"inGenerated"`
+	Fields      []FieldDiff    `json:"fields"`
+}
+
+// The result of comparing a canonical rewards file with an independently generated one
+type RewardsFileDiff struct {
+	CanonicalMerkleRoot        common.Hash               `json:"canonicalMerkleRoot"`
+	GeneratedMerkleRoot        common.Hash               `json:"generatedMerkleRoot"`
+	MinipoolPerformanceChecked bool                      `json:"minipoolPerformanceChecked"`
+	NodeDiffs                  []NodeRewardsDiff         `json:"nodeDiffs"`
+	MinipoolDiffs              []MinipoolPerformanceDiff `json:"minipoolDiffs"`
+}
+
+// True if the two files agree on the Merkle root and on every compared field
+func (d *RewardsFileDiff) Matches() bool {
+	return d.CanonicalMerkleRoot == d.GeneratedMerkleRoot && len(d.NodeDiffs) == 0 && len(d.MinipoolDiffs) == 0
+}
+
+// Compares a canonical rewards file (and optionally its minipool performance file) with a generated one
+func CompareRewardsFiles(canonical *RewardsFile, canonicalPerformance *MinipoolPerformanceFile, generated *RewardsFile) *RewardsFileDiff {
+	diff := &RewardsFileDiff{
+		CanonicalMerkleRoot: common.HexToHash(canonical.MerkleRoot),
+		GeneratedMerkleRoot: common.HexToHash(generated.MerkleRoot),
+		NodeDiffs:           []NodeRewardsDiff{},
+		MinipoolDiffs:       []MinipoolPerformanceDiff{},
+	}
+
+	// Compare the node rewards
+	nodeAddresses := map[common.Address]bool{}
+	for address := range canonical.NodeRewards {
+		nodeAddresses[address] = true
+	}
+	for address := range generated.NodeRewards {
+		nodeAddresses[address] = true
+	}
+	for _, address := range getSortedAddresses(nodeAddresses) {
+		canonicalRewards, inCanonical := canonical.NodeRewards[address]
+		generatedRewards, inGenerated := generated.NodeRewards[address]
+		if !inCanonical {
+			canonicalRewards = &NodeRewardsInfo{}
+		}
+		if !inGenerated {
+			generatedRewards = &NodeRewardsInfo{}
+		}
+
+		fields := []FieldDiff{}
+		fields = appendBigIntDiff(fields, "collateralRpl", canonicalRewards.CollateralRpl, generatedRewards.CollateralRpl)
+		fields = appendBigIntDiff(fields, "oracleDaoRpl", canonicalRewards.OracleDaoRpl, generatedRewards.OracleDaoRpl)
+		fields = appendBigIntDiff(fields, "smoothingPoolEth", canonicalRewards.SmoothingPoolEth, generatedRewards.SmoothingPoolEth)
+		if inCanonical != inGenerated || len(fields) > 0 {
+			diff.NodeDiffs = append(diff.NodeDiffs, NodeRewardsDiff{
+				Address:     address,
+				InCanonical: inCanonical,
+				InGenerated: inGenerated,
+				Fields:      fields,
+			})
+		}
+	}
+
+	// Compare the minipool performance if the canonical file is available
+	if canonicalPerformance == nil {
+		return diff
+	}
+	diff.MinipoolPerformanceChecked = true
+	generatedPerformance := generated.MinipoolPerformanceFile.MinipoolPerformance
+	minipoolAddresses := map[common.Address]bool{}
+	for address := range canonicalPerformance.MinipoolPerformance {
+		minipoolAddresses[address] = true
+	}
+	for address := range generatedPerformance {
+		minipoolAddresses[address] = true
+	}
+	for _, address := range getSortedAddresses(minipoolAddresses) {
+		canonicalMinipool, inCanonical := canonicalPerformance.MinipoolPerformance[address]
+		generatedMinipool, inGenerated := generatedPerformance[address]
+		pubkey := ""
+		if inCanonical {
+			pubkey = canonicalMinipool.Pubkey
+		} else {
+			canonicalMinipool = &SmoothingPoolMinipoolPerformance{}
+		}
+		if inGenerated {
+			pubkey = generatedMinipool.Pubkey
+		} else {
+			generatedMinipool = &SmoothingPoolMinipoolPerformance{}
+		}
+
+		fields := []FieldDiff{}
+		fields = appendUintDiff(fields, "successfulAttestations", canonicalMinipool.SuccessfulAttestations, generatedMinipool.SuccessfulAttestations)
+		fields = appendUintDiff(fields, "missedAttestations", canonicalMinipool.MissedAttestations, generatedMinipool.MissedAttestations)
+		if inCanonical != inGenerated || len(fields) > 0 {
+			diff.MinipoolDiffs = append(diff.MinipoolDiffs, MinipoolPerformanceDiff{
+				Address:     address,
+				Pubkey:      pubkey,
+				InCanonical: inCanonical,
+				InGenerated: inGenerated,
+				Fields:      fields,
+			})
+		}
+	}
+
+	return diff
+}
+
+// Deserializes a rewards file from disk
+func LoadRewardsFile(path string) (*RewardsFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards file %s: %w", path, err)
+	}
+	rewardsFile := new(RewardsFile)
+	err = json.Unmarshal(fileBytes, rewardsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing rewards file %s: %w", path, err)
+	}
+	return rewardsFile, nil
+}
+
+// Deserializes a minipool performance file from disk
+func LoadMinipoolPerformanceFile(path string) (*MinipoolPerformanceFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading minipool performance file %s: %w", path, err)
+	}
+	performanceFile := new(MinipoolPerformanceFile)
+	err = json.Unmarshal(fileBytes, performanceFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing minipool performance file %s: %w", path, err)
+	}
+	return performanceFile, nil
+}
+
+// Records a difference between two quoted big ints, treating nil as zero
+func appendBigIntDiff(fields []FieldDiff, name string, canonical *QuotedBigInt, generated *QuotedBigInt) []FieldDiff {
+	canonicalValue := big.NewInt(0)
+	if canonical != nil {
+		canonicalValue = &canonical.Int
+	}
+	generatedValue := big.NewInt(0)
+	if generated != nil {
+		generatedValue = &generated.Int
+	}
+	if canonicalValue.Cmp(generatedValue) != 0 {
+		fields = append(fields, FieldDiff{
+			Name:      name,
+			Canonical: canonicalValue.String(),
+			Generated: generatedValue.String(),
+		})
+	}
+	return fields
+}
+
+// Records a difference between two counters
+func appendUintDiff(fields []FieldDiff, name string, canonical uint64, generated uint64) []FieldDiff {
+	if canonical != generated {
+		fields = append(fields, FieldDiff{
+			Name:      name,
+			Canonical: fmt.Sprint(canonical),
+			Generated: fmt.Sprint(generated),
+		})
+	}
+	return fields
+}
+
+// Sorts a set of addresses so reports are deterministic
+func getSortedAddresses(addressSet map[common.Address]bool) []common.Address {
+	addresses := make([]common.Address, 0, len(addressSet))
+	for address := range addressSet {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	return addresses
+}
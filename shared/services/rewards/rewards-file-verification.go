@@ -0,0 +1,81 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// A downloaded rewards file that was rejected because it didn't match the canonical rewards event
+type RewardsFileRejection struct {
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// Checks that a rewards file is for the expected interval and network, and that the Merkle tree built from its node rewards has the canonical root
+func VerifyRewardsFile(rewardsFile *RewardsFile, interval uint64, network string, merkleRoot common.Hash) error {
+	if rewardsFile.Index != interval {
+		return fmt.Errorf("file is for interval %d instead of %d", rewardsFile.Index, interval)
+	}
+	if rewardsFile.Network != network {
+		return fmt.Errorf("file is for network %s instead of %s", rewardsFile.Network, network)
+	}
+	if common.HexToHash(rewardsFile.MerkleRoot) != merkleRoot {
+		return fmt.Errorf("file claims a Merkle root of %s but the canonical root is %s", rewardsFile.MerkleRoot, merkleRoot.Hex())
+	}
+
+	// Don't trust the root in the file, rebuild it from the rewards themselves
+	rebuiltRoot, err := GetMerkleRootFromNodeRewards(rewardsFile.NodeRewards)
+	if err != nil {
+		return err
+	}
+	if rebuiltRoot != merkleRoot {
+		return fmt.Errorf("file's node rewards produce a Merkle root of %s but the canonical root is %s", rebuiltRoot.Hex(), merkleRoot.Hex())
+	}
+	return nil
+}
+
+// Rebuilds the Merkle tree for a set of node rewards and returns its root
+func GetMerkleRootFromNodeRewards(nodeRewards map[common.Address]*NodeRewardsInfo) (common.Hash, error) {
+
+	// Generate the leaf data for each node
+	zero := big.NewInt(0)
+	totalData := make([][]byte, 0, len(nodeRewards))
+	for address, rewardsForNode := range nodeRewards {
+		if rewardsForNode == nil || rewardsForNode.CollateralRpl == nil || rewardsForNode.OracleDaoRpl == nil || rewardsForNode.SmoothingPoolEth == nil {
+			return common.Hash{}, fmt.Errorf("rewards for node %s are incomplete", address.Hex())
+		}
+
+		// Ignore nodes that didn't receive any rewards
+		if rewardsForNode.CollateralRpl.Cmp(zero) == 0 && rewardsForNode.OracleDaoRpl.Cmp(zero) == 0 && rewardsForNode.SmoothingPoolEth.Cmp(zero) == 0 {
+			continue
+		}
+
+		// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
+		rplRewards := big.NewInt(0)
+		rplRewards.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
+		nodeData := make([]byte, 0, 20+32*3)
+		nodeData = append(nodeData, address.Bytes()...)
+		for _, value := range []*big.Int{big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork), rplRewards, &rewardsForNode.SmoothingPoolEth.Int} {
+			// FillBytes panics on values that don't fit, so check them first since the file isn't trusted
+			if value.Sign() < 0 || value.BitLen() > 256 {
+				return common.Hash{}, fmt.Errorf("rewards for node %s have an out-of-range value (%s)", address.Hex(), value.String())
+			}
+			valueBytes := make([]byte, 32)
+			value.FillBytes(valueBytes)
+			nodeData = append(nodeData, valueBytes...)
+		}
+		totalData = append(totalData, nodeData)
+	}
+
+	// Generate the tree
+	tree, err := merkletree.NewUsing(totalData, keccak256.New(), false, true)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error generating Merkle Tree: %w", err)
+	}
+	return common.BytesToHash(tree.Root()), nil
+
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Placeholders for HTTP mirror URL templates
+const (
+	CidPlaceholder      string = "{cid}"
+	FilenamePlaceholder string = "{filename}"
+)
+
+// Downloads rewards files from a plain HTTP server, such as an IPFS gateway
+type HttpMirror struct {
+	urlTemplate string
+	client      *http.Client
+}
+
+// Create a new HTTP mirror from a URL template. If the template doesn't have any placeholders, the CID and filename are appended as path segments.
+func NewHttpMirror(urlTemplate string) *HttpMirror {
+	if !strings.Contains(urlTemplate, CidPlaceholder) && !strings.Contains(urlTemplate, FilenamePlaceholder) {
+		urlTemplate = strings.TrimSuffix(urlTemplate, "/") + "/" + CidPlaceholder + "/" + FilenamePlaceholder
+	}
+	return &HttpMirror{
+		urlTemplate: urlTemplate,
+		client:      newHttpClient(),
+	}
+}
+
+func (m *HttpMirror) GetName() string {
+	return m.urlTemplate
+}
+
+func (m *HttpMirror) Download(cid string, filename string) ([]byte, error) {
+	url := strings.ReplaceAll(m.urlTemplate, CidPlaceholder, cid)
+	url = strings.ReplaceAll(url, FilenamePlaceholder, filename)
+
+	resp, err := m.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s failed (%w)", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s failed with status %s", url, resp.Status)
+	}
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response bytes from %s: %w", url, err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A single entry in the response from the IPFS add endpoint
+type ipfsAddResponse struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+}
+
+// Uploads and downloads rewards files using the HTTP API of an IPFS node
+type IpfsNode struct {
+	apiUrl string
+	client *http.Client
+}
+
+// Create a new IPFS node client
+func NewIpfsNode(apiUrl string) *IpfsNode {
+	return &IpfsNode{
+		apiUrl: strings.TrimSuffix(apiUrl, "/"),
+		client: newHttpClient(),
+	}
+}
+
+func (n *IpfsNode) GetName() string {
+	return fmt.Sprintf("IPFS node at %s", n.apiUrl)
+}
+
+// Adds and pins the file, wrapped in a directory so it can be retrieved by CID and filename like the other services
+func (n *IpfsNode) Upload(path string) (string, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+	filename := filepath.Base(path)
+
+	// Build the form
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("error creating upload form: %w", err)
+	}
+	_, err = part.Write(fileBytes)
+	if err != nil {
+		return "", fmt.Errorf("error writing upload form: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", fmt.Errorf("error closing upload form: %w", err)
+	}
+
+	// Add it
+	query := url.Values{}
+	query.Set("wrap-with-directory", "true")
+	query.Set("cid-version", "1")
+	query.Set("pin", "true")
+	responseBytes, err := n.post("add", query, body, writer.FormDataContentType())
+	if err != nil {
+		return "", err
+	}
+
+	// The response has one JSON object per added entry; the wrapping directory has an empty name
+	decoder := json.NewDecoder(bytes.NewReader(responseBytes))
+	for decoder.More() {
+		var entry ipfsAddResponse
+		err = decoder.Decode(&entry)
+		if err != nil {
+			return "", fmt.Errorf("error deserializing IPFS add response: %w", err)
+		}
+		if entry.Name == "" {
+			return entry.Hash, nil
+		}
+	}
+	return "", fmt.Errorf("IPFS add response for %s did not include the wrapping directory", filename)
+}
+
+func (n *IpfsNode) Download(cid string, filename string) ([]byte, error) {
+	query := url.Values{}
+	query.Set("arg", fmt.Sprintf("/ipfs/%s/%s", cid, filename))
+	return n.post("cat", query, nil, "")
+}
+
+// Calls an IPFS API endpoint; all of them use POST
+func (n *IpfsNode) post(endpoint string, query url.Values, body io.Reader, contentType string) ([]byte, error) {
+	requestUrl := fmt.Sprintf("%s/api/v0/%s?%s", n.apiUrl, endpoint, query.Encode())
+	request, err := http.NewRequest(http.MethodPost, requestUrl, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating IPFS %s request: %w", endpoint, err)
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := n.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("IPFS %s request to %s failed (%w)", endpoint, n.apiUrl, err)
+	}
+	defer resp.Body.Close()
+	responseBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading IPFS %s response: %w", endpoint, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("IPFS %s request to %s failed with status %s: %s", endpoint, n.apiUrl, resp.Status, string(responseBytes))
+	}
+	return responseBytes, nil
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Mirrors and downloads rewards files using an S3-compatible bucket, stored under <cid>/<filename>
+type S3Bucket struct {
+	endpoint        string
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+// Create a new S3-compatible bucket client. If the access key is blank, requests are sent unsigned for public buckets.
+func NewS3Bucket(endpoint string, region string, bucket string, accessKeyID string, secretAccessKey string) *S3Bucket {
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Bucket{
+		endpoint:        strings.TrimSuffix(endpoint, "/"),
+		region:          region,
+		bucket:          bucket,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          newHttpClient(),
+	}
+}
+
+func (b *S3Bucket) GetName() string {
+	return fmt.Sprintf("S3 bucket %s at %s", b.bucket, b.endpoint)
+}
+
+func (b *S3Bucket) Mirror(cid string, path string) error {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	_, err = b.do(http.MethodPut, cid, filepath.Base(path), fileBytes)
+	return err
+}
+
+func (b *S3Bucket) Download(cid string, filename string) ([]byte, error) {
+	return b.do(http.MethodGet, cid, filename, nil)
+}
+
+// Sends a path-style request for an object in the bucket
+func (b *S3Bucket) do(method string, cid string, filename string, body []byte) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s/%s/%s", b.endpoint, b.bucket, cid, filename)
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating S3 request for %s: %w", url, err)
+	}
+	if b.accessKeyID != "" {
+		b.sign(request, body, time.Now())
+	}
+
+	resp, err := b.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("S3 %s request for %s failed (%w)", method, url, err)
+	}
+	defer resp.Body.Close()
+	responseBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading S3 response for %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("S3 %s request for %s failed with status %s: %s", method, url, resp.Status, string(responseBytes))
+	}
+	return responseBytes, nil
+}
+
+// Signs a request with AWS Signature Version 4
+func (b *S3Bucket) sign(request *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	payloadHash := hashHex(body)
+
+	request.Header.Set("x-amz-date", amzDate)
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+
+	// Build the canonical request
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", request.URL.Host, payloadHash, amzDate)
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	// Sign it
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", dateStamp, b.region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+	signingKey := hmacSha256([]byte("AWS4"+b.secretAccessKey), dateStamp)
+	signingKey = hmacSha256(signingKey, b.region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", b.accessKeyID, scope, signedHeaders, signature))
+}
+
+// Get the hex-encoded SHA256 hash of some data
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// Get the HMAC-SHA256 of some data
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
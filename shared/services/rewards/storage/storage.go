@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	RequestTimeout time.Duration = 5 * time.Minute
+)
+
+// Publishes compressed rewards files and provides the IPFS CID they can be retrieved with
+type Uploader interface {
+	// Get the name of the service, for logging
+	GetName() string
+
+	// Upload the file at the given path, returning the CID of the directory that wraps it
+	Upload(path string) (string, error)
+}
+
+// Stores extra copies of compressed rewards files under a CID that was provided by an Uploader
+type Mirror interface {
+	// Get the name of the service, for logging
+	GetName() string
+
+	// Store a copy of the file at the given path under the given CID
+	Mirror(cid string, path string) error
+}
+
+// Retrieves compressed rewards files by CID and filename
+type Downloader interface {
+	// Get the name of the service, for logging
+	GetName() string
+
+	// Download the file with the given name from the directory with the given CID
+	Download(cid string, filename string) ([]byte, error)
+}
+
+// Get the uploader the Oracle DAO node is configured to publish rewards files with
+func GetUploader(cfg *config.RocketPoolConfig) (Uploader, error) {
+	uploader := cfg.Smartnode.RewardsUploader.Value.(cfgtypes.RewardsUploader)
+	switch uploader {
+	case cfgtypes.RewardsUploader_Web3Storage:
+		return NewWeb3Storage(cfg.Smartnode.Web3StorageApiToken.Value.(string))
+	case cfgtypes.RewardsUploader_Ipfs:
+		apiUrl := cfg.Smartnode.IpfsApiUrl.Value.(string)
+		if apiUrl == "" {
+			return nil, fmt.Errorf("You have selected the IPFS Node rewards tree uploader, but you have not configured an IPFS API URL yet.\nPlease enter it in the Smartnode section of the `service config` TUI (or use `--smartnode-ipfsApiUrl` if you configure your system headlessly).")
+		}
+		return NewIpfsNode(apiUrl), nil
+	default:
+		return nil, fmt.Errorf("unknown rewards tree uploader: %s", string(uploader))
+	}
+}
+
+// Get the mirrors the Oracle DAO node is configured to copy published rewards files to
+func GetMirrors(cfg *config.RocketPoolConfig) []Mirror {
+	mirrors := []Mirror{}
+	s3Bucket := getS3Bucket(cfg)
+	if s3Bucket != nil {
+		mirrors = append(mirrors, s3Bucket)
+	}
+	return mirrors
+}
+
+// Get the downloaders to try, in order, when retrieving a rewards file
+func GetDownloaders(cfg *config.RocketPoolConfig) []Downloader {
+	downloaders := []Downloader{}
+
+	// Prefer a local IPFS node if there is one
+	apiUrl := cfg.Smartnode.IpfsApiUrl.Value.(string)
+	if apiUrl != "" {
+		downloaders = append(downloaders, NewIpfsNode(apiUrl))
+	}
+
+	// Add the user's own mirrors
+	for _, mirror := range strings.Split(cfg.Smartnode.RewardsFileMirrors.Value.(string), ",") {
+		mirror = strings.TrimSpace(mirror)
+		if mirror != "" {
+			downloaders = append(downloaders, NewHttpMirror(mirror))
+		}
+	}
+
+	// Add the bucket if this node mirrors to one
+	s3Bucket := getS3Bucket(cfg)
+	if s3Bucket != nil {
+		downloaders = append(downloaders, s3Bucket)
+	}
+
+	// Fall back to the public gateways
+	downloaders = append(downloaders,
+		NewHttpMirror(fmt.Sprintf(config.PrimaryRewardsFileUrl, CidPlaceholder, FilenamePlaceholder)),
+		NewHttpMirror(fmt.Sprintf(config.SecondaryRewardsFileUrl, CidPlaceholder, FilenamePlaceholder)),
+	)
+	return downloaders
+}
+
+// Get the S3-compatible bucket from the config, or nil if one isn't configured
+func getS3Bucket(cfg *config.RocketPoolConfig) *S3Bucket {
+	endpoint := cfg.Smartnode.S3Endpoint.Value.(string)
+	bucket := cfg.Smartnode.S3Bucket.Value.(string)
+	if endpoint == "" || bucket == "" {
+		return nil
+	}
+	return NewS3Bucket(endpoint, cfg.Smartnode.S3Region.Value.(string), bucket, cfg.Smartnode.S3AccessKeyID.Value.(string), cfg.Smartnode.S3SecretAccessKey.Value.(string))
+}
+
+// Create an HTTP client for talking to storage services
+func newHttpClient() *http.Client {
+	return &http.Client{
+		Timeout: RequestTimeout,
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/web3-storage/go-w3s-client"
+)
+
+// Uploads rewards files to Web3.Storage
+type Web3Storage struct {
+	client w3s.Client
+}
+
+// Create a new Web3.Storage uploader
+func NewWeb3Storage(apiToken string) (*Web3Storage, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("***ERROR***\nYou have not configured your Web3.Storage API token yet, so you cannot submit Merkle rewards trees.\nPlease get an API token from https://web3.storage and enter it in the Smartnode section of the `service config` TUI (or use `--smartnode-web3StorageApiToken` if you configure your system headlessly).")
+	}
+	client, err := w3s.NewClient(w3s.WithToken(apiToken))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating new Web3.Storage client: %w", err)
+	}
+	return &Web3Storage{
+		client: client,
+	}, nil
+}
+
+func (w *Web3Storage) GetName() string {
+	return "Web3.Storage"
+}
+
+func (w *Web3Storage) Upload(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	cid, err := w.client.Put(context.Background(), file)
+	if err != nil {
+		return "", fmt.Errorf("error uploading %s to Web3.Storage: %w", path, err)
+	}
+	return cid.String(), nil
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
@@ -44,8 +46,10 @@ const (
 	PrometheusConfigTemplate string = "prometheus.tmpl"
 	PrometheusFile           string = "prometheus.yml"
 
-	APIContainerSuffix string = "_api"
-	APIBinPath         string = "/go/bin/rocketpool"
+	APIContainerSuffix        string = "_api"
+	NodeContainerSuffix       string = "_node"
+	WatchtowerContainerSuffix string = "_watchtower"
+	APIBinPath                string = "/go/bin/rocketpool"
 
 	templatesDir                  string = "templates"
 	overrideDir                   string = "override"
@@ -625,8 +629,8 @@ func (c *Client) InstallUpdateTracker(verbose bool, version string) error {
 
 }
 
-// Start the Rocket Pool service
-func (c *Client) StartService(composeFiles []string) error {
+// Start the Rocket Pool service, handing the daemons the passphrase for the encrypted wallet password file if one is given
+func (c *Client) StartService(composeFiles []string, passwordUnlockKey string) error {
 
 	/*
 		// Start the API container first
@@ -644,7 +648,35 @@ func (c *Client) StartService(composeFiles []string) error {
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	if err := c.printOutput(cmd); err != nil {
+		return err
+	}
+	if passwordUnlockKey == "" {
+		return nil
+	}
+	return c.unlockPasswordFile(passwordUnlockKey)
+}
+
+// Hand the passphrase for the encrypted wallet password file to each running daemon container.
+// It's written to the container's tmpfs through stdin, so it isn't kept in its environment or on disk and doesn't survive a restart.
+func (c *Client) unlockPasswordFile(passwordUnlockKey string) error {
+	cfg, _, err := c.LoadConfig()
+	if err != nil {
+		return err
+	}
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	for _, suffix := range []string{APIContainerSuffix, NodeContainerSuffix, WatchtowerContainerSuffix} {
+		container := prefix + suffix
+		status, err := c.GetDockerStatus(container)
+		if err != nil || status != "running" {
+			continue
+		}
+		cmd := fmt.Sprintf("docker exec -i %s sh -c %s", shellescape.Quote(container), shellescape.Quote(fmt.Sprintf("umask 077 && cat > %s", passwords.UnlockKeyPath)))
+		if err := c.printOutputWithInput(cmd, passwordUnlockKey); err != nil {
+			return fmt.Errorf("error unlocking the wallet password in %s: %w", container, err)
+		}
+	}
+	return nil
 }
 
 // Pause the Rocket Pool service
@@ -971,6 +1003,21 @@ func (c *Client) RunEcMigrator(container string, volume string, targetDir string
 	return nil
 }
 
+// Runs a validator client's slashing protection database tool in a container using the client's image
+func (c *Client) RunSlashingProtectionTool(container string, image string, validatorsPath string, workDir string, entrypoint string, args []string) error {
+	quotedArgs := make([]string, len(args))
+	for i, arg := range args {
+		quotedArgs[i] = shellescape.Quote(arg)
+	}
+	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:%s -v %s:%s --entrypoint %s %s %s", container, shellescape.Quote(validatorsPath), slashing.ToolValidatorsPath, shellescape.Quote(workDir), slashing.ToolWorkPath, shellescape.Quote(entrypoint), image, strings.Join(quotedArgs, " "))
+	err := c.printOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Gets the size of the target directory via the EC migrator for importing, which should have the same permissions as exporting
 func (c *Client) GetDirSizeViaEcMigrator(container string, targetDir string, image string) (uint64, error) {
 	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/mnt/external -e OPERATION='size' %s", container, targetDir, image)
@@ -1031,8 +1078,12 @@ func (c *Client) PurgeAllKeys(composeFiles []string) error {
 	if err != nil {
 		return fmt.Errorf("error loading password path: %w", err)
 	}
+	encryptedPasswordPath, err := homedir.Expand(cfg.Smartnode.GetEncryptedPasswordPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error loading encrypted password path: %w", err)
+	}
 	fmt.Println("Deleting password...")
-	cmd = fmt.Sprintf("%s rm -f %s", rootCmd, passwordPath)
+	cmd = fmt.Sprintf("%s rm -f %s %s", rootCmd, passwordPath, encryptedPasswordPath)
 	_, err = c.readOutput(cmd)
 	if err != nil {
 		return fmt.Errorf("error deleting password: %w", err)
@@ -1057,7 +1108,7 @@ func (c *Client) PurgeAllKeys(composeFiles []string) error {
 
 	// Start the containers
 	fmt.Println("Starting containers...")
-	err = c.StartService(composeFiles)
+	err = c.StartService(composeFiles, "")
 	if err != nil {
 		return fmt.Errorf("error starting Docker containers: %w", err)
 	}
@@ -1830,6 +1881,30 @@ func (c *Client) printOutput(cmdText string) error {
 
 }
 
+// Run a command with the given input and print its output
+func (c *Client) printOutputWithInput(cmdText string, input string) error {
+
+	// Initialize command
+	cmd, err := c.newCommand(cmdText)
+	if err != nil {
+		return err
+	}
+	defer cmd.Close()
+
+	cmd.SetStdin(strings.NewReader(input))
+	cmd.SetStdout(os.Stdout)
+	cmd.SetStderr(os.Stderr)
+
+	// Start the command
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Wait for the command to exit
+	return cmd.Wait()
+
+}
+
 // Run a command and return its output
 func (c *Client) readOutput(cmdText string) ([]byte, error) {
 
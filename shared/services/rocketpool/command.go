@@ -84,6 +84,14 @@ func (c *command) SetStderr(w io.Writer) {
 	}
 }
 
+func (c *command) SetStdin(r io.Reader) {
+	if c.cmd != nil {
+		c.cmd.Stdin = r
+	} else {
+		c.session.Stdin = r
+	}
+}
+
 // Run the command and return its output
 func (c *command) Output() ([]byte, error) {
 	if c.cmd != nil {
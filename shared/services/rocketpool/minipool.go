@@ -88,6 +88,22 @@ func (c *Client) RefundMinipool(address common.Address) (api.RefundMinipoolRespo
 	return response, nil
 }
 
+// Run the Oracle DAO's scrub checks against the node's prelaunch minipools
+func (c *Client) CheckScrub() (api.MinipoolCheckScrubResponse, error) {
+	responseBytes, err := c.callAPI("minipool check-scrub")
+	if err != nil {
+		return api.MinipoolCheckScrubResponse{}, fmt.Errorf("Could not run scrub checks: %w", err)
+	}
+	var response api.MinipoolCheckScrubResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolCheckScrubResponse{}, fmt.Errorf("Could not decode scrub check response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolCheckScrubResponse{}, fmt.Errorf("Could not run scrub checks: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for staking
 func (c *Client) CanStakeMinipool(address common.Address) (api.CanStakeMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-stake %s", address.Hex()))
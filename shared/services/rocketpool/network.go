@@ -119,6 +119,35 @@ func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTr
 	return response, nil
 }
 
+// Rebuild the network balances at a block, or at every reported block in a range if block is 0
+func (c *Client) NetworkBalancesAt(block uint64, startBlock uint64, endBlock uint64, includeMinipools bool) (api.NetworkBalancesAtResponse, error) {
+	var command string
+	if block != 0 {
+		command = fmt.Sprintf("network balances-at --block %d", block)
+	} else {
+		command = fmt.Sprintf("network balances-at --start-block %d", startBlock)
+		if endBlock != 0 {
+			command += fmt.Sprintf(" --end-block %d", endBlock)
+		}
+	}
+	if includeMinipools {
+		command += " --minipools"
+	}
+
+	responseBytes, err := c.callAPI(command)
+	if err != nil {
+		return api.NetworkBalancesAtResponse{}, fmt.Errorf("Could not get historical network balances: %w", err)
+	}
+	var response api.NetworkBalancesAtResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkBalancesAtResponse{}, fmt.Errorf("Could not decode historical network balances response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkBalancesAtResponse{}, fmt.Errorf("Could not get historical network balances: %s", response.Error)
+	}
+	return response, nil
+}
+
 // GetActiveDAOProposals fetches information about active DAO proposals
 func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("network dao-proposals")
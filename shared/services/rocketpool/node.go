@@ -989,3 +989,19 @@ func (c *Client) GetEthBalance() (api.NodeEthBalanceResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the ledger of rewards paid out to the node between the given blocks (0 uses the defaults)
+func (c *Client) NodeRewardsHistory(startBlock uint64, endBlock uint64) (api.NodeRewardsHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node rewards-history %d %d", startBlock, endBlock))
+	if err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get node rewards history: %w", err)
+	}
+	var response api.NodeRewardsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not decode node rewards history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get node rewards history: %s", response.Error)
+	}
+	return response, nil
+}
@@ -48,6 +48,33 @@ func (c *Client) TNDAOMembers() (api.TNDAOMembersResponse, error) {
 	return response, nil
 }
 
+// Get the transactions the watchtower has made
+func (c *Client) TNDAOAuditLog(task string, minBlock uint64, limit int) (api.TNDAOAuditLogResponse, error) {
+	command := "odao audit-log"
+	if task != "" {
+		command += fmt.Sprintf(" --task %s", task)
+	}
+	if minBlock != 0 {
+		command += fmt.Sprintf(" --min-block %d", minBlock)
+	}
+	if limit != 0 {
+		command += fmt.Sprintf(" --limit %d", limit)
+	}
+
+	responseBytes, err := c.callAPI(command)
+	if err != nil {
+		return api.TNDAOAuditLogResponse{}, fmt.Errorf("Could not get watchtower audit log: %w", err)
+	}
+	var response api.TNDAOAuditLogResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOAuditLogResponse{}, fmt.Errorf("Could not decode watchtower audit log response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOAuditLogResponse{}, fmt.Errorf("Could not get watchtower audit log: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get oracle DAO proposals
 func (c *Client) TNDAOProposals() (api.TNDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("odao proposals")
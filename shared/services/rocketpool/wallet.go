@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -164,6 +165,26 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Move validator keys held back for their slashing protection data into the Validator client's keystores, or every held key if none are given
+func (c *Client) ReleaseHeldKeys(pubkeys []types.ValidatorPubkey) (api.ReleaseHeldKeysResponse, error) {
+	args := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		args[i] = pubkey.Hex()
+	}
+	responseBytes, err := c.callAPI("wallet release-held-keys", args...)
+	if err != nil {
+		return api.ReleaseHeldKeysResponse{}, fmt.Errorf("Could not release held validator keys: %w", err)
+	}
+	var response api.ReleaseHeldKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReleaseHeldKeysResponse{}, fmt.Errorf("Could not decode release held validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReleaseHeldKeysResponse{}, fmt.Errorf("Could not release held validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
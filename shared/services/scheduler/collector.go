@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "rocketpool"
+
+// The latest run information for a task
+type taskMetrics struct {
+	lastRunTime  float64
+	lastDuration float64
+	lastSuccess  float64
+	runs         float64
+	failures     float64
+	timeouts     float64
+}
+
+// Represents the collector for the task scheduler metrics
+type TaskCollector struct {
+
+	// The time the task's latest run finished
+	lastRunTimeDesc *prometheus.Desc
+
+	// How long the task's latest run took, in seconds
+	lastDurationDesc *prometheus.Desc
+
+	// Whether the task's latest run succeeded
+	lastSuccessDesc *prometheus.Desc
+
+	// The number of times the task has run
+	runsDesc *prometheus.Desc
+
+	// The number of times the task has failed
+	failuresDesc *prometheus.Desc
+
+	// The number of times the task has run past its timeout
+	timeoutsDesc *prometheus.Desc
+
+	// The metrics for each task
+	tasks map[string]*taskMetrics
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new TaskCollector instance
+func NewTaskCollector() *TaskCollector {
+	subsystem := "task"
+	labels := []string{"task"}
+	return &TaskCollector{
+		lastRunTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_run_time"),
+			"The time the task's latest run finished",
+			labels, nil,
+		),
+		lastDurationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_duration_seconds"),
+			"How long the task's latest run took, in seconds",
+			labels, nil,
+		),
+		lastSuccessDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_success"),
+			"Whether the task's latest run succeeded (1) or failed (0)",
+			labels, nil,
+		),
+		runsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "runs_total"),
+			"The number of times the task has run",
+			labels, nil,
+		),
+		failuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of times the task has failed",
+			labels, nil,
+		),
+		timeoutsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "timeouts_total"),
+			"The number of times the task has run past its timeout",
+			labels, nil,
+		),
+		tasks: map[string]*taskMetrics{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *TaskCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.lastRunTimeDesc
+	channel <- collector.lastDurationDesc
+	channel <- collector.lastSuccessDesc
+	channel <- collector.runsDesc
+	channel <- collector.failuresDesc
+	channel <- collector.timeoutsDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TaskCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Update all of the metrics
+	for name, metrics := range collector.tasks {
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastRunTimeDesc, prometheus.GaugeValue, metrics.lastRunTime, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastDurationDesc, prometheus.GaugeValue, metrics.lastDuration, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastSuccessDesc, prometheus.GaugeValue, metrics.lastSuccess, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.runsDesc, prometheus.CounterValue, metrics.runs, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failuresDesc, prometheus.CounterValue, metrics.failures, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.timeoutsDesc, prometheus.CounterValue, metrics.timeouts, name)
+	}
+
+}
+
+// Start tracking a task
+func (collector *TaskCollector) addTask(name string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.tasks[name] = &taskMetrics{}
+}
+
+// Record the result of a task run
+func (collector *TaskCollector) recordRun(name string, start time.Time, duration time.Duration, err error) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	metrics := collector.tasks[name]
+	metrics.lastRunTime = float64(start.Add(duration).Unix())
+	metrics.lastDuration = duration.Seconds()
+	metrics.runs++
+	if err == nil {
+		metrics.lastSuccess = 1
+	} else {
+		metrics.lastSuccess = 0
+		metrics.failures++
+	}
+}
+
+// Record a task running past its timeout
+func (collector *TaskCollector) recordTimeout(name string) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.tasks[name].timeouts++
+}
@@ -0,0 +1,382 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	tickInterval  time.Duration = 30 * time.Second
+	retryCooldown time.Duration = 10 * time.Second
+)
+
+// How often a task should run
+type Cadence int
+
+const (
+	// Run whenever the task's interval has elapsed since its last run started
+	Cadence_Interval Cadence = iota
+
+	// Run once per epoch
+	Cadence_Epoch
+
+	// Run once per finalized checkpoint
+	Cadence_Finalized
+)
+
+// A task the scheduler can run
+type Task struct {
+	// The name of the task, used in logs and metrics
+	Name string
+
+	// How often the task runs
+	Cadence Cadence
+
+	// The time between runs, for tasks with Cadence_Interval
+	Interval time.Duration
+
+	// Tasks that must finish successfully before this one runs when they're due at the same time
+	DependsOn []string
+
+	// How long to wait for the task before reporting it as timed out.
+	// Tasks can't be interrupted, so one that times out won't be started again until its previous run returns,
+	// but it gives up its turn with the other tasks that send transactions.
+	Timeout time.Duration
+
+	// True if the task needs an up-to-date network state
+	NeedsState bool
+
+	// True if the task sends transactions from the node wallet; these run one at a time so they don't race over the nonce
+	SendsTransactions bool
+
+	// Optional check for whether the task should run at all this cycle, based on the daemon's cycle data
+	Enabled func(data interface{}) bool
+
+	// The task itself
+	Run func(cycle *Cycle) error
+}
+
+// The context a task is run in
+type Cycle struct {
+	// The Beacon chain head when the cycle started
+	Head beacon.BeaconHead
+
+	// The daemon-specific data from its prepare function
+	Data interface{}
+
+	// The network state, for tasks that need it
+	State *state.NetworkState
+
+	// The lock shared by the tasks that send transactions
+	txLock *sync.Mutex
+}
+
+// Run a function that sends transactions while holding the transaction lock.
+// This is for long-running tasks that only send transactions occasionally, so they don't hold up the others for their whole run.
+func (c *Cycle) RunTransaction(function func() error) error {
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+	return function()
+}
+
+// Daemon hook that checks its clients and gathers any data its tasks need for the cycle
+type PrepareFunc func() (interface{}, error)
+
+// Daemon hook that gets the network state for a cycle
+type StateFunc func(data interface{}) (*state.NetworkState, error)
+
+// The bookkeeping for a registered task
+type taskEntry struct {
+	task               Task
+	isRunning          bool
+	hasRun             bool
+	lastStart          time.Time
+	lastEpoch          uint64
+	lastFinalizedEpoch uint64
+}
+
+// The outcome of a task in a cycle, for the tasks that depend on it
+type taskResult struct {
+	done      chan struct{}
+	once      sync.Once
+	succeeded bool
+}
+
+// Record the outcome of the task and release its dependents
+func (r *taskResult) finish(succeeded bool) {
+	r.once.Do(func() {
+		r.succeeded = succeeded
+		close(r.done)
+	})
+}
+
+// Runs a daemon's tasks on their own cadences, concurrently where it's safe to
+type Scheduler struct {
+	bc        beacon.Client
+	trigger   *services.BeaconEventTrigger
+	prepare   PrepareFunc
+	getState  StateFunc
+	logger    *log.ColorLogger
+	errLog    *log.ColorLogger
+	collector *TaskCollector
+
+	entries []*taskEntry
+	lock    sync.Mutex
+	txLock  sync.Mutex
+}
+
+// Create a new scheduler
+func NewScheduler(bc beacon.Client, trigger *services.BeaconEventTrigger, prepare PrepareFunc, getState StateFunc, logger *log.ColorLogger, errLog *log.ColorLogger) *Scheduler {
+	return &Scheduler{
+		bc:        bc,
+		trigger:   trigger,
+		prepare:   prepare,
+		getState:  getState,
+		logger:    logger,
+		errLog:    errLog,
+		collector: NewTaskCollector(),
+	}
+}
+
+// Add a task to the scheduler. Tasks must be added before the scheduler is started.
+func (s *Scheduler) AddTask(task Task) error {
+	if task.Name == "" || task.Run == nil {
+		return fmt.Errorf("tasks must have a name and a run function")
+	}
+	if task.Cadence == Cadence_Interval && task.Interval <= 0 {
+		return fmt.Errorf("task %s runs on an interval but doesn't have one", task.Name)
+	}
+	for _, entry := range s.entries {
+		if entry.task.Name == task.Name {
+			return fmt.Errorf("a task named %s already exists", task.Name)
+		}
+	}
+	for _, dependency := range task.DependsOn {
+		if s.getEntry(dependency) == nil {
+			return fmt.Errorf("task %s depends on %s, which must be added first", task.Name, dependency)
+		}
+	}
+	s.entries = append(s.entries, &taskEntry{task: task})
+	s.collector.addTask(task.Name)
+	return nil
+}
+
+// Get the collector that exports the task metrics
+func (s *Scheduler) GetCollector() *TaskCollector {
+	return s.collector
+}
+
+// Run the scheduler forever
+func (s *Scheduler) Run() {
+	for {
+		err := s.runCycle()
+		if err != nil {
+			s.errLog.Println(err)
+			time.Sleep(retryCooldown)
+			continue
+		}
+
+		// Wait for the next tick, or wake early if the chain finalizes or reorgs
+		s.trigger.Wait(tickInterval)
+	}
+}
+
+// Start every task that's due
+func (s *Scheduler) runCycle() error {
+
+	// Get the chain head, which decides whether the epoch-based tasks are due
+	head, err := s.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+
+	// Get the tasks that are due
+	due := s.getDueTasks(head)
+	if len(due) == 0 {
+		return nil
+	}
+
+	// Let the daemon check its clients and get its data for the cycle
+	data, err := s.prepare()
+	if err != nil {
+		return err
+	}
+	enabled := []*taskEntry{}
+	needsState := false
+	s.lock.Lock()
+	for _, entry := range due {
+		// Disabled tasks are skipped until they're next due, so they don't force a prepare on every tick
+		s.markStarted(entry, head)
+		if entry.task.Enabled != nil && !entry.task.Enabled(data) {
+			continue
+		}
+		entry.isRunning = true
+		enabled = append(enabled, entry)
+		needsState = needsState || entry.task.NeedsState
+	}
+	s.lock.Unlock()
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	// Get the network state once for every task that needs it
+	cycle := &Cycle{
+		Head:   head,
+		Data:   data,
+		txLock: &s.txLock,
+	}
+	if needsState {
+		cycle.State, err = s.getState(data)
+		if err != nil {
+			// Let the tasks try again on the next tick
+			s.lock.Lock()
+			for _, entry := range enabled {
+				entry.isRunning = false
+				entry.hasRun = false
+			}
+			s.lock.Unlock()
+			return err
+		}
+	}
+
+	// Start the tasks; each one waits for its dependencies that are also running this cycle
+	results := map[string]*taskResult{}
+	for _, entry := range enabled {
+		results[entry.task.Name] = &taskResult{
+			done: make(chan struct{}),
+		}
+	}
+	for _, entry := range enabled {
+		go s.runTask(entry, cycle, results)
+	}
+
+	return nil
+}
+
+// Get the tasks whose cadence says they should run now and that aren't still running
+func (s *Scheduler) getDueTasks(head beacon.BeaconHead) []*taskEntry {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	due := []*taskEntry{}
+	for _, entry := range s.entries {
+		if entry.isRunning {
+			continue
+		}
+		if !entry.hasRun {
+			due = append(due, entry)
+			continue
+		}
+		switch entry.task.Cadence {
+		case Cadence_Interval:
+			if time.Since(entry.lastStart) >= entry.task.Interval {
+				due = append(due, entry)
+			}
+		case Cadence_Epoch:
+			if head.Epoch > entry.lastEpoch {
+				due = append(due, entry)
+			}
+		case Cadence_Finalized:
+			if head.FinalizedEpoch > entry.lastFinalizedEpoch {
+				due = append(due, entry)
+			}
+		}
+	}
+	return due
+}
+
+// Run a single task once its dependencies are done, reporting whether it succeeded to the tasks that depend on it
+func (s *Scheduler) runTask(entry *taskEntry, cycle *Cycle, results map[string]*taskResult) {
+	ownResult := results[entry.task.Name]
+	defer s.finishTask(entry)
+
+	// Wait for the dependencies that are running in this cycle
+	for _, dependency := range entry.task.DependsOn {
+		dependencyResult, exists := results[dependency]
+		if !exists {
+			continue
+		}
+		<-dependencyResult.done
+		if !dependencyResult.succeeded {
+			s.logger.Printlnf("Skipping %s because %s didn't finish successfully.", entry.task.Name, dependency)
+			ownResult.finish(false)
+			return
+		}
+	}
+
+	// Tasks that send transactions take turns
+	releaseTxLock := func() {}
+	if entry.task.SendsTransactions {
+		s.txLock.Lock()
+		var releaseOnce sync.Once
+		releaseTxLock = func() {
+			releaseOnce.Do(s.txLock.Unlock)
+		}
+		defer releaseTxLock()
+	}
+
+	// Run the task
+	start := time.Now()
+	errChannel := make(chan error, 1)
+	go func() {
+		errChannel <- entry.task.Run(cycle)
+	}()
+
+	// If it takes too long, release its dependents and report it, but keep it marked as running until it returns
+	var err error
+	if entry.task.Timeout > 0 {
+		timer := time.NewTimer(entry.task.Timeout)
+		select {
+		case err = <-errChannel:
+			timer.Stop()
+		case <-timer.C:
+			s.errLog.Printlnf("Task %s has been running for more than %s; it won't run again until it finishes.", entry.task.Name, entry.task.Timeout)
+			s.collector.recordTimeout(entry.task.Name)
+			ownResult.finish(false)
+
+			// Let the other transaction tasks run in the meantime; the transaction manager still keeps their nonces apart if it sends one later
+			releaseTxLock()
+			err = <-errChannel
+		}
+	} else {
+		err = <-errChannel
+	}
+
+	if err != nil {
+		s.errLog.Println(err)
+	}
+	s.collector.recordRun(entry.task.Name, start, time.Since(start), err)
+	ownResult.finish(err == nil)
+}
+
+// Record the start of a task's cadence period; the lock must be held
+func (s *Scheduler) markStarted(entry *taskEntry, head beacon.BeaconHead) {
+	entry.hasRun = true
+	entry.lastStart = time.Now()
+	entry.lastEpoch = head.Epoch
+	entry.lastFinalizedEpoch = head.FinalizedEpoch
+}
+
+// Mark a task as no longer running
+func (s *Scheduler) finishTask(entry *taskEntry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	entry.isRunning = false
+}
+
+// Get a registered task by name
+func (s *Scheduler) getEntry(name string) *taskEntry {
+	for _, entry := range s.entries {
+		if entry.task.Name == name {
+			return entry
+		}
+	}
+	return nil
+}
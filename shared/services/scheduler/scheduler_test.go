@@ -0,0 +1,363 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const testWaitTimeout = 5 * time.Second
+
+// A Beacon client that only reports a head the test controls
+type fakeBeaconClient struct {
+	beacon.Client
+	lock sync.Mutex
+	head beacon.BeaconHead
+}
+
+func (c *fakeBeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.head, nil
+}
+
+func (c *fakeBeaconClient) setHead(epoch uint64, finalizedEpoch uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.head = beacon.BeaconHead{Epoch: epoch, FinalizedEpoch: finalizedEpoch}
+}
+
+// The trigger's subscription never delivers anything, so the scheduler only runs when the test calls it
+func (c *fakeBeaconClient) SubscribeEvents(topics []beacon.EventTopic, ch chan<- beacon.Event) (ethereum.Subscription, error) {
+	return &fakeSubscription{err: make(chan error)}, nil
+}
+
+type fakeSubscription struct {
+	err chan error
+}
+
+func (s *fakeSubscription) Err() <-chan error { return s.err }
+func (s *fakeSubscription) Unsubscribe()      {}
+
+// Create a scheduler with a fake Beacon client and a state function that can be made to fail
+func newTestScheduler(t *testing.T, stateErr *error) (*Scheduler, *fakeBeaconClient) {
+	bc := &fakeBeaconClient{}
+	logger := log.NewColorLogger(color.FgWhite)
+	trigger := services.NewBeaconEventTrigger(bc, &logger)
+	prepare := func() (interface{}, error) {
+		return nil, nil
+	}
+	getState := func(data interface{}) (*state.NetworkState, error) {
+		if stateErr != nil && *stateErr != nil {
+			return nil, *stateErr
+		}
+		return &state.NetworkState{}, nil
+	}
+	return NewScheduler(bc, trigger, prepare, getState, &logger, &logger), bc
+}
+
+// Wait until none of the scheduler's tasks are running
+func waitForIdle(t *testing.T, s *Scheduler) {
+	t.Helper()
+	deadline := time.Now().Add(testWaitTimeout)
+	for time.Now().Before(deadline) {
+		s.lock.Lock()
+		running := false
+		for _, entry := range s.entries {
+			running = running || entry.isRunning
+		}
+		s.lock.Unlock()
+		if !running {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("tasks didn't finish in time")
+}
+
+// Wait for a signal from a task
+func waitFor(t *testing.T, channel chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-channel:
+	case <-time.After(testWaitTimeout):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func getMetrics(s *Scheduler, name string) taskMetrics {
+	s.collector.lock.Lock()
+	defer s.collector.lock.Unlock()
+	return *s.collector.tasks[name]
+}
+
+func TestGetDueTasks(t *testing.T) {
+	head := beacon.BeaconHead{Epoch: 10, FinalizedEpoch: 8}
+	tests := []struct {
+		name     string
+		entry    taskEntry
+		expected bool
+	}{
+		{
+			name:     "never run",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Epoch}},
+			expected: true,
+		},
+		{
+			name:     "still running",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Epoch}, isRunning: true},
+			expected: false,
+		},
+		{
+			name:     "interval elapsed",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Interval, Interval: time.Minute}, hasRun: true, lastStart: time.Now().Add(-2 * time.Minute)},
+			expected: true,
+		},
+		{
+			name:     "interval not elapsed",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Interval, Interval: time.Minute}, hasRun: true, lastStart: time.Now()},
+			expected: false,
+		},
+		{
+			name:     "new epoch",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Epoch}, hasRun: true, lastEpoch: 9},
+			expected: true,
+		},
+		{
+			name:     "same epoch",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Epoch}, hasRun: true, lastEpoch: 10},
+			expected: false,
+		},
+		{
+			name:     "new finalized epoch",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Finalized}, hasRun: true, lastEpoch: 10, lastFinalizedEpoch: 7},
+			expected: true,
+		},
+		{
+			name:     "new epoch but same finalized epoch",
+			entry:    taskEntry{task: Task{Cadence: Cadence_Finalized}, hasRun: true, lastEpoch: 9, lastFinalizedEpoch: 8},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry := test.entry
+			s := &Scheduler{entries: []*taskEntry{&entry}}
+			due := s.getDueTasks(head)
+			if (len(due) == 1) != test.expected {
+				t.Errorf("expected due = %t, got %d due tasks", test.expected, len(due))
+			}
+		})
+	}
+}
+
+func TestDependencySkipping(t *testing.T) {
+	tests := []struct {
+		name            string
+		dependencyErr   error
+		dependentShould bool
+	}{
+		{name: "dependency succeeded", dependencyErr: nil, dependentShould: true},
+		{name: "dependency failed", dependencyErr: errors.New("failed"), dependentShould: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, bc := newTestScheduler(t, nil)
+			bc.setHead(1, 0)
+			var dependentRan atomic.Bool
+			if err := s.AddTask(Task{
+				Name:    "first",
+				Cadence: Cadence_Epoch,
+				Run: func(cycle *Cycle) error {
+					// Give the dependent a chance to run early if it doesn't wait
+					time.Sleep(20 * time.Millisecond)
+					return test.dependencyErr
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.AddTask(Task{
+				Name:      "second",
+				Cadence:   Cadence_Epoch,
+				DependsOn: []string{"first"},
+				Run: func(cycle *Cycle) error {
+					dependentRan.Store(true)
+					return nil
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.runCycle(); err != nil {
+				t.Fatal(err)
+			}
+			waitForIdle(t, s)
+			if dependentRan.Load() != test.dependentShould {
+				t.Errorf("expected the dependent to run = %t", test.dependentShould)
+			}
+			if failures := getMetrics(s, "first").failures; (failures == 1) != (test.dependencyErr != nil) {
+				t.Errorf("unexpected failure count %f", failures)
+			}
+		})
+	}
+}
+
+func TestTxLockReleasedOnTimeout(t *testing.T) {
+	s, bc := newTestScheduler(t, nil)
+	bc.setHead(1, 0)
+
+	slowStarted := make(chan struct{})
+	releaseSlow := make(chan struct{})
+	fastRan := make(chan struct{})
+	if err := s.AddTask(Task{
+		Name:              "slow",
+		Cadence:           Cadence_Epoch,
+		Timeout:           50 * time.Millisecond,
+		SendsTransactions: true,
+		Run: func(cycle *Cycle) error {
+			close(slowStarted)
+			<-releaseSlow
+			return nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTask(Task{
+		Name:              "fast",
+		Cadence:           Cadence_Epoch,
+		SendsTransactions: true,
+		Run: func(cycle *Cycle) error {
+			if timeouts := getMetrics(s, "slow").timeouts; timeouts != 1 {
+				t.Errorf("fast task got the lock before the slow one timed out")
+			}
+			close(fastRan)
+			return nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only start the slow task in the first cycle
+	fast := s.getEntry("fast")
+	s.lock.Lock()
+	s.markStarted(fast, beacon.BeaconHead{Epoch: 1})
+	s.lock.Unlock()
+	if err := s.runCycle(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, slowStarted, "the slow task")
+
+	// The fast task is due on the next epoch, and gets the lock once the slow one times out even though it's still running
+	bc.setHead(2, 0)
+	if err := s.runCycle(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, fastRan, "the fast task")
+	close(releaseSlow)
+	waitForIdle(t, s)
+	if runs := getMetrics(s, "slow").runs; runs != 1 {
+		t.Errorf("expected the slow task's run to be recorded once it returned, got %f runs", runs)
+	}
+}
+
+func TestRunningTaskNotRestarted(t *testing.T) {
+	s, bc := newTestScheduler(t, nil)
+	bc.setHead(1, 0)
+
+	var starts atomic.Int32
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	if err := s.AddTask(Task{
+		Name:    "slow",
+		Cadence: Cadence_Epoch,
+		Run: func(cycle *Cycle) error {
+			starts.Add(1)
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.runCycle(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, started, "the first run")
+
+	// It's due again on the next epoch, but it's still running
+	bc.setHead(2, 0)
+	if err := s.runCycle(); err != nil {
+		t.Fatal(err)
+	}
+	if count := starts.Load(); count != 1 {
+		t.Fatalf("expected 1 start while the task was running, got %d", count)
+	}
+
+	// Once it's done, it runs again
+	close(release)
+	waitForIdle(t, s)
+	if err := s.runCycle(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, started, "the second run")
+	waitForIdle(t, s)
+	if count := starts.Load(); count != 2 {
+		t.Errorf("expected 2 starts, got %d", count)
+	}
+}
+
+func TestStateFailureRetriesTasks(t *testing.T) {
+	stateErr := errors.New("state unavailable")
+	s, bc := newTestScheduler(t, &stateErr)
+	bc.setHead(1, 0)
+
+	var runs atomic.Int32
+	if err := s.AddTask(Task{
+		Name:       "needs-state",
+		Cadence:    Cadence_Epoch,
+		NeedsState: true,
+		Run: func(cycle *Cycle) error {
+			if cycle.State == nil {
+				t.Error("task ran without a state")
+			}
+			runs.Add(1)
+			return nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.runCycle(); !errors.Is(err, stateErr) {
+		t.Fatalf("expected the state error, got %v", err)
+	}
+	entry := s.getEntry("needs-state")
+	s.lock.Lock()
+	hasRun, isRunning := entry.hasRun, entry.isRunning
+	s.lock.Unlock()
+	if hasRun || isRunning {
+		t.Fatalf("expected the task to be reset after the state failed, got hasRun = %t, isRunning = %t", hasRun, isRunning)
+	}
+
+	// It's retried on the next tick in the same epoch
+	stateErr = nil
+	if err := s.runCycle(); err != nil {
+		t.Fatal(err)
+	}
+	waitForIdle(t, s)
+	if count := runs.Load(); count != 1 {
+		t.Errorf("expected 1 run, got %d", count)
+	}
+}
@@ -0,0 +1,534 @@
+package scrub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	prdeposit "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const BlockStartOffset = 100000
+const ScrubSafetyDivider = 2
+const MinScrubSafetyTime = time.Duration(0) * time.Hour
+
+// The check that decided a minipool's verdict
+type Check string
+
+const (
+	Check_Beacon   Check = "beacon"
+	Check_Prestake Check = "prestake"
+	Check_Deposit  Check = "deposit"
+	Check_Safety   Check = "safety"
+)
+
+// Whether a minipool will be scrubbed
+type Verdict string
+
+const (
+	// The minipool passed the checks and won't be scrubbed
+	Verdict_Pass Verdict = "pass"
+
+	// The minipool failed a check and will be scrubbed by the Oracle DAO
+	Verdict_Scrub Verdict = "scrub"
+
+	// No valid deposit has been found yet; the minipool will be scrubbed once the safety period passes if it stays that way
+	Verdict_Pending Verdict = "pending"
+)
+
+// The evidence a scrub verdict is based on
+type Evidence struct {
+	Check                         Check  `json:"check"`
+	Reason                        string `json:"reason"`
+	ExpectedWithdrawalCredentials string `json:"expectedWithdrawalCredentials,omitempty"`
+	ActualWithdrawalCredentials   string `json:"actualWithdrawalCredentials,omitempty"`
+	DepositTxHash                 string `json:"depositTxHash,omitempty"`
+	DepositBlock                  uint64 `json:"depositBlock,omitempty"`
+	DepositIndex                  int    `json:"depositIndex,omitempty"`
+	PrelaunchTime                 int64  `json:"prelaunchTime,omitempty"`
+	SafetyPeriod                  string `json:"safetyPeriod,omitempty"`
+}
+
+// The result of running the scrub checks on a minipool
+type Result struct {
+	Minipool        common.Address        `json:"minipool"`
+	Pubkey          types.ValidatorPubkey `json:"pubkey"`
+	Verdict         Verdict               `json:"verdict"`
+	Evidence        Evidence              `json:"evidence"`
+	PrelaunchTime   time.Time             `json:"prelaunchTime"`
+	ScrubPeriodEnd  time.Time             `json:"scrubPeriodEnd"`
+	SafetyScrubTime time.Time             `json:"safetyScrubTime"`
+
+	// The minipool binding, for voting on the result
+	Binding minipool.Minipool `json:"-"`
+}
+
+// How many minipools passed and failed each check
+type Tally struct {
+	TotalMinipools        int `json:"totalMinipools"`
+	GoodOnBeaconCount     int `json:"goodOnBeaconCount"`
+	BadOnBeaconCount      int `json:"badOnBeaconCount"`
+	GoodPrestakeCount     int `json:"goodPrestakeCount"`
+	BadPrestakeCount      int `json:"badPrestakeCount"`
+	GoodOnDepositContract int `json:"goodOnDepositContract"`
+	BadOnDepositContract  int `json:"badOnDepositContract"`
+	UnknownMinipools      int `json:"unknownMinipools"`
+	SafetyScrubs          int `json:"safetyScrubs"`
+	UncoveredMinipools    int `json:"uncoveredMinipools"`
+}
+
+// The results of a scrub check
+type Report struct {
+	BeaconSlot     uint64        `json:"beaconSlot"`
+	ElBlock        uint64        `json:"elBlock"`
+	StateBlockTime time.Time     `json:"stateBlockTime"`
+	ScrubPeriod    time.Duration `json:"scrubPeriod"`
+	SafetyPeriod   time.Duration `json:"safetyPeriod"`
+	Results        []Result      `json:"results"`
+	Tally          Tally         `json:"tally"`
+}
+
+// Get the results for the minipools with the given verdict
+func (r *Report) GetResults(verdict Verdict) []Result {
+	results := []Result{}
+	for _, result := range r.Results {
+		if result.Verdict == verdict {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Details for a minipool that's still under review
+type minipoolDetails struct {
+	mpd                           *rpstate.NativeMinipoolDetails
+	pubkey                        types.ValidatorPubkey
+	expectedWithdrawalCredentials common.Hash
+}
+
+// Runs the scrub checks for a set of minipools
+type checker struct {
+	rp     *rocketpool.RocketPool
+	cfg    *config.RocketPoolConfig
+	state  *state.NetworkState
+	log    *log.ColorLogger
+	report *Report
+
+	// Minipools that haven't been decided yet
+	minipools map[minipool.Minipool]*minipoolDetails
+
+	// ETH1 search artifacts
+	startBlock       *big.Int
+	eventLogInterval *big.Int
+	depositDomain    []byte
+}
+
+// Run the checks the Oracle DAO uses to scrub minipools against the provided prelaunch minipools.
+// If a check fails, the returned report still holds the results of the checks that ran before it.
+// The logger is optional; pass nil to run the checks silently.
+func CheckMinipools(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, networkState *state.NetworkState, minipools []*rpstate.NativeMinipoolDetails, logger *log.ColorLogger) (*Report, error) {
+
+	// Get the scrub period and the safety period where minipools can be scrubbed without a valid deposit
+	scrubPeriod := networkState.NetworkDetails.ScrubPeriod
+	safetyPeriod := scrubPeriod / ScrubSafetyDivider
+	if safetyPeriod < MinScrubSafetyTime {
+		safetyPeriod = MinScrubSafetyTime
+	}
+
+	// Get the time of the state's EL block
+	genesisTime := time.Unix(int64(networkState.BeaconConfig.GenesisTime), 0)
+	secondsSinceGenesis := time.Duration(networkState.BeaconSlotNumber*networkState.BeaconConfig.SecondsPerSlot) * time.Second
+
+	c := &checker{
+		rp:    rp,
+		cfg:   cfg,
+		state: networkState,
+		log:   logger,
+		report: &Report{
+			BeaconSlot:     networkState.BeaconSlotNumber,
+			ElBlock:        networkState.ElBlockNumber,
+			StateBlockTime: genesisTime.Add(secondsSinceGenesis),
+			ScrubPeriod:    scrubPeriod,
+			SafetyPeriod:   safetyPeriod,
+			Results:        []Result{},
+		},
+		minipools: map[minipool.Minipool]*minipoolDetails{},
+	}
+	c.report.Tally.TotalMinipools = len(minipools)
+	defer c.finish()
+
+	// Get the correct withdrawal credentials and validator pubkeys for each minipool
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(networkState.ElBlockNumber),
+	}
+	c.initializeMinipoolDetails(minipools, opts)
+
+	// Step 1: Verify the Beacon credentials if they exist
+	c.verifyBeaconWithdrawalCredentials()
+	if len(c.minipools) == 0 {
+		return c.report, nil
+	}
+
+	// Get various elements needed to do eth1 prestake and deposit contract searches
+	err := c.getEth1SearchArtifacts()
+	if err != nil {
+		return c.report, err
+	}
+
+	// Step 2: Verify the MinipoolPrestaked events
+	c.verifyPrestakeEvents()
+	if len(c.minipools) == 0 {
+		return c.report, nil
+	}
+
+	// Step 3: Verify the deposit data of the remaining minipools
+	err = c.verifyDeposits()
+	if err != nil {
+		return c.report, err
+	}
+	if len(c.minipools) == 0 {
+		return c.report, nil
+	}
+
+	// Step 4: Scrub all of the undeposited minipools after half the scrub period for safety
+	c.checkSafetyScrub()
+	return c.report, nil
+
+}
+
+// Get the correct withdrawal credentials and pubkeys for each minipool
+func (c *checker) initializeMinipoolDetails(minipools []*rpstate.NativeMinipoolDetails, opts *bind.CallOpts) {
+	for _, mpd := range minipools {
+		// Ignore vacant minipools - they have the wrong withdrawal creds (temporarily) by design
+		if mpd.IsVacant {
+			continue
+		}
+
+		// Create a minipool contract wrapper for the given address
+		mp, err := minipool.NewMinipoolFromVersion(c.rp, mpd.MinipoolAddress, mpd.Version, opts)
+		if err != nil {
+			c.printlnf("Error creating minipool wrapper for %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
+			continue
+		}
+
+		// Create a new details entry for this minipool
+		c.minipools[mp] = &minipoolDetails{
+			mpd:                           mpd,
+			expectedWithdrawalCredentials: mpd.WithdrawalCredentials,
+			pubkey:                        mpd.Pubkey,
+		}
+	}
+}
+
+// Step 1: Verify the Beacon Chain credentials for a minipool if they're present
+func (c *checker) verifyBeaconWithdrawalCredentials() {
+
+	// Get the withdrawal credentials on Beacon for each validator if they exist
+	for minipool, details := range c.minipools {
+		pubkey := details.pubkey
+
+		status := c.state.ValidatorDetails[pubkey]
+		if status.Exists {
+			// This minipool's deposit has been seen on the Beacon Chain
+			expectedCreds := details.expectedWithdrawalCredentials
+			beaconCreds := status.WithdrawalCredentials
+			if beaconCreds != expectedCreds {
+				c.printlnf("=== SCRUB DETECTED ON BEACON CHAIN ===")
+				c.printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
+				c.printlnf("\tExpected creds: %s", expectedCreds.Hex())
+				c.printlnf("\tActual creds: %s", beaconCreds.Hex())
+				c.printlnf("======================================")
+				c.addResult(minipool, Verdict_Scrub, Evidence{
+					Check:                         Check_Beacon,
+					Reason:                        "the validator's withdrawal credentials on the Beacon chain don't match the minipool",
+					ExpectedWithdrawalCredentials: expectedCreds.Hex(),
+					ActualWithdrawalCredentials:   beaconCreds.Hex(),
+				})
+				c.report.Tally.BadOnBeaconCount++
+			} else {
+				// This minipool's credentials match, it's clean.
+				c.addResult(minipool, Verdict_Pass, Evidence{
+					Check:  Check_Beacon,
+					Reason: "the validator's withdrawal credentials on the Beacon chain match the minipool",
+				})
+				c.report.Tally.GoodOnBeaconCount++
+			}
+
+			// If it was seen on Beacon we can remove it from the list of things to check on eth1.
+			// Otherwise we have to keep it in the map.
+			delete(c.minipools, minipool)
+		}
+	}
+
+}
+
+// Get various elements needed to do eth1 prestake and deposit contract searches
+func (c *checker) getEth1SearchArtifacts() error {
+
+	// Get the block to start searching the deposit contract from
+	stateBlockNumber := big.NewInt(0).SetUint64(c.state.ElBlockNumber)
+	offset := big.NewInt(BlockStartOffset)
+	if stateBlockNumber.Cmp(offset) < 0 {
+		offset = stateBlockNumber // Deal with chains that are younger than the look-behind interval
+	}
+	targetBlockNumber := big.NewInt(0).Sub(stateBlockNumber, offset)
+	targetBlock, err := c.rp.Client.HeaderByNumber(context.Background(), targetBlockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting header for EL block %d: %w", targetBlockNumber, err)
+	}
+	c.startBlock = targetBlock.Number
+
+	// Check the prestake event from the minipool and validate its signature
+	eventLogInterval, err := c.cfg.GetEventLogInterval()
+	if err != nil {
+		return fmt.Errorf("error getting event log interval %w", err)
+	}
+	c.eventLogInterval = big.NewInt(int64(eventLogInterval))
+
+	// Put together the signature validation data
+	eth2Config := c.state.BeaconConfig
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return fmt.Errorf("error computing deposit domain: %w", err)
+	}
+	c.depositDomain = depositDomain
+
+	return nil
+
+}
+
+// Step 2: Verify the MinipoolPrestaked event of each minipool
+func (c *checker) verifyPrestakeEvents() {
+
+	weiPerGwei := big.NewInt(int64(eth.WeiPerGwei))
+	for minipool := range c.minipools {
+		// Get the MinipoolPrestaked event
+		prestakeData, err := minipool.GetPrestakeEvent(c.eventLogInterval, nil)
+		if err != nil {
+			c.printlnf("Error getting prestake event for minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			continue
+		}
+
+		// Convert the amount to gwei
+		prestakeData.Amount.Div(prestakeData.Amount, weiPerGwei)
+
+		// Convert it into Prysm's deposit data struct
+		depositData := new(ethpb.Deposit_Data)
+		depositData.Amount = prestakeData.Amount.Uint64()
+		depositData.PublicKey = prestakeData.Pubkey.Bytes()
+		depositData.WithdrawalCredentials = prestakeData.WithdrawalCredentials.Bytes()
+		depositData.Signature = prestakeData.Signature.Bytes()
+
+		// Validate the signature
+		err = prdeposit.VerifyDepositSignature(depositData, c.depositDomain)
+		if err != nil {
+			// The signature is illegal
+			c.printlnf("=== SCRUB DETECTED ON PRESTAKE EVENT ===")
+			c.printlnf("Invalid prestake data for minipool %s:", minipool.GetAddress().Hex())
+			c.printlnf("\tError: %s", err.Error())
+			c.printlnf("========================================")
+
+			// Remove this minipool from the list of things to process in the next step
+			c.addResult(minipool, Verdict_Scrub, Evidence{
+				Check:  Check_Prestake,
+				Reason: fmt.Sprintf("the prestake event has an invalid deposit signature: %s", err.Error()),
+			})
+			c.report.Tally.BadPrestakeCount++
+			delete(c.minipools, minipool)
+		} else {
+			// The signature is good, it can proceed to the next step
+			c.report.Tally.GoodPrestakeCount++
+		}
+	}
+
+}
+
+// Step 3: Verify minipools by their deposits
+func (c *checker) verifyDeposits() error {
+
+	// Create a "hashset" of the remaining pubkeys
+	pubkeys := make(map[types.ValidatorPubkey]bool, len(c.minipools))
+	for _, details := range c.minipools {
+		pubkeys[details.pubkey] = true
+	}
+
+	// Get the deposits from the deposit contract
+	depositMap, err := utils.GetDeposits(c.rp, pubkeys, c.startBlock, c.eventLogInterval, nil)
+	if err != nil {
+		return err
+	}
+	c.checkDeposits(depositMap)
+	return nil
+
+}
+
+// Check each remaining minipool against the first valid deposit for its validator
+func (c *checker) checkDeposits(depositMap map[types.ValidatorPubkey][]utils.DepositData) {
+
+	for minipool, details := range c.minipools {
+
+		// Get the deposit list for this minipool
+		deposits, exists := depositMap[details.pubkey]
+		if !exists || len(deposits) == 0 {
+			// Somehow this minipool doesn't have a deposit?
+			c.report.Tally.UnknownMinipools++
+			continue
+		}
+
+		// Go through each deposit for this minipool and find the first one that's valid
+		for depositIndex, deposit := range deposits {
+			depositData := new(ethpb.Deposit_Data)
+			depositData.Amount = deposit.Amount
+			depositData.PublicKey = deposit.Pubkey.Bytes()
+			depositData.WithdrawalCredentials = deposit.WithdrawalCredentials.Bytes()
+			depositData.Signature = deposit.Signature.Bytes()
+
+			err := prdeposit.VerifyDepositSignature(depositData, c.depositDomain)
+			if err != nil {
+				// This isn't a valid deposit, so ignore it
+				c.printlnf("Invalid deposit for minipool %s:", minipool.GetAddress().Hex())
+				c.printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
+				c.printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
+				c.printlnf("\tError: %s", err.Error())
+			} else {
+				// This is a valid deposit
+				expectedCreds := details.expectedWithdrawalCredentials
+				actualCreds := deposit.WithdrawalCredentials
+				if actualCreds != expectedCreds {
+					c.printlnf("=== SCRUB DETECTED ON DEPOSIT CONTRACT ===")
+					c.printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
+					c.printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
+					c.printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
+					c.printlnf("\tExpected creds: %s", expectedCreds.Hex())
+					c.printlnf("\tActual creds: %s", actualCreds.Hex())
+					c.printlnf("==========================================")
+					c.addResult(minipool, Verdict_Scrub, Evidence{
+						Check:                         Check_Deposit,
+						Reason:                        "the first valid deposit for the validator has withdrawal credentials that don't match the minipool",
+						ExpectedWithdrawalCredentials: expectedCreds.Hex(),
+						ActualWithdrawalCredentials:   actualCreds.Hex(),
+						DepositTxHash:                 deposit.TxHash.Hex(),
+						DepositBlock:                  deposit.BlockNumber,
+						DepositIndex:                  depositIndex,
+					})
+					c.report.Tally.BadOnDepositContract++
+				} else {
+					c.addResult(minipool, Verdict_Pass, Evidence{
+						Check:         Check_Deposit,
+						Reason:        "the first valid deposit for the validator has withdrawal credentials that match the minipool",
+						DepositTxHash: deposit.TxHash.Hex(),
+						DepositBlock:  deposit.BlockNumber,
+						DepositIndex:  depositIndex,
+					})
+					c.report.Tally.GoodOnDepositContract++
+				}
+
+				// Remove this minipool from the list of things to process in the next step
+				delete(c.minipools, minipool)
+				break
+			}
+		}
+	}
+
+}
+
+// Step 4: Catch-all safety mechanism that scrubs minipools without valid deposits after a certain period of time
+// This should never be used, it's simply here as a redundant check
+func (c *checker) checkSafetyScrub() {
+
+	// Warn if there are any remaining minipools - this should never happen
+	c.printlnf("WARNING: %d minipools did not have deposit information", len(c.minipools))
+
+	safetyPeriod := c.report.SafetyPeriod
+	for minipool, details := range c.minipools {
+		mpd := details.mpd
+
+		// Verify this is actually a prelaunch minipool
+		if mpd.Status != types.Prelaunch {
+			c.printlnf("\tMinipool %s is under review but is in %d status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
+			continue
+		}
+
+		// Check the time it entered prelaunch against the safety period
+		statusTime := time.Unix(mpd.StatusTime.Int64(), 0)
+		if c.report.StateBlockTime.Sub(statusTime) > safetyPeriod {
+			c.printlnf("=== SAFETY SCRUB DETECTED ===")
+			c.printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
+			c.printlnf("\tTime since prelaunch: %s", time.Since(statusTime))
+			c.printlnf("\tSafety scrub period: %s", safetyPeriod)
+			c.printlnf("=============================")
+			c.addResult(minipool, Verdict_Scrub, Evidence{
+				Check:         Check_Safety,
+				Reason:        "the minipool has been in prelaunch for longer than the safety period without a valid deposit",
+				PrelaunchTime: statusTime.Unix(),
+				SafetyPeriod:  safetyPeriod.String(),
+			})
+			c.report.Tally.SafetyScrubs++
+			// Remove this minipool from the list of things to process in the next step
+			delete(c.minipools, minipool)
+		}
+	}
+
+}
+
+// Record the minipools that couldn't be decided and sort the results
+func (c *checker) finish() {
+	for minipool, details := range c.minipools {
+		c.addResult(minipool, Verdict_Pending, Evidence{
+			Check:         Check_Safety,
+			Reason:        "no valid deposit for the validator has been found yet",
+			PrelaunchTime: details.mpd.StatusTime.Int64(),
+			SafetyPeriod:  c.report.SafetyPeriod.String(),
+		})
+	}
+	c.report.Tally.UncoveredMinipools = len(c.minipools)
+
+	sort.Slice(c.report.Results, func(i, j int) bool {
+		return bytes.Compare(c.report.Results[i].Minipool.Bytes(), c.report.Results[j].Minipool.Bytes()) < 0
+	})
+}
+
+// Add the result for a minipool
+func (c *checker) addResult(mp minipool.Minipool, verdict Verdict, evidence Evidence) {
+	address := mp.GetAddress()
+	result := Result{
+		Minipool: address,
+		Verdict:  verdict,
+		Evidence: evidence,
+		Binding:  mp,
+	}
+	if details, exists := c.minipools[mp]; exists {
+		result.Pubkey = details.pubkey
+		prelaunchTime := time.Unix(details.mpd.StatusTime.Int64(), 0)
+		result.PrelaunchTime = prelaunchTime
+		result.ScrubPeriodEnd = prelaunchTime.Add(c.report.ScrubPeriod)
+		result.SafetyScrubTime = prelaunchTime.Add(c.report.SafetyPeriod)
+	}
+	c.report.Results = append(c.report.Results, result)
+}
+
+// Print a message if there's a logger
+func (c *checker) printlnf(format string, v ...interface{}) {
+	if c.log != nil {
+		c.log.Printlnf(format, v...)
+	}
+}
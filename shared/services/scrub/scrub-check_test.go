@@ -0,0 +1,423 @@
+package scrub
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+const testDepositAmount uint64 = 1e9 // 1 ETH in gwei
+
+var (
+	testEth2Config    = beacon.Eth2Config{GenesisForkVersion: []byte{0x00, 0x00, 0x10, 0x20}}
+	testStateTime     = time.Unix(1700000000, 0)
+	testSafetyPeriod  = 12 * time.Hour
+	testMinipoolCreds = common.HexToHash("0x0100000000000000000000001111111111111111111111111111111111111111")
+	testWrongCreds    = common.HexToHash("0x0100000000000000000000002222222222222222222222222222222222222222")
+)
+
+// A minipool that only knows its address and its prestake event
+type fakeMinipool struct {
+	minipool.Minipool
+	address     common.Address
+	prestake    minipool.PrestakeData
+	prestakeErr error
+}
+
+func (m *fakeMinipool) GetAddress() common.Address {
+	return m.address
+}
+
+func (m *fakeMinipool) GetPrestakeEvent(intervalSize *big.Int, opts *bind.CallOpts) (minipool.PrestakeData, error) {
+	return m.prestake, m.prestakeErr
+}
+
+// A validator key and the minipool it belongs to
+type testValidator struct {
+	key      *eth2types.BLSPrivateKey
+	pubkey   types.ValidatorPubkey
+	minipool *fakeMinipool
+	details  *minipoolDetails
+}
+
+func newTestValidator(t *testing.T, index byte, prelaunchTime time.Time) *testValidator {
+	t.Helper()
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	return &testValidator{
+		key:      key,
+		pubkey:   pubkey,
+		minipool: &fakeMinipool{address: common.BytesToAddress([]byte{index})},
+		details: &minipoolDetails{
+			mpd: &rpstate.NativeMinipoolDetails{
+				MinipoolAddress: common.BytesToAddress([]byte{index}),
+				Pubkey:          pubkey,
+				Status:          types.Prelaunch,
+				StatusTime:      big.NewInt(prelaunchTime.Unix()),
+			},
+			pubkey:                        pubkey,
+			expectedWithdrawalCredentials: testMinipoolCreds,
+		},
+	}
+}
+
+// Create a deposit for the validator; a signature from the wrong key makes it invalid
+func (v *testValidator) deposit(t *testing.T, creds common.Hash, signer *eth2types.BLSPrivateKey) utils.DepositData {
+	t.Helper()
+	depositData, _, err := validator.GetDepositData(signer, creds, testEth2Config, testDepositAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return utils.DepositData{
+		Pubkey:                v.pubkey,
+		WithdrawalCredentials: creds,
+		Amount:                testDepositAmount,
+		Signature:             types.BytesToValidatorSignature(depositData.Signature),
+		TxHash:                common.BytesToHash(v.pubkey.Bytes()),
+	}
+}
+
+// Create a checker for a set of validators, as it would be after getting the EL search artifacts
+func newTestChecker(t *testing.T, validatorDetails map[types.ValidatorPubkey]beacon.ValidatorStatus, validators ...*testValidator) *checker {
+	t.Helper()
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, testEth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &checker{
+		state: &state.NetworkState{
+			BeaconConfig:     testEth2Config,
+			ValidatorDetails: validatorDetails,
+		},
+		report: &Report{
+			StateBlockTime: testStateTime,
+			ScrubPeriod:    2 * testSafetyPeriod,
+			SafetyPeriod:   testSafetyPeriod,
+			Results:        []Result{},
+		},
+		minipools:     map[minipool.Minipool]*minipoolDetails{},
+		depositDomain: depositDomain,
+	}
+	for _, v := range validators {
+		c.minipools[v.minipool] = v.details
+	}
+	return c
+}
+
+// Check the result the checker recorded for a validator, or that it's still undecided if verdict is empty
+func checkResult(t *testing.T, c *checker, v *testValidator, verdict Verdict, check Check) Result {
+	t.Helper()
+	var found *Result
+	for i, result := range c.report.Results {
+		if result.Minipool == v.minipool.address {
+			found = &c.report.Results[i]
+		}
+	}
+	_, undecided := c.minipools[v.minipool]
+	if verdict == "" {
+		if found != nil || !undecided {
+			t.Fatalf("expected the minipool to still be undecided, got result %+v", found)
+		}
+		return Result{}
+	}
+	if found == nil {
+		t.Fatalf("expected a %s result from the %s check, got none", verdict, check)
+	}
+	if undecided {
+		t.Errorf("minipool has a result but is still being checked")
+	}
+	if found.Verdict != verdict || found.Evidence.Check != check {
+		t.Errorf("expected a %s result from the %s check, got %s from %s", verdict, check, found.Verdict, found.Evidence.Check)
+	}
+	if found.Pubkey != v.pubkey {
+		t.Errorf("expected pubkey %s, got %s", v.pubkey.Hex(), found.Pubkey.Hex())
+	}
+	return *found
+}
+
+func TestVerifyBeaconWithdrawalCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *beacon.ValidatorStatus
+		verdict  Verdict
+		expected Tally
+	}{
+		{
+			name:    "not on the Beacon chain",
+			status:  nil,
+			verdict: "",
+		},
+		{
+			name:     "matching credentials",
+			status:   &beacon.ValidatorStatus{Exists: true, WithdrawalCredentials: testMinipoolCreds},
+			verdict:  Verdict_Pass,
+			expected: Tally{GoodOnBeaconCount: 1},
+		},
+		{
+			name:     "wrong credentials",
+			status:   &beacon.ValidatorStatus{Exists: true, WithdrawalCredentials: testWrongCreds},
+			verdict:  Verdict_Scrub,
+			expected: Tally{BadOnBeaconCount: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := newTestValidator(t, 1, testStateTime)
+			statuses := map[types.ValidatorPubkey]beacon.ValidatorStatus{}
+			if test.status != nil {
+				statuses[v.pubkey] = *test.status
+			}
+			c := newTestChecker(t, statuses, v)
+			c.verifyBeaconWithdrawalCredentials()
+
+			result := checkResult(t, c, v, test.verdict, Check_Beacon)
+			if test.verdict == Verdict_Scrub && result.Evidence.ActualWithdrawalCredentials != testWrongCreds.Hex() {
+				t.Errorf("expected the Beacon credentials in the evidence, got %s", result.Evidence.ActualWithdrawalCredentials)
+			}
+			if c.report.Tally != test.expected {
+				t.Errorf("expected tally %+v, got %+v", test.expected, c.report.Tally)
+			}
+		})
+	}
+}
+
+func TestVerifyPrestakeEvents(t *testing.T) {
+	other := newTestValidator(t, 2, testStateTime)
+	tests := []struct {
+		name        string
+		signer      *eth2types.BLSPrivateKey
+		prestakeErr error
+		verdict     Verdict
+		expected    Tally
+	}{
+		{
+			name:     "valid signature",
+			verdict:  "",
+			expected: Tally{GoodPrestakeCount: 1},
+		},
+		{
+			name:     "invalid signature",
+			signer:   other.key,
+			verdict:  Verdict_Scrub,
+			expected: Tally{BadPrestakeCount: 1},
+		},
+		{
+			name:        "event not found",
+			prestakeErr: errors.New("no prestake event"),
+			verdict:     "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := newTestValidator(t, 1, testStateTime)
+			signer := v.key
+			if test.signer != nil {
+				signer = test.signer
+			}
+			deposit := v.deposit(t, testMinipoolCreds, signer)
+			v.minipool.prestake = minipool.PrestakeData{
+				Pubkey:                deposit.Pubkey,
+				WithdrawalCredentials: deposit.WithdrawalCredentials,
+				Amount:                eth.GweiToWei(float64(deposit.Amount)),
+				Signature:             deposit.Signature,
+			}
+			v.minipool.prestakeErr = test.prestakeErr
+
+			c := newTestChecker(t, nil, v)
+			c.verifyPrestakeEvents()
+
+			checkResult(t, c, v, test.verdict, Check_Prestake)
+			if c.report.Tally != test.expected {
+				t.Errorf("expected tally %+v, got %+v", test.expected, c.report.Tally)
+			}
+		})
+	}
+}
+
+func TestCheckDeposits(t *testing.T) {
+	other := newTestValidator(t, 2, testStateTime)
+	tests := []struct {
+		name          string
+		deposits      func(v *testValidator) []utils.DepositData
+		verdict       Verdict
+		expectedIndex int
+		expected      Tally
+	}{
+		{
+			name:     "no deposits",
+			deposits: func(v *testValidator) []utils.DepositData { return nil },
+			verdict:  "",
+			expected: Tally{UnknownMinipools: 1},
+		},
+		{
+			name: "valid deposit with matching credentials",
+			deposits: func(v *testValidator) []utils.DepositData {
+				return []utils.DepositData{v.deposit(t, testMinipoolCreds, v.key)}
+			},
+			verdict:  Verdict_Pass,
+			expected: Tally{GoodOnDepositContract: 1},
+		},
+		{
+			name: "valid deposit with wrong credentials",
+			deposits: func(v *testValidator) []utils.DepositData {
+				return []utils.DepositData{v.deposit(t, testWrongCreds, v.key)}
+			},
+			verdict:  Verdict_Scrub,
+			expected: Tally{BadOnDepositContract: 1},
+		},
+		{
+			name: "invalid front-run deposit is ignored",
+			deposits: func(v *testValidator) []utils.DepositData {
+				return []utils.DepositData{
+					v.deposit(t, testWrongCreds, other.key),
+					v.deposit(t, testMinipoolCreds, v.key),
+				}
+			},
+			verdict:       Verdict_Pass,
+			expectedIndex: 1,
+			expected:      Tally{GoodOnDepositContract: 1},
+		},
+		{
+			name: "first valid deposit decides",
+			deposits: func(v *testValidator) []utils.DepositData {
+				return []utils.DepositData{
+					v.deposit(t, testWrongCreds, v.key),
+					v.deposit(t, testMinipoolCreds, v.key),
+				}
+			},
+			verdict:  Verdict_Scrub,
+			expected: Tally{BadOnDepositContract: 1},
+		},
+		{
+			name: "only invalid deposits",
+			deposits: func(v *testValidator) []utils.DepositData {
+				return []utils.DepositData{v.deposit(t, testMinipoolCreds, other.key)}
+			},
+			verdict: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := newTestValidator(t, 1, testStateTime)
+			depositMap := map[types.ValidatorPubkey][]utils.DepositData{}
+			if deposits := test.deposits(v); deposits != nil {
+				depositMap[v.pubkey] = deposits
+			}
+			c := newTestChecker(t, nil, v)
+			c.checkDeposits(depositMap)
+
+			result := checkResult(t, c, v, test.verdict, Check_Deposit)
+			if test.verdict != "" && result.Evidence.DepositIndex != test.expectedIndex {
+				t.Errorf("expected deposit index %d in the evidence, got %d", test.expectedIndex, result.Evidence.DepositIndex)
+			}
+			if c.report.Tally != test.expected {
+				t.Errorf("expected tally %+v, got %+v", test.expected, c.report.Tally)
+			}
+		})
+	}
+}
+
+func TestCheckSafetyScrub(t *testing.T) {
+	tests := []struct {
+		name          string
+		prelaunchTime time.Time
+		status        types.MinipoolStatus
+		verdict       Verdict
+		expected      Tally
+	}{
+		{
+			name:          "inside the safety period",
+			prelaunchTime: testStateTime.Add(-time.Hour),
+			status:        types.Prelaunch,
+			verdict:       Verdict_Pending,
+			expected:      Tally{UncoveredMinipools: 1},
+		},
+		{
+			name:          "exactly at the end of the safety period",
+			prelaunchTime: testStateTime.Add(-testSafetyPeriod),
+			status:        types.Prelaunch,
+			verdict:       Verdict_Pending,
+			expected:      Tally{UncoveredMinipools: 1},
+		},
+		{
+			name:          "just past the safety period",
+			prelaunchTime: testStateTime.Add(-testSafetyPeriod - time.Second),
+			status:        types.Prelaunch,
+			verdict:       Verdict_Scrub,
+			expected:      Tally{SafetyScrubs: 1},
+		},
+		{
+			name:          "not in prelaunch",
+			prelaunchTime: testStateTime.Add(-2 * testSafetyPeriod),
+			status:        types.Staking,
+			verdict:       Verdict_Pending,
+			expected:      Tally{UncoveredMinipools: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := newTestValidator(t, 1, test.prelaunchTime)
+			v.details.mpd.Status = test.status
+			c := newTestChecker(t, nil, v)
+			c.checkSafetyScrub()
+			c.finish()
+
+			if len(c.report.Results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(c.report.Results))
+			}
+			result := c.report.Results[0]
+			if result.Verdict != test.verdict || result.Evidence.Check != Check_Safety {
+				t.Errorf("expected a %s result from the safety check, got %s from %s", test.verdict, result.Verdict, result.Evidence.Check)
+			}
+			if !result.SafetyScrubTime.Equal(test.prelaunchTime.Add(testSafetyPeriod)) {
+				t.Errorf("expected safety scrub time %s, got %s", test.prelaunchTime.Add(testSafetyPeriod), result.SafetyScrubTime)
+			}
+			if c.report.Tally != test.expected {
+				t.Errorf("expected tally %+v, got %+v", test.expected, c.report.Tally)
+			}
+		})
+	}
+}
+
+func TestReportResultsSorted(t *testing.T) {
+	first := newTestValidator(t, 1, testStateTime)
+	second := newTestValidator(t, 2, testStateTime)
+	third := newTestValidator(t, 3, testStateTime)
+	c := newTestChecker(t, nil, third, first, second)
+	c.finish()
+
+	report := c.report
+	if len(report.GetResults(Verdict_Pending)) != 3 || len(report.GetResults(Verdict_Scrub)) != 0 {
+		t.Fatalf("expected 3 pending results, got %+v", report.Results)
+	}
+	for i, v := range []*testValidator{first, second, third} {
+		if report.Results[i].Minipool != v.minipool.address {
+			t.Errorf("result %d is for %s instead of %s", i, report.Results[i].Minipool.Hex(), v.minipool.address.Hex())
+		}
+	}
+}
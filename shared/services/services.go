@@ -13,16 +13,22 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	kmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/signer"
+	"github.com/rocket-pool/smartnode/shared/services/web3signer"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -38,6 +44,7 @@ const (
 var (
 	cfg                *config.RocketPoolConfig
 	passwordManager    *passwords.PasswordManager
+	passwordManagerErr error
 	nodeWallet         *wallet.Wallet
 	ecManager          *ExecutionClientManager
 	bcManager          *BeaconClientManager
@@ -47,6 +54,9 @@ var (
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
 	docker             *client.Client
+	auditLog           *audit.Log
+	web3Signer         *web3signer.Client
+	keymanagerClient   *keymanager.Client
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
@@ -58,6 +68,9 @@ var (
 	initRplFaucet          sync.Once
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
+	initAuditLog           sync.Once
+	initWeb3Signer         sync.Once
+	initKeymanager         sync.Once
 	initDocker             sync.Once
 )
 
@@ -74,7 +87,7 @@ func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	return getPasswordManager(cfg), nil
+	return getPasswordManager(cfg)
 }
 
 func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
@@ -82,10 +95,43 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	if err != nil {
 		return nil, err
 	}
-	pm := getPasswordManager(cfg)
+	pm, err := getPasswordManager(cfg)
+	if err != nil {
+		return nil, err
+	}
 	return getWallet(c, cfg, pm)
 }
 
+func GetAuditLog(c *cli.Context) (*audit.Log, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getAuditLog(cfg), nil
+}
+
+func GetWeb3Signer(c *cli.Context) (*web3signer.Client, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Smartnode.IsWeb3SignerEnabled() {
+		return nil, fmt.Errorf("Web3Signer is not configured")
+	}
+	return getWeb3Signer(cfg), nil
+}
+
+func GetKeymanager(c *cli.Context) (*keymanager.Client, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Smartnode.IsKeymanagerApiEnabled() {
+		return nil, fmt.Errorf("the Keymanager API is not enabled")
+	}
+	return getKeymanager(cfg), nil
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -161,6 +207,37 @@ func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	return getBeaconClient(c, cfg)
 }
 
+// Cross-check critical reads against the quorum endpoints in the config, if there are any.
+// This is meant for the watchtower, whose reads decide the Oracle DAO's votes.
+func EnableQuorumMode(c *cli.Context) error {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return err
+	}
+	bc, err := getBeaconClient(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	threshold := cfg.Smartnode.QuorumThreshold.Value.(uint16)
+	ecEndpoints := parseQuorumEndpoints(cfg.Smartnode.QuorumEcUrls.Value.(string))
+	if len(ecEndpoints) > 0 {
+		err = ec.EnableQuorum(ecEndpoints, getQuorumThreshold(threshold, len(ecEndpoints)))
+		if err != nil {
+			return err
+		}
+	}
+	bcEndpoints := parseQuorumEndpoints(cfg.Smartnode.QuorumBcUrls.Value.(string))
+	if len(bcEndpoints) > 0 {
+		bc.EnableQuorum(bcEndpoints, getQuorumThreshold(threshold, len(bcEndpoints)))
+	}
+	return nil
+}
+
 func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
@@ -181,11 +258,54 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return cfg, err
 }
 
-func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
+func getPasswordManager(cfg *config.RocketPoolConfig) (*passwords.PasswordManager, error) {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		if !cfg.IsNativeMode && cfg.Smartnode.IsPasswordStoreNativeOnly() {
+			passwordManagerErr = fmt.Errorf("the '%v' wallet password store is only supported in Native Mode", cfg.Smartnode.PasswordStoreType.Value)
+			return
+		}
+
+		var store passwords.PasswordStore
+		storeName := cfg.Smartnode.PasswordStoreName.Value.(string)
+		switch cfg.Smartnode.PasswordStoreType.Value.(cfgtypes.PasswordStoreType) {
+		case cfgtypes.PasswordStoreType_File:
+			store = passwords.NewFileStore(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		case cfgtypes.PasswordStoreType_KernelKeyring:
+			store = passwords.NewKernelKeyringStore(storeName)
+		case cfgtypes.PasswordStoreType_SecretService:
+			store = passwords.NewSecretServiceStore(storeName)
+		case cfgtypes.PasswordStoreType_SystemdCredential:
+			store = passwords.NewSystemdCredentialStore(storeName)
+		case cfgtypes.PasswordStoreType_Encrypted:
+			store = passwords.NewEncryptedFileStoreWithUnlockKeyFile(os.ExpandEnv(cfg.Smartnode.GetEncryptedPasswordPath()), passwords.UnlockKeyPath)
+		default:
+			passwordManagerErr = fmt.Errorf("unknown wallet password store '%v'", cfg.Smartnode.PasswordStoreType.Value)
+			return
+		}
+		passwordManager = passwords.NewPasswordManager(store)
+	})
+	return passwordManager, passwordManagerErr
+}
+
+func getAuditLog(cfg *config.RocketPoolConfig) *audit.Log {
+	initAuditLog.Do(func() {
+		auditLog = audit.NewLog(os.ExpandEnv(cfg.Smartnode.GetWatchtowerAuditLogPath()))
 	})
-	return passwordManager
+	return auditLog
+}
+
+func getWeb3Signer(cfg *config.RocketPoolConfig) *web3signer.Client {
+	initWeb3Signer.Do(func() {
+		web3Signer = web3signer.NewClient(cfg.Smartnode.Web3SignerUrl.Value.(string))
+	})
+	return web3Signer
+}
+
+func getKeymanager(cfg *config.RocketPoolConfig) *keymanager.Client {
+	initKeymanager.Do(func() {
+		keymanagerClient = keymanager.NewClient(cfg.Smartnode.GetKeymanagerApiUrl(), os.ExpandEnv(cfg.Smartnode.GetKeymanagerTokenPath()))
+	})
+	return keymanagerClient
 }
 
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
@@ -216,7 +336,56 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 			return
 		}
 
-		// Keystores
+		// Node account signer, if the node account's key is held outside of the wallet
+		signerUrl := cfg.Smartnode.NodeSignerUrl.Value.(string)
+		signerAddress := cfg.Smartnode.NodeSignerAddress.Value.(string)
+		signerType := cfg.Smartnode.NodeSignerType.Value.(cfgtypes.NodeSignerType)
+		if signerType != cfgtypes.NodeSignerType_Local && signerUrl == "" {
+			err = fmt.Errorf("the node account signer URL must be set to use an external signer")
+			return
+		}
+		switch signerType {
+		case cfgtypes.NodeSignerType_Clef:
+			nodeWallet.SetNodeSigner(signer.NewClefSigner(signerUrl, signerAddress))
+		case cfgtypes.NodeSignerType_Http:
+			nodeWallet.SetNodeSigner(signer.NewHttpSigner(signerUrl, signerAddress))
+		}
+
+		// Transaction manager, so the processes sharing the node key don't race over nonces and stuck transactions get replaced
+		var ec *ExecutionClientManager
+		ec, err = getEthClient(c, cfg)
+		if err != nil {
+			return
+		}
+		autoTxGasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+		var maxFeeLimit *big.Int
+		if autoTxGasThreshold != 0 {
+			maxFeeLimit = eth.GweiToWei(autoTxGasThreshold)
+		}
+		txManager := wallet.NewTxManager(nodeWallet, ec, os.ExpandEnv(cfg.Smartnode.GetPendingTransactionsPath()), maxFeeLimit)
+		ec.SetSendHandler(txManager.HandleSentTransaction)
+		nodeWallet.SetTxManager(txManager)
+
+		// Keystores; with a remote signer or the Keymanager API, validator keys are only imported into the client holding them
+		if cfg.Smartnode.IsWeb3SignerEnabled() {
+			web3SignerUrl := cfg.Smartnode.Web3SignerUrl.Value.(string)
+			web3SignerKeystore := kmkeystore.NewKeystore(keymanager.NewClient(web3SignerUrl, ""))
+			web3SignerKeystore.SetSlashingProtectionPath(os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionImportPath()))
+			if cfg.Smartnode.IsKeymanagerApiEnabled() {
+				web3SignerKeystore.SetRemoteKeyClient(getKeymanager(cfg), web3SignerUrl)
+			}
+			nodeWallet.AddKeystore("web3signer", web3SignerKeystore)
+			nodeWallet.SetRemoteValidatorKeys(true)
+			return
+		}
+		if cfg.Smartnode.IsKeymanagerApiEnabled() {
+			keymanagerKeystore := kmkeystore.NewKeystore(getKeymanager(cfg))
+			keymanagerKeystore.SetSlashingProtectionPath(os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionImportPath()))
+			nodeWallet.AddKeystore("keymanager", keymanagerKeystore)
+			nodeWallet.SetRemoteValidatorKeys(true)
+			return
+		}
+		nodeWallet.SetHeldKeysPath(os.ExpandEnv(cfg.Smartnode.GetHeldValidatorKeysPath()))
 		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
 		lodestarKeystore := lokeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
 		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
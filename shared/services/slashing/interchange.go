@@ -0,0 +1,104 @@
+package slashing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// The EIP-3076 interchange format version this supports
+const InterchangeFormatVersion string = "5"
+
+// EIP-3076 slashing protection interchange data
+type Interchange struct {
+	Metadata Metadata           `json:"metadata"`
+	Data     []ValidatorHistory `json:"data"`
+}
+
+// Interchange metadata
+type Metadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// The blocks and attestations a validator has signed
+type ValidatorHistory struct {
+	Pubkey             string              `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+
+// A signed block
+type SignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// A signed attestation
+type SignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// Parse and check interchange data
+func ParseInterchange(data []byte) (*Interchange, error) {
+
+	// Decode it
+	var interchange Interchange
+	if err := json.Unmarshal(data, &interchange); err != nil {
+		return nil, fmt.Errorf("error decoding slashing protection data: %w", err)
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return nil, fmt.Errorf("slashing protection data uses interchange format version '%s', but only version %s is supported", interchange.Metadata.InterchangeFormatVersion, InterchangeFormatVersion)
+	}
+	if _, err := decodeBytes(interchange.Metadata.GenesisValidatorsRoot); err != nil {
+		return nil, fmt.Errorf("slashing protection data has invalid genesis validators root '%s': %w", interchange.Metadata.GenesisValidatorsRoot, err)
+	}
+
+	// Check each validator's history
+	for _, validator := range interchange.Data {
+		if _, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(validator.Pubkey)); err != nil {
+			return nil, fmt.Errorf("slashing protection data has invalid pubkey '%s': %w", validator.Pubkey, err)
+		}
+		for _, block := range validator.SignedBlocks {
+			if _, err := strconv.ParseUint(block.Slot, 10, 64); err != nil {
+				return nil, fmt.Errorf("slashing protection data for %s has invalid block slot '%s'", validator.Pubkey, block.Slot)
+			}
+		}
+		for _, attestation := range validator.SignedAttestations {
+			if _, err := strconv.ParseUint(attestation.SourceEpoch, 10, 64); err != nil {
+				return nil, fmt.Errorf("slashing protection data for %s has invalid attestation source epoch '%s'", validator.Pubkey, attestation.SourceEpoch)
+			}
+			if _, err := strconv.ParseUint(attestation.TargetEpoch, 10, 64); err != nil {
+				return nil, fmt.Errorf("slashing protection data for %s has invalid attestation target epoch '%s'", validator.Pubkey, attestation.TargetEpoch)
+			}
+		}
+	}
+	return &interchange, nil
+
+}
+
+// Get the pubkeys of the validators the data covers
+func (i *Interchange) Pubkeys() []types.ValidatorPubkey {
+	pubkeys := make([]types.ValidatorPubkey, 0, len(i.Data))
+	for _, validator := range i.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(validator.Pubkey))
+		if err != nil {
+			// Already checked while parsing
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys
+}
+
+// Decode a 0x-prefixed hex string
+func decodeBytes(value string) ([]byte, error) {
+	return hex.DecodeString(hexutil.RemovePrefix(value))
+}
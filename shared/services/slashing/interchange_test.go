@@ -0,0 +1,71 @@
+package slashing
+
+import (
+	"strings"
+	"testing"
+)
+
+const testPubkey string = "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+
+func TestParseInterchange(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},
+				"data":[{"pubkey":"` + testPubkey + `","signed_blocks":[{"slot":"81952"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007"}]}]}`,
+		},
+		{
+			name:    "not json",
+			data:    `slashing protection`,
+			wantErr: "error decoding",
+		},
+		{
+			name:    "wrong version",
+			data:    `{"metadata":{"interchange_format_version":"4","genesis_validators_root":"0x00"},"data":[]}`,
+			wantErr: "format version '4'",
+		},
+		{
+			name:    "bad genesis validators root",
+			data:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xzz"},"data":[]}`,
+			wantErr: "genesis validators root",
+		},
+		{
+			name:    "bad pubkey",
+			data:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x00"},"data":[{"pubkey":"0x1234"}]}`,
+			wantErr: "invalid pubkey",
+		},
+		{
+			name:    "bad block slot",
+			data:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x00"},"data":[{"pubkey":"` + testPubkey + `","signed_blocks":[{"slot":"-1"}]}]}`,
+			wantErr: "invalid block slot",
+		},
+		{
+			name:    "bad attestation target epoch",
+			data:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x00"},"data":[{"pubkey":"` + testPubkey + `","signed_attestations":[{"source_epoch":"1","target_epoch":"x"}]}]}`,
+			wantErr: "invalid attestation target epoch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			interchange, err := ParseInterchange([]byte(test.data))
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("got error %v, want one containing '%s'", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			pubkeys := interchange.Pubkeys()
+			if len(pubkeys) != 1 || pubkeys[0].Hex() != strings.TrimPrefix(testPubkey, "0x") {
+				t.Errorf("got pubkeys %v", pubkeys)
+			}
+		})
+	}
+}
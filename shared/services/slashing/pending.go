@@ -0,0 +1,104 @@
+package slashing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Get the validator keys that are held back from the validator client until their slashing protection data is imported
+// or the user accepts waiting out doppelganger detection instead.
+func LoadPendingKeys(path string) ([]types.ValidatorPubkey, error) {
+
+	// Read the file
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []types.ValidatorPubkey{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pending slashing protection file: %w", err)
+	}
+
+	// Decode it
+	var pubkeyStrings []string
+	if err := json.Unmarshal(bytes, &pubkeyStrings); err != nil {
+		return nil, fmt.Errorf("error decoding pending slashing protection file: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(pubkeyStrings))
+	for i, pubkeyString := range pubkeyStrings {
+		pubkeys[i], err = types.HexToValidatorPubkey(hexutil.RemovePrefix(pubkeyString))
+		if err != nil {
+			return nil, fmt.Errorf("pending slashing protection file has invalid pubkey '%s': %w", pubkeyString, err)
+		}
+	}
+	return pubkeys, nil
+
+}
+
+// Record validator keys that were held back without their slashing protection data
+func AddPendingKeys(path string, pubkeys []types.ValidatorPubkey) error {
+	pending, err := LoadPendingKeys(path)
+	if err != nil {
+		return err
+	}
+	for _, pubkey := range pubkeys {
+		if !ContainsPubkey(pending, pubkey) {
+			pending = append(pending, pubkey)
+		}
+	}
+	return savePendingKeys(path, pending)
+}
+
+// Remove validator keys that have been released, returning the ones that are still held
+func ClearPendingKeys(path string, pubkeys []types.ValidatorPubkey) ([]types.ValidatorPubkey, error) {
+	pending, err := LoadPendingKeys(path)
+	if err != nil {
+		return nil, err
+	}
+	remaining := []types.ValidatorPubkey{}
+	for _, pubkey := range pending {
+		if !ContainsPubkey(pubkeys, pubkey) {
+			remaining = append(remaining, pubkey)
+		}
+	}
+	return remaining, savePendingKeys(path, remaining)
+}
+
+// Save the pending keys, removing the file if there aren't any.
+// The old file is always removed first, since the daemon and the CLI can run as different users.
+func savePendingKeys(path string, pubkeys []types.ValidatorPubkey) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing pending slashing protection file: %w", err)
+	}
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	bytes, err := json.MarshalIndent(pubkeyStrings, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error encoding pending slashing protection file: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing pending slashing protection file: %w", err)
+	}
+	return nil
+}
+
+// Check if a list of pubkeys contains one
+func ContainsPubkey(pubkeys []types.ValidatorPubkey, pubkey types.ValidatorPubkey) bool {
+	for _, candidate := range pubkeys {
+		if candidate == pubkey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package slashing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestPendingKeys(t *testing.T) {
+	a := types.ValidatorPubkey{0x01}
+	b := types.ValidatorPubkey{0x02}
+	c := types.ValidatorPubkey{0x03}
+
+	tests := []struct {
+		name     string
+		add      [][]types.ValidatorPubkey
+		clear    []types.ValidatorPubkey
+		want     []types.ValidatorPubkey
+		wantFile bool
+	}{
+		{name: "nothing recorded", want: []types.ValidatorPubkey{}},
+		{name: "add", add: [][]types.ValidatorPubkey{{a, b}}, want: []types.ValidatorPubkey{a, b}, wantFile: true},
+		{name: "add duplicates", add: [][]types.ValidatorPubkey{{a, b}, {b, c}}, want: []types.ValidatorPubkey{a, b, c}, wantFile: true},
+		{name: "clear some", add: [][]types.ValidatorPubkey{{a, b, c}}, clear: []types.ValidatorPubkey{b}, want: []types.ValidatorPubkey{a, c}, wantFile: true},
+		{name: "clear all", add: [][]types.ValidatorPubkey{{a, b}}, clear: []types.ValidatorPubkey{a, b, c}, want: []types.ValidatorPubkey{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pending.json")
+			for _, pubkeys := range test.add {
+				if err := AddPendingKeys(path, pubkeys); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if test.clear != nil {
+				remaining, err := ClearPendingKeys(path, test.clear)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !equalPubkeys(remaining, test.want) {
+					t.Errorf("clearing left %v, want %v", remaining, test.want)
+				}
+			}
+
+			loaded, err := LoadPendingKeys(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalPubkeys(loaded, test.want) {
+				t.Errorf("loaded %v, want %v", loaded, test.want)
+			}
+			if _, err := os.Stat(path); test.wantFile != (err == nil) {
+				t.Errorf("got file error %v, want file %t", err, test.wantFile)
+			}
+		})
+	}
+}
+
+func equalPubkeys(a []types.ValidatorPubkey, b []types.ValidatorPubkey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
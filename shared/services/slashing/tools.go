@@ -0,0 +1,93 @@
+package slashing
+
+import (
+	"fmt"
+	"path/filepath"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Paths the database tool container mounts the validators folder and its working folder at
+const (
+	ToolValidatorsPath string = "/validators"
+	ToolWorkPath       string = "/mnt/slashing-protection"
+
+	toolInterchangeFilename string = "interchange.json"
+	prysmExportFilename     string = "slashing_protection.json"
+)
+
+// A command that runs a validator client's slashing protection database tool in a container using the client's image
+type ToolCommand struct {
+	Entrypoint string
+	Args       []string
+
+	// The file in the working folder the data is exported to or imported from
+	Filename string
+}
+
+// Get the command that exports a validator client's slashing protection database
+func GetExportCommand(client cfgtypes.ConsensusClient, network cfgtypes.Network) (ToolCommand, error) {
+	interchangePath := filepath.Join(ToolWorkPath, toolInterchangeFilename)
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		return ToolCommand{
+			Entrypoint: "lighthouse",
+			Args:       []string{"--network", getToolNetwork(network), "--datadir", filepath.Join(ToolValidatorsPath, "lighthouse"), "account", "validator", "slashing-protection", "export", interchangePath},
+			Filename:   toolInterchangeFilename,
+		}, nil
+	case cfgtypes.ConsensusClient_Prysm:
+		return ToolCommand{
+			Entrypoint: "/app/cmd/validator/validator",
+			Args:       []string{"slashing-protection-history", "export", "--accept-terms-of-use", "--datadir=" + filepath.Join(ToolValidatorsPath, "prysm-non-hd", "direct"), "--slashing-protection-export-dir=" + ToolWorkPath},
+			Filename:   prysmExportFilename,
+		}, nil
+	case cfgtypes.ConsensusClient_Teku:
+		return ToolCommand{
+			Entrypoint: "/opt/teku/bin/teku",
+			Args:       []string{"slashing-protection", "export", "--data-path=" + filepath.Join(ToolValidatorsPath, "teku"), "--to=" + interchangePath},
+			Filename:   toolInterchangeFilename,
+		}, nil
+	default:
+		return ToolCommand{}, getUnsupportedClientError(client)
+	}
+}
+
+// Get the command that imports data into a validator client's slashing protection database from the working folder
+func GetImportCommand(client cfgtypes.ConsensusClient, network cfgtypes.Network) (ToolCommand, error) {
+	interchangePath := filepath.Join(ToolWorkPath, toolInterchangeFilename)
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		return ToolCommand{
+			Entrypoint: "lighthouse",
+			Args:       []string{"--network", getToolNetwork(network), "--datadir", filepath.Join(ToolValidatorsPath, "lighthouse"), "account", "validator", "slashing-protection", "import", interchangePath},
+			Filename:   toolInterchangeFilename,
+		}, nil
+	case cfgtypes.ConsensusClient_Prysm:
+		return ToolCommand{
+			Entrypoint: "/app/cmd/validator/validator",
+			Args:       []string{"slashing-protection-history", "import", "--accept-terms-of-use", "--datadir=" + filepath.Join(ToolValidatorsPath, "prysm-non-hd", "direct"), "--slashing-protection-json-file=" + interchangePath},
+			Filename:   toolInterchangeFilename,
+		}, nil
+	case cfgtypes.ConsensusClient_Teku:
+		return ToolCommand{
+			Entrypoint: "/opt/teku/bin/teku",
+			Args:       []string{"slashing-protection", "import", "--data-path=" + filepath.Join(ToolValidatorsPath, "teku"), "--from=" + interchangePath},
+			Filename:   toolInterchangeFilename,
+		}, nil
+	default:
+		return ToolCommand{}, getUnsupportedClientError(client)
+	}
+}
+
+// Get the network name the clients' tools use
+func getToolNetwork(network cfgtypes.Network) string {
+	if network == cfgtypes.Network_Mainnet {
+		return "mainnet"
+	}
+	return "prater"
+}
+
+// Get the error for clients whose tools can't be run in a standalone container
+func getUnsupportedClientError(client cfgtypes.ConsensusClient) error {
+	return fmt.Errorf("the Smartnode can't run %s's slashing protection tool on its own; use the client's own tool instead, or enable the Keymanager API in the Smartnode settings so slashing protection data can be imported through the Validator client", client)
+}
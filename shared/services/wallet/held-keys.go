@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Held validator key settings
+const (
+	heldKeyDirMode  = 0700
+	heldKeyFileMode = 0600
+)
+
+// A validator key held back from the Validator client, encrypted with the node wallet password
+type heldValidatorKey struct {
+	Crypto         map[string]interface{}  `json:"crypto"`
+	Version        uint                    `json:"version"`
+	UUID           uuid.UUID               `json:"uuid"`
+	DerivationPath string                  `json:"path"`
+	Pubkey         rptypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Set the folder validator keys are held in until they're released to the keystores.
+// It must be outside of the folder the Validator client loads its keys from.
+func (w *Wallet) SetHeldKeysPath(path string) {
+	w.heldKeysPath = path
+}
+
+// Hold validator keys stored from now on back from the keystores, unless they're already in them, until they're released
+func (w *Wallet) HoldNewValidatorKeys() error {
+	if w.heldKeysPath == "" {
+		return errors.New("validator keys can't be held back because the wallet has no folder to hold them in")
+	}
+	w.holdNewValidatorKeys = true
+	return nil
+}
+
+// Get the validator keys that were held back since HoldNewValidatorKeys was called
+func (w *Wallet) GetNewlyHeldValidatorKeys() []rptypes.ValidatorPubkey {
+	return w.newlyHeldValidatorKeys
+}
+
+// Move a held validator key into the keystores so the Validator client can load it
+func (w *Wallet) ReleaseHeldValidatorKey(pubkey rptypes.ValidatorPubkey) error {
+
+	// Read the held key
+	keyFilePath := w.getHeldKeyPath(pubkey)
+	bytes, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return fmt.Errorf("Could not read held validator key %s: %w", pubkey.Hex(), err)
+	}
+	var heldKey heldValidatorKey
+	if err := json.Unmarshal(bytes, &heldKey); err != nil {
+		return fmt.Errorf("Could not decode held validator key %s: %w", pubkey.Hex(), err)
+	}
+
+	// Decrypt it
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("Could not get node password: %w", err)
+	}
+	decryptedKey, err := w.encryptor.Decrypt(heldKey.Crypto, password)
+	if err != nil {
+		return fmt.Errorf("Could not decrypt held validator key %s: %w", pubkey.Hex(), err)
+	}
+	key, err := eth2types.BLSPrivateKeyFromBytes(decryptedKey)
+	if err != nil {
+		return fmt.Errorf("Could not recreate held validator key %s: %w", pubkey.Hex(), err)
+	}
+	if rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()) != pubkey {
+		return fmt.Errorf("held validator key file %s is not for validator %s", keyFilePath, pubkey.Hex())
+	}
+
+	// Store it in the keystores, then stop holding it
+	if err := w.storeInKeystores(key, heldKey.DerivationPath); err != nil {
+		return err
+	}
+	if err := os.Remove(keyFilePath); err != nil {
+		return fmt.Errorf("Could not remove held validator key %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+
+}
+
+// Store a validator key in the keystores, or hold it back if new keys are being held and it isn't already in them
+func (w *Wallet) storeValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	if !w.holdNewValidatorKeys {
+		return w.storeInKeystores(key, derivationPath)
+	}
+
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	stored, err := w.isInKeystores(pubkey)
+	if err != nil {
+		return err
+	}
+	if stored {
+		return w.storeInKeystores(key, derivationPath)
+	}
+	if err := w.holdValidatorKey(key, pubkey, derivationPath); err != nil {
+		return err
+	}
+	w.newlyHeldValidatorKeys = append(w.newlyHeldValidatorKeys, pubkey)
+	return nil
+}
+
+// Store a validator key in every keystore
+func (w *Wallet) storeInKeystores(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	for name := range w.keystores {
+		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
+		if err := w.keystores[name].StoreValidatorKey(key, derivationPath); err != nil {
+			return fmt.Errorf("Could not store %s validator key: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Check if a validator key is already in the keystores
+func (w *Wallet) isInKeystores(pubkey rptypes.ValidatorPubkey) (bool, error) {
+	for name := range w.keystores {
+		key, err := w.keystores[name].LoadValidatorKey(pubkey)
+		if err != nil {
+			return false, fmt.Errorf("Could not check %s keystore for validator key %s: %w", name, pubkey.Hex(), err)
+		}
+		if key != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Write a validator key to the held keys folder
+func (w *Wallet) holdValidatorKey(key *eth2types.BLSPrivateKey, pubkey rptypes.ValidatorPubkey, derivationPath string) error {
+
+	// Encrypt it with the node password
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("Could not get node password: %w", err)
+	}
+	encryptedKey, err := w.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key %s: %w", pubkey.Hex(), err)
+	}
+	bytes, err := json.Marshal(heldValidatorKey{
+		Crypto:         encryptedKey,
+		Version:        w.encryptor.Version(),
+		UUID:           uuid.New(),
+		DerivationPath: derivationPath,
+		Pubkey:         pubkey,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key %s: %w", pubkey.Hex(), err)
+	}
+
+	// Write it to disk
+	if err := os.MkdirAll(w.heldKeysPath, heldKeyDirMode); err != nil {
+		return fmt.Errorf("Could not create held validator key folder: %w", err)
+	}
+	if err := os.WriteFile(w.getHeldKeyPath(pubkey), bytes, heldKeyFileMode); err != nil {
+		return fmt.Errorf("Could not write held validator key %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+
+}
+
+// Get the path of a held validator key
+func (w *Wallet) getHeldKeyPath(pubkey rptypes.ValidatorPubkey) string {
+	return filepath.Join(w.heldKeysPath, hexutil.AddPrefix(pubkey.Hex())+".json")
+}
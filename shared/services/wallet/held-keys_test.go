@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// A keystore that keeps validator keys in memory
+type memoryKeystore struct {
+	keys map[rptypes.ValidatorPubkey]*eth2types.BLSPrivateKey
+}
+
+func (ks *memoryKeystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	ks.keys[rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())] = key
+	return nil
+}
+
+func (ks *memoryKeystore) LoadValidatorKey(pubkey rptypes.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return ks.keys[pubkey], nil
+}
+
+func (ks *memoryKeystore) GetKeystoreDir() string {
+	return ""
+}
+
+func newHeldKeysTestWallet(t *testing.T) (*Wallet, *memoryKeystore) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(passwords.NewFileStore(filepath.Join(dir, "password")))
+	if err := pm.SetPassword("test password"); err != nil {
+		t.Fatal(err)
+	}
+	ks := &memoryKeystore{keys: map[rptypes.ValidatorPubkey]*eth2types.BLSPrivateKey{}}
+	w := &Wallet{
+		pm:        pm,
+		encryptor: eth2ks.New(),
+		keystores: map[string]keystore.Keystore{"memory": ks},
+	}
+	w.SetHeldKeysPath(filepath.Join(dir, "held-validators"))
+	return w, ks
+}
+
+func TestHeldValidatorKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		hold           bool
+		alreadyStored  bool
+		wantHeld       bool
+		wantInKeystore bool
+	}{
+		{name: "not holding", wantInKeystore: true},
+		{name: "holding a new key", hold: true, wantHeld: true},
+		{name: "holding a key that's already loaded", hold: true, alreadyStored: true, wantInKeystore: true},
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, ks := newHeldKeysTestWallet(t)
+			key, err := eth2types.GenerateBLSPrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+			derivationPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
+			if test.alreadyStored {
+				ks.keys[pubkey] = key
+			}
+			if test.hold {
+				if err := w.HoldNewValidatorKeys(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := w.StoreValidatorKey(key, derivationPath); err != nil {
+				t.Fatal(err)
+			}
+			_, inKeystore := ks.keys[pubkey]
+			if inKeystore != test.wantInKeystore {
+				t.Errorf("got key in keystore %t, want %t", inKeystore, test.wantInKeystore)
+			}
+			held := len(w.GetNewlyHeldValidatorKeys()) == 1 && w.GetNewlyHeldValidatorKeys()[0] == pubkey
+			if held != test.wantHeld {
+				t.Errorf("got key held %t, want %t", held, test.wantHeld)
+			}
+			if !test.wantHeld {
+				return
+			}
+
+			// Release it
+			if err := w.ReleaseHeldValidatorKey(pubkey); err != nil {
+				t.Fatal(err)
+			}
+			released, ok := ks.keys[pubkey]
+			if !ok || rptypes.BytesToValidatorPubkey(released.PublicKey().Marshal()) != pubkey {
+				t.Error("released key wasn't stored in the keystore")
+			}
+			if _, err := os.Stat(w.getHeldKeyPath(pubkey)); !os.IsNotExist(err) {
+				t.Errorf("held key file wasn't removed: %v", err)
+			}
+			if err := w.ReleaseHeldValidatorKey(pubkey); err == nil {
+				t.Error("expected an error releasing a key that isn't held")
+			}
+		})
+	}
+}
+
+func TestHoldNewValidatorKeysWithoutPath(t *testing.T) {
+	w := &Wallet{}
+	if err := w.HoldNewValidatorKeys(); err == nil {
+		t.Error("expected an error holding keys without a folder to hold them in")
+	}
+}
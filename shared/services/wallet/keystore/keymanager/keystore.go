@@ -0,0 +1,138 @@
+package keymanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Keymanager keystore, which imports keys into a running validator client or remote signer through its Keymanager API instead of writing them to disk
+type Keystore struct {
+	client          *keymanager.Client
+	remoteKeyClient *keymanager.Client
+	remoteSignerUrl string
+	protectionPath  string
+	encryptor       *eth2ks.Encryptor
+}
+
+// Encrypted validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{}  `json:"crypto"`
+	Version uint                    `json:"version"`
+	UUID    uuid.UUID               `json:"uuid"`
+	Path    string                  `json:"path"`
+	Pubkey  rptypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Create new Keymanager keystore
+func NewKeystore(client *keymanager.Client) *Keystore {
+	return &Keystore{
+		client:    client,
+		encryptor: eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}
+}
+
+// Also register each imported key with a validator client as a remote key held by the signer at signerUrl,
+// so the validator client starts using it without a restart
+func (ks *Keystore) SetRemoteKeyClient(client *keymanager.Client, signerUrl string) {
+	ks.remoteKeyClient = client
+	ks.remoteSignerUrl = signerUrl
+}
+
+// Import the EIP-3076 slashing protection data in protectionPath along with each key, if that file exists
+func (ks *Keystore) SetSlashingProtectionPath(protectionPath string) {
+	ks.protectionPath = protectionPath
+}
+
+// Get the keystore directory; keys are held by the client, so there isn't one
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password; the client keeps it along with the keystore, so it isn't saved here
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Create key store
+	keyStore := validatorKey{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey,
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(keyStore)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Get the slashing protection data to import with it
+	slashingProtection := ""
+	if ks.protectionPath != "" {
+		protectionBytes, err := os.ReadFile(ks.protectionPath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Could not read slashing protection data: %w", err)
+		}
+		slashingProtection = string(protectionBytes)
+	}
+
+	// Import it
+	results, err := ks.client.ImportKeystores([]string{string(keyStoreBytes)}, []string{password}, slashingProtection)
+	if err != nil {
+		return err
+	}
+	if len(results) != 1 {
+		return fmt.Errorf("Could not import the key for validator %s: the client returned %d results for 1 key", pubkey.Hex(), len(results))
+	}
+	if results[0].Status == keymanager.ImportStatus_Error {
+		return fmt.Errorf("Could not import the key for validator %s: %s", pubkey.Hex(), results[0].Message)
+	}
+
+	// Register it with the validator client
+	if ks.remoteKeyClient != nil {
+		results, err := ks.remoteKeyClient.ImportRemoteKeys([]rptypes.ValidatorPubkey{pubkey}, ks.remoteSignerUrl)
+		if err != nil {
+			return err
+		}
+		if len(results) != 1 {
+			return fmt.Errorf("Could not register validator %s with the validator client: it returned %d results for 1 key", pubkey.Hex(), len(results))
+		}
+		if results[0].Status == keymanager.ImportStatus_Error {
+			return fmt.Errorf("Could not register validator %s with the validator client: %s", pubkey.Hex(), results[0].Message)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Load a private key; keys can't be read back out of the client, so this never finds one
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
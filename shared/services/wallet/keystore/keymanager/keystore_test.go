@@ -0,0 +1,70 @@
+package keymanager
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+)
+
+func TestStoreValidatorKeyResults(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		keystoresResponse string
+		remoteKeyResponse string
+		wantErr           string
+	}{
+		{name: "imported", keystoresResponse: `{"data":[{"status":"imported"}]}`},
+		{name: "duplicate", keystoresResponse: `{"data":[{"status":"duplicate"}]}`},
+		{name: "no results", keystoresResponse: `{"data":[]}`, wantErr: "0 import results"},
+		{name: "too many results", keystoresResponse: `{"data":[{"status":"imported"},{"status":"imported"}]}`, wantErr: "2 import results"},
+		{name: "import error", keystoresResponse: `{"data":[{"status":"error","message":"bad keystore"}]}`, wantErr: "bad keystore"},
+		{name: "remote key registered", keystoresResponse: `{"data":[{"status":"imported"}]}`, remoteKeyResponse: `{"data":[{"status":"imported"}]}`},
+		{name: "no remote key results", keystoresResponse: `{"data":[{"status":"imported"}]}`, remoteKeyResponse: `{"data":[]}`, wantErr: "0 import results"},
+		{name: "remote key error", keystoresResponse: `{"data":[{"status":"imported"}]}`, remoteKeyResponse: `{"data":[{"status":"error","message":"unknown signer"}]}`, wantErr: "unknown signer"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case keymanager.KeystoresPath:
+					_, _ = w.Write([]byte(test.keystoresResponse))
+				case keymanager.RemoteKeysPath:
+					_, _ = w.Write([]byte(test.remoteKeyResponse))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			ks := NewKeystore(keymanager.NewClient(server.URL, ""))
+			if test.remoteKeyResponse != "" {
+				ks.SetRemoteKeyClient(keymanager.NewClient(server.URL, ""), "http://signer")
+			}
+			key, err := eth2types.GenerateBLSPrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ks.StoreValidatorKey(key, "m/12381/3600/0/0/0")
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
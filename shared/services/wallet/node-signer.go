@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/signer"
+)
+
+// Node account signer using the key derived from the wallet's mnemonic
+type localNodeSigner struct {
+	w *Wallet
+}
+
+// Set the signer that holds the node account's key instead of the wallet
+func (w *Wallet) SetNodeSigner(nodeSigner signer.NodeSigner) {
+	w.nodeSigner = nodeSigner
+}
+
+// Check if the node account's key is held by an external signer instead of the wallet
+func (w *Wallet) IsNodeKeyExternal() bool {
+	return w.nodeSigner != nil
+}
+
+// Get the signer for the node account
+func (w *Wallet) getNodeSigner() signer.NodeSigner {
+	if w.nodeSigner != nil {
+		return w.nodeSigner
+	}
+	return &localNodeSigner{w: w}
+}
+
+// Get the node account's address
+func (s *localNodeSigner) GetAddress() (common.Address, error) {
+	privateKey, _, err := s.w.getNodePrivateKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(privateKey.PublicKey), nil
+}
+
+// Get the URL of the node account, which is its derivation path
+func (s *localNodeSigner) GetURL() accounts.URL {
+	_, path, err := s.w.getNodePrivateKey()
+	if err != nil {
+		return accounts.URL{}
+	}
+	return accounts.URL{
+		Scheme: "",
+		Path:   path,
+	}
+}
+
+// Sign a transaction from the node account
+func (s *localNodeSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	privateKey, _, err := s.w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+}
+
+// Sign a message with the EIP-191 personal message prefix
+func (s *localNodeSigner) SignMessage(message []byte) ([]byte, error) {
+	privateKey, _, err := s.w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	messageHash := accounts.TextHash(message)
+	signedMessage, err := crypto.Sign(messageHash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing message: %w", err)
+	}
+
+	// fix the ECDSA 'v' (see https://medium.com/mycrypto/the-magic-of-digital-signatures-on-ethereum-98fe184dc9c7#:~:text=The%20version%20number,2%E2%80%9D%20was%20introduced)
+	signedMessage[crypto.RecoveryIDOffset] += 27
+	return signedMessage, nil
+}
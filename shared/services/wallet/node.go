@@ -10,6 +10,8 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -21,26 +23,17 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
-	// Get private key
-	privateKey, path, err := w.getNodePrivateKey()
+	// Get the address from the node signer
+	nodeSigner := w.getNodeSigner()
+	address, err := nodeSigner.GetAddress()
 	if err != nil {
 		return accounts.Account{}, err
 	}
 
-	// Get public key
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return accounts.Account{}, errors.New("Could not get node public key")
-	}
-
 	// Create & return account
 	return accounts.Account{
-		Address: crypto.PubkeyToAddress(*publicKeyECDSA),
-		URL: accounts.URL{
-			Scheme: "",
-			Path:   path,
-		},
+		Address: address,
+		URL:     nodeSigner.GetURL(),
 	}, nil
 
 }
@@ -53,19 +46,36 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
+	// Get the node account address
+	nodeSigner := w.getNodeSigner()
+	address, err := nodeSigner.GetAddress()
 	if err != nil {
 		return nil, err
 	}
 
-	// Create & return transactor
-	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	// Create transactor
+	chainID := w.GetChainID()
+	transactor := &bind.TransactOpts{
+		From: address,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return nodeSigner.SignTransaction(tx, chainID)
+		},
+	}
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+
+	// Let the transaction manager assign the nonce and track the transaction
+	if w.txManager != nil {
+		w.txManager.wrapSigner(transactor)
+	}
+
+	// Return
+	return transactor, nil
 
 }
 
@@ -77,6 +87,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Check the key is held by the wallet
+	if w.IsNodeKeyExternal() {
+		return nil, errors.New("The node account's key is held by an external signer")
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
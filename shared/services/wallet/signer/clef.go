@@ -0,0 +1,126 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Node account signer backed by Clef over its JSON-RPC API
+type ClefSigner struct {
+	url               string
+	configuredAddress string
+	signer            *external.ExternalSigner
+	address           *common.Address
+	lock              sync.Mutex
+}
+
+// Create a new Clef signer; it connects to Clef the first time it's used
+func NewClefSigner(url string, address string) *ClefSigner {
+	return &ClefSigner{
+		url:               url,
+		configuredAddress: address,
+	}
+}
+
+// Get the node account's address
+func (s *ClefSigner) GetAddress() (common.Address, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.address != nil {
+		return *s.address, nil
+	}
+	address, err := selectAccount(s.configuredAddress, func() ([]common.Address, error) {
+		signer, err := s.getSigner()
+		if err != nil {
+			return nil, err
+		}
+		addresses := []common.Address{}
+		for _, account := range signer.Accounts() {
+			addresses = append(addresses, account.Address)
+		}
+		return addresses, nil
+	})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting node account from Clef: %w", err)
+	}
+	s.address = &address
+	return address, nil
+}
+
+// Get the URL of the node account
+func (s *ClefSigner) GetURL() accounts.URL {
+	return accounts.URL{
+		Scheme: "clef",
+		Path:   s.url,
+	}
+}
+
+// Sign a transaction from the node account
+func (s *ClefSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	signer, err := s.getSigner()
+	s.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := signer.SignTx(accounts.Account{Address: address}, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("Clef could not sign the transaction: %w", err)
+	}
+	if err := verifySignedTransaction(tx, signedTx, chainID, address); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// Sign a message with the EIP-191 personal message prefix
+func (s *ClefSigner) SignMessage(message []byte) ([]byte, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	signer, err := s.getSigner()
+	s.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.SignText(accounts.Account{Address: address}, message)
+	if err != nil {
+		return nil, fmt.Errorf("Clef could not sign the message: %w", err)
+	}
+
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("Clef returned a signature of %d bytes instead of 65", len(signature))
+	}
+
+	// Clef's V is converted to 0 or 1, so move it back to 27 or 28
+	signature[64] += 27
+	return signature, nil
+}
+
+// Connect to Clef if it isn't connected yet; the lock must be held
+func (s *ClefSigner) getSigner() (*external.ExternalSigner, error) {
+	if s.signer != nil {
+		return s.signer, nil
+	}
+	signer, err := external.NewExternalSigner(s.url)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Clef at %s: %w", s.url, err)
+	}
+	s.signer = signer
+	return signer, nil
+}
@@ -0,0 +1,185 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Config
+const (
+	HttpSignerTimeout time.Duration = 60 * time.Second
+)
+
+// Node account signer backed by an HTTP signer exposing the standard EIP-1193 account methods over JSON-RPC
+type HttpSigner struct {
+	url               string
+	configuredAddress string
+	client            *rpc.Client
+	address           *common.Address
+	lock              sync.Mutex
+}
+
+// Transaction arguments for eth_signTransaction
+type signTransactionArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	Value                *hexutil.Big      `json:"value"`
+	Data                 hexutil.Bytes     `json:"data"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+	Type                 hexutil.Uint64    `json:"type"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+}
+
+// Create a new HTTP signer; it connects to the signer the first time it's used
+func NewHttpSigner(url string, address string) *HttpSigner {
+	return &HttpSigner{
+		url:               url,
+		configuredAddress: address,
+	}
+}
+
+// Get the node account's address
+func (s *HttpSigner) GetAddress() (common.Address, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.address != nil {
+		return *s.address, nil
+	}
+	address, err := selectAccount(s.configuredAddress, func() ([]common.Address, error) {
+		var addresses []common.Address
+		err := s.call(&addresses, "eth_accounts")
+		return addresses, err
+	})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting node account from the HTTP signer: %w", err)
+	}
+	s.address = &address
+	return address, nil
+}
+
+// Get the URL of the node account
+func (s *HttpSigner) GetURL() accounts.URL {
+	return accounts.URL{
+		Scheme: "httpsigner",
+		Path:   s.url,
+	}
+}
+
+// Sign a transaction from the node account
+func (s *HttpSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the arguments
+	args := signTransactionArgs{
+		From:    address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Data:    tx.Data(),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		ChainID: (*hexutil.Big)(chainID),
+		Type:    hexutil.Uint64(tx.Type()),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+
+	// Sign it; signers return either the raw transaction or an object containing it
+	s.lock.Lock()
+	var result json.RawMessage
+	err = s.call(&result, "eth_signTransaction", args)
+	s.lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("the HTTP signer could not sign the transaction: %w", err)
+	}
+	var rawTx hexutil.Bytes
+	if err := json.Unmarshal(result, &rawTx); err != nil {
+		var resultObject struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(result, &resultObject); err != nil {
+			return nil, fmt.Errorf("error decoding the HTTP signer's signed transaction: %w", err)
+		}
+		rawTx = resultObject.Raw
+	}
+
+	// Decode and check it
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("error decoding the HTTP signer's signed transaction: %w", err)
+	}
+	if err := verifySignedTransaction(tx, signedTx, chainID, address); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// Sign a message with the EIP-191 personal message prefix
+func (s *HttpSigner) SignMessage(message []byte) ([]byte, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	var signature hexutil.Bytes
+	err = s.call(&signature, "personal_sign", hexutil.Bytes(message), address)
+	s.lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("the HTTP signer could not sign the message: %w", err)
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("the HTTP signer returned a signature of %d bytes instead of 65", len(signature))
+	}
+
+	// Some signers return a V of 0 or 1, so move it to 27 or 28
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	return signature, nil
+}
+
+// Call a method on the signer, connecting to it if it isn't connected yet; the lock must be held
+func (s *HttpSigner) call(result interface{}, method string, args ...interface{}) error {
+	if s.client == nil {
+		client, err := rpc.DialHTTP(s.url)
+		if err != nil {
+			return fmt.Errorf("error connecting to the HTTP signer at %s: %w", s.url, err)
+		}
+		s.client = client
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), HttpSignerTimeout)
+	defer cancel()
+	return s.client.CallContext(ctx, result, method, args...)
+}
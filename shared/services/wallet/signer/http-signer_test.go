@@ -0,0 +1,177 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Start a JSON-RPC server that answers each method with a fixed result
+func newTestHttpSigner(t *testing.T, results map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+		}
+		if result, exists := results[request.Method]; exists {
+			response["result"] = result
+		} else {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHttpSignerGetAddress(t *testing.T) {
+	first := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	second := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	tests := []struct {
+		name     string
+		accounts []common.Address
+		expected common.Address
+		wantErr  string
+	}{
+		{name: "one account", accounts: []common.Address{first}, expected: first},
+		{name: "no accounts", accounts: []common.Address{}, wantErr: "does not hold any accounts"},
+		{name: "several accounts", accounts: []common.Address{first, second}, wantErr: "holds 2 accounts"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestHttpSigner(t, map[string]interface{}{"eth_accounts": test.accounts})
+			address, err := NewHttpSigner(server.URL, "").GetAddress()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				if address != test.expected {
+					t.Errorf("expected %s, got %s", test.expected.Hex(), address.Hex())
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestHttpSignerSignTransaction(t *testing.T) {
+	key := newTestKey(t)
+	otherKey := newTestKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	tx := newTestTransaction(testChainID, 100)
+
+	signed := func(value int64, signer *ecdsa.PrivateKey) hexutil.Bytes {
+		encoded, err := signTestTransaction(t, newTestTransaction(testChainID, value), testChainID, signer).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encoded
+	}
+	raw := signed(100, key)
+
+	tests := []struct {
+		name    string
+		result  interface{}
+		wantErr string
+	}{
+		{name: "raw transaction", result: raw},
+		{name: "object with the raw transaction", result: map[string]interface{}{"raw": raw, "tx": map[string]interface{}{}}},
+		{name: "undecodable result", result: 42, wantErr: "error decoding"},
+		{name: "invalid transaction bytes", result: hexutil.Bytes{0x02, 0x01}, wantErr: "error decoding"},
+		{name: "swapped transaction", result: signed(200, key), wantErr: "different transaction"},
+		{name: "swapped sender", result: signed(100, otherKey), wantErr: "instead of"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestHttpSigner(t, map[string]interface{}{"eth_signTransaction": test.result})
+			signedTx, err := NewHttpSigner(server.URL, address.Hex()).SignTransaction(tx, testChainID)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				signedBytes, err := signedTx.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(signedBytes, raw) {
+					t.Errorf("the signed transaction doesn't match the one the signer returned")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestHttpSignerSignMessage(t *testing.T) {
+	key := newTestKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	message := []byte("rocket pool")
+
+	// crypto.Sign returns a V of 0 or 1
+	signature, err := crypto.Sign(accounts.TextHash(message), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withV := func(offset byte) hexutil.Bytes {
+		adjusted := append([]byte{}, signature...)
+		adjusted[64] += offset
+		return adjusted
+	}
+
+	tests := []struct {
+		name    string
+		result  hexutil.Bytes
+		wantErr string
+	}{
+		{name: "V of 0 or 1", result: withV(0)},
+		{name: "V of 27 or 28", result: withV(27)},
+		{name: "short signature", result: signature[:64], wantErr: "64 bytes instead of 65"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestHttpSigner(t, map[string]interface{}{"personal_sign": test.result})
+			result, err := NewHttpSigner(server.URL, address.Hex()).SignMessage(message)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				if !bytes.Equal(result, withV(27)) {
+					t.Errorf("expected signature %s, got %s", withV(27), hexutil.Bytes(result))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
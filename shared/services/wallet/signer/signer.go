@@ -0,0 +1,64 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Node account signer interface, so the node account's key can be held somewhere other than the wallet
+type NodeSigner interface {
+	// Get the node account's address
+	GetAddress() (common.Address, error)
+
+	// Get the URL of the node account, describing where its key is held
+	GetURL() accounts.URL
+
+	// Sign a transaction from the node account
+	SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// Sign a message with the EIP-191 personal message prefix, returning the signature with a V of 27 or 28
+	SignMessage(message []byte) ([]byte, error)
+}
+
+// Check that a signer signed the transaction it was asked to, with the expected account, so it can't change the transaction or send it from a different account
+func verifySignedTransaction(requested *types.Transaction, signed *types.Transaction, chainID *big.Int, expected common.Address) error {
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signed) != txSigner.Hash(requested) {
+		return fmt.Errorf("the signer returned a different transaction than the one it was asked to sign")
+	}
+	sender, err := types.Sender(txSigner, signed)
+	if err != nil {
+		return fmt.Errorf("error recovering the sender of the signed transaction: %w", err)
+	}
+	if sender != expected {
+		return fmt.Errorf("the signer signed the transaction with account %s instead of %s", sender.Hex(), expected.Hex())
+	}
+	return nil
+}
+
+// Get the node account's address, using the configured address if there is one or the only account the signer holds otherwise
+func selectAccount(configured string, listAccounts func() ([]common.Address, error)) (common.Address, error) {
+	if configured != "" {
+		if !common.IsHexAddress(configured) {
+			return common.Address{}, fmt.Errorf("the node account address %s is not a valid address", configured)
+		}
+		return common.HexToAddress(configured), nil
+	}
+
+	available, err := listAccounts()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error listing the signer's accounts: %w", err)
+	}
+	switch len(available) {
+	case 0:
+		return common.Address{}, fmt.Errorf("the signer does not hold any accounts")
+	case 1:
+		return available[0], nil
+	default:
+		return common.Address{}, fmt.Errorf("the signer holds %d accounts; set the node account address to choose one", len(available))
+	}
+}
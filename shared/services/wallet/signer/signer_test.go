@@ -0,0 +1,140 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	testChainID   = big.NewInt(1)
+	testRecipient = common.HexToAddress("0x1111111111111111111111111111111111111111")
+)
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// Create the unsigned transaction a signer is asked to sign
+func newTestTransaction(chainID *big.Int, value int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       100000,
+		To:        &testRecipient,
+		Value:     big.NewInt(value),
+		Data:      []byte{0x01, 0x02},
+	})
+}
+
+func signTestTransaction(t *testing.T, tx *types.Transaction, chainID *big.Int, key *ecdsa.PrivateKey) *types.Transaction {
+	t.Helper()
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signedTx
+}
+
+func TestVerifySignedTransaction(t *testing.T) {
+	key := newTestKey(t)
+	otherKey := newTestKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	requested := newTestTransaction(testChainID, 100)
+
+	tests := []struct {
+		name    string
+		signed  *types.Transaction
+		wantErr string
+	}{
+		{
+			name:   "requested transaction from the node account",
+			signed: signTestTransaction(t, requested, testChainID, key),
+		},
+		{
+			name:    "different transaction",
+			signed:  signTestTransaction(t, newTestTransaction(testChainID, 200), testChainID, key),
+			wantErr: "different transaction",
+		},
+		{
+			name:    "different account",
+			signed:  signTestTransaction(t, requested, testChainID, otherKey),
+			wantErr: "instead of",
+		},
+		{
+			name:    "different chain",
+			signed:  signTestTransaction(t, newTestTransaction(big.NewInt(5), 100), big.NewInt(5), key),
+			wantErr: "error recovering the sender",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifySignedTransaction(requested, test.signed, testChainID, address)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectAccount(t *testing.T) {
+	first := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	second := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	tests := []struct {
+		name       string
+		configured string
+		available  []common.Address
+		listErr    error
+		expected   common.Address
+		wantErr    string
+	}{
+		{name: "configured address", configured: second.Hex(), available: []common.Address{first, second}, expected: second},
+		{name: "configured address isn't checked against the signer", configured: second.Hex(), listErr: errors.New("unreachable"), expected: second},
+		{name: "invalid configured address", configured: "0x1234", wantErr: "not a valid address"},
+		{name: "no accounts", available: []common.Address{}, wantErr: "does not hold any accounts"},
+		{name: "one account", available: []common.Address{first}, expected: first},
+		{name: "several accounts", available: []common.Address{first, second}, wantErr: "holds 2 accounts"},
+		{name: "listing fails", listErr: errors.New("connection refused"), wantErr: "connection refused"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := selectAccount(test.configured, func() ([]common.Address, error) {
+				return test.available, test.listErr
+			})
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				if address != test.expected {
+					t.Errorf("expected %s, got %s", test.expected.Hex(), address.Hex())
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing '%s'", err, test.wantErr)
+			}
+		})
+	}
+}
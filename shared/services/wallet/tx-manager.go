@@ -0,0 +1,647 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long a transaction can go unmined before its fee is bumped
+	pendingTxBumpDeadline time.Duration = 3 * time.Minute
+
+	// How much to raise the fees by when bumping a transaction, in percent; Execution clients require at least 10%
+	pendingTxBumpPercent int64 = 15
+
+	// How many times a dropped transaction can fail to be rebroadcast before it's given up on
+	pendingTxMaxFailures int = 10
+
+	// How long to wait between checks while waiting for a transaction to be mined
+	pendingTxPollInterval time.Duration = 5 * time.Second
+
+	// How long to wait for the lock on the pending transaction file
+	txLockTimeout time.Duration = 30 * time.Second
+
+	// How long to wait between attempts to take the lock on the pending transaction file
+	txLockRetryDelay time.Duration = 100 * time.Millisecond
+
+	// How long a nonce can stay reserved for a transaction that's being signed and sent before it's released
+	nonceReservationTimeout time.Duration = 5 * time.Minute
+)
+
+// A transaction from the node wallet that hasn't been mined yet
+type PendingTransaction struct {
+	From         common.Address `json:"from"`
+	Nonce        uint64         `json:"nonce"`
+	Hashes       []common.Hash  `json:"hashes"`
+	RawTx        hexutil.Bytes  `json:"rawTx"`
+	MaxFeeLimit  *big.Int       `json:"maxFeeLimit"`
+	FirstSent    time.Time      `json:"firstSent"`
+	LastSent     time.Time      `json:"lastSent"`
+	Bumps        int            `json:"bumps"`
+	Rebroadcasts int            `json:"rebroadcasts"`
+	Failures     int            `json:"failures"`
+
+	// Set while the nonce is reserved for a transaction that hasn't been sent yet
+	Reserved bool `json:"reserved,omitempty"`
+}
+
+// A transaction that was signed with a nonce from the manager but hasn't been sent yet
+type signedTransaction struct {
+	from        common.Address
+	nonce       uint64
+	rawTx       []byte
+	maxFeeLimit *big.Int
+	signedAt    time.Time
+}
+
+// The pending transaction file
+type pendingTransactionFile struct {
+	Transactions []*PendingTransaction `json:"transactions"`
+}
+
+// Tracks the node wallet's transactions by nonce and persists them to disk.
+// Every process that shares the node key (the node daemon, the watchtower and the API) assigns nonces through the same file under a lock,
+// so concurrent senders don't collide, and the daemons periodically bump or rebroadcast any of them that get stuck or dropped.
+type TxManager struct {
+	w           *Wallet
+	ec          rocketpool.ExecutionClient
+	path        string
+	maxFeeLimit *big.Int
+	signed      map[common.Hash]*signedTransaction
+	lock        sync.Mutex
+}
+
+// Create a new transaction manager.
+// maxFeeLimit is the highest max fee this process will bump its transactions to; if it's nil or lower than a transaction's own max fee, that transaction will only be rebroadcast.
+func NewTxManager(w *Wallet, ec rocketpool.ExecutionClient, path string, maxFeeLimit *big.Int) *TxManager {
+	return &TxManager{
+		w:           w,
+		ec:          ec,
+		path:        path,
+		maxFeeLimit: maxFeeLimit,
+		signed:      map[common.Hash]*signedTransaction{},
+	}
+}
+
+// Set the highest max fee this process will bump its transactions to
+func (m *TxManager) SetMaxFeeLimit(maxFeeLimit *big.Int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.maxFeeLimit = maxFeeLimit
+}
+
+// Get the node's pending transactions, sorted by nonce
+func (m *TxManager) GetPendingTransactions() ([]*PendingTransaction, error) {
+	var transactions []*PendingTransaction
+	err := m.withLock(func(file *pendingTransactionFile) (bool, error) {
+		for _, pendingTx := range file.Transactions {
+			if !pendingTx.Reserved {
+				transactions = append(transactions, pendingTx)
+			}
+		}
+		return false, nil
+	})
+	return transactions, err
+}
+
+// Wrap a transactor's signer so the transactions it signs get the next free nonce and are tracked once they're sent.
+// If the caller set a nonce on the transactor explicitly (e.g. with --nonce), that nonce is used and the transaction replaces the tracked one.
+// The nonce is reserved in the pending transaction file while the transaction is signed, so the lock isn't held during slow external signers;
+// HandleSentTransaction has to be called with the result of sending it so the reservation becomes a tracked transaction or is released.
+func (m *TxManager) wrapSigner(opts *bind.TransactOpts) {
+	signer := opts.Signer
+	opts.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		// Transactions that won't be sent don't need a nonce from the manager
+		if opts.NoSend {
+			return signer(from, tx)
+		}
+
+		// Reserve the nonce
+		nonce := tx.Nonce()
+		reserved := false
+		err := m.withLock(func(file *pendingTransactionFile) (bool, error) {
+			if opts.Nonce != nil {
+				// Explicit nonces replace whatever is tracked for them, so only reserve ones that aren't
+				if file.get(from, nonce) != nil {
+					return false, nil
+				}
+			} else {
+				pendingNonce, err := m.ec.PendingNonceAt(context.Background(), from)
+				if err != nil {
+					return false, fmt.Errorf("error getting pending nonce: %w", err)
+				}
+				nonce = pendingNonce
+				for file.get(from, nonce) != nil {
+					nonce++
+				}
+			}
+			file.Transactions = append(file.Transactions, &PendingTransaction{
+				From:      from,
+				Nonce:     nonce,
+				FirstSent: time.Now(),
+				Reserved:  true,
+			})
+			reserved = true
+			return true, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error assigning transaction nonce: %w", err)
+		}
+
+		// Sign the transaction with that nonce
+		signedTx, err := signer(from, copyTransaction(tx, nonce, tx.GasFeeCap(), tx.GasTipCap()))
+		if err == nil {
+			var rawTx []byte
+			rawTx, err = signedTx.MarshalBinary()
+			if err != nil {
+				err = fmt.Errorf("error serializing transaction: %w", err)
+			} else {
+				maxFeeLimit := m.getMaxFeeLimit()
+				if maxFeeLimit == nil || maxFeeLimit.Cmp(tx.GasFeeCap()) < 0 {
+					maxFeeLimit = tx.GasFeeCap()
+				}
+				m.lock.Lock()
+				m.signed[signedTx.Hash()] = &signedTransaction{
+					from:        from,
+					nonce:       nonce,
+					rawTx:       rawTx,
+					maxFeeLimit: maxFeeLimit,
+					signedAt:    time.Now(),
+				}
+				m.lock.Unlock()
+				return signedTx, nil
+			}
+		}
+
+		// Release the reservation if it couldn't be signed
+		if reserved {
+			if releaseErr := m.releaseReservation(from, nonce); releaseErr != nil {
+				return nil, fmt.Errorf("%w (and the nonce reservation couldn't be released: %s)", err, releaseErr.Error())
+			}
+		}
+		return nil, err
+	}
+}
+
+// Handle the result of sending a transaction; transactions signed by the manager are tracked if they were sent, and their nonce reservation is released if they weren't.
+// Problems are written to stderr, since stdout is the API's JSON response in the processes that aren't daemons.
+func (m *TxManager) HandleSentTransaction(tx *types.Transaction, sendErr error) {
+	m.lock.Lock()
+	signedTx, exists := m.signed[tx.Hash()]
+	delete(m.signed, tx.Hash())
+	m.lock.Unlock()
+	if !exists {
+		return
+	}
+
+	if sendErr != nil {
+		if err := m.releaseReservation(signedTx.from, signedTx.nonce); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: Couldn't release the reservation for nonce %d: %s\n", signedTx.nonce, err.Error())
+		}
+		return
+	}
+
+	// Track it
+	err := m.withLock(func(file *pendingTransactionFile) (bool, error) {
+		now := time.Now()
+		pendingTx := file.get(signedTx.from, signedTx.nonce)
+		if pendingTx == nil {
+			pendingTx = &PendingTransaction{
+				From:      signedTx.from,
+				Nonce:     signedTx.nonce,
+				FirstSent: now,
+			}
+			file.Transactions = append(file.Transactions, pendingTx)
+		}
+		if pendingTx.Reserved {
+			pendingTx.Reserved = false
+			pendingTx.FirstSent = now
+		}
+		pendingTx.Hashes = append(pendingTx.Hashes, tx.Hash())
+		pendingTx.RawTx = signedTx.rawTx
+		pendingTx.MaxFeeLimit = signedTx.maxFeeLimit
+		pendingTx.LastSent = now
+		pendingTx.Failures = 0
+		return true, nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: Couldn't track transaction %s with nonce %d: %s\n", tx.Hash().Hex(), signedTx.nonce, err.Error())
+	}
+}
+
+// Release a nonce reserved for a transaction that wasn't sent
+func (m *TxManager) releaseReservation(from common.Address, nonce uint64) error {
+	return m.withLock(func(file *pendingTransactionFile) (bool, error) {
+		pendingTx := file.get(from, nonce)
+		if pendingTx == nil || !pendingTx.Reserved {
+			return false, nil
+		}
+		file.remove(pendingTx)
+		return true, nil
+	})
+}
+
+// Check the node's pending transactions, forgetting the ones that were mined, rebroadcasting the ones that were dropped,
+// and bumping the fees of the ones that have been waiting too long
+func (m *TxManager) CheckPendingTransactions(logger *log.ColorLogger) error {
+	nodeAccount, err := m.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Forget transactions that were signed but never sent
+	m.lock.Lock()
+	for hash, signedTx := range m.signed {
+		if time.Since(signedTx.signedAt) >= nonceReservationTimeout {
+			delete(m.signed, hash)
+		}
+	}
+	m.lock.Unlock()
+
+	return m.withLock(func(file *pendingTransactionFile) (bool, error) {
+		if len(file.Transactions) == 0 {
+			return false, nil
+		}
+
+		// Get the latest nonce that has been mined
+		latestNonce, err := m.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+		if err != nil {
+			return false, fmt.Errorf("error getting latest nonce: %w", err)
+		}
+
+		remaining := []*PendingTransaction{}
+		for _, pendingTx := range file.Transactions {
+			// Release nonces that were reserved by a process that never reported whether it sent the transaction
+			if pendingTx.Reserved {
+				if time.Since(pendingTx.FirstSent) < nonceReservationTimeout {
+					remaining = append(remaining, pendingTx)
+				} else {
+					printTxMessage(logger, "Releasing nonce %d, which was reserved for a transaction that was never sent.", pendingTx.Nonce)
+				}
+				continue
+			}
+
+			// Leave transactions from other accounts alone (e.g. from before the wallet was changed)
+			if pendingTx.From != nodeAccount.Address {
+				remaining = append(remaining, pendingTx)
+				continue
+			}
+
+			keep, err := m.checkPendingTransaction(pendingTx, latestNonce, logger)
+			if err != nil {
+				printTxMessage(logger, "WARNING: Couldn't check transaction with nonce %d: %s", pendingTx.Nonce, err.Error())
+				keep = true
+			}
+			if keep {
+				remaining = append(remaining, pendingTx)
+			}
+		}
+		file.Transactions = remaining
+		return true, nil
+	})
+}
+
+// Check a single pending transaction, returning false once it doesn't need to be tracked anymore
+func (m *TxManager) checkPendingTransaction(pendingTx *PendingTransaction, latestNonce uint64, logger *log.ColorLogger) (bool, error) {
+
+	// Check if the nonce has been used
+	if pendingTx.Nonce < latestNonce {
+		for _, hash := range pendingTx.Hashes {
+			receipt, err := m.ec.TransactionReceipt(context.Background(), hash)
+			if err == nil && receipt != nil {
+				printTxMessage(logger, "Transaction %s with nonce %d was mined in block %d.", hash.Hex(), pendingTx.Nonce, receipt.BlockNumber.Uint64())
+				return false, nil
+			}
+		}
+		printTxMessage(logger, "Nonce %d was used by a transaction the Smartnode didn't send, so it will stop tracking %s.", pendingTx.Nonce, pendingTx.latestHash().Hex())
+		return false, nil
+	}
+
+	// Rebroadcast the transaction if the client doesn't know about it anymore
+	tx := new(types.Transaction)
+	err := tx.UnmarshalBinary(pendingTx.RawTx)
+	if err != nil {
+		return false, fmt.Errorf("error deserializing transaction: %w", err)
+	}
+	_, _, err = m.ec.TransactionByHash(context.Background(), tx.Hash())
+	if errors.Is(err, ethereum.NotFound) {
+		printTxMessage(logger, "Transaction %s with nonce %d was dropped, rebroadcasting it...", tx.Hash().Hex(), pendingTx.Nonce)
+		pendingTx.Rebroadcasts++
+		return m.send(pendingTx, tx, logger), nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error getting transaction %s: %w", tx.Hash().Hex(), err)
+	}
+
+	// Bump the fees if it's been waiting too long
+	if time.Since(pendingTx.LastSent) < pendingTxBumpDeadline {
+		return true, nil
+	}
+	feeCap := bumpFee(tx.GasFeeCap())
+	tipCap := bumpFee(tx.GasTipCap())
+	if pendingTx.MaxFeeLimit != nil && feeCap.Cmp(pendingTx.MaxFeeLimit) > 0 {
+		printTxMessage(logger, "Transaction %s with nonce %d has been pending since %s, but its max fee can't be raised above the limit of %s wei.", tx.Hash().Hex(), pendingTx.Nonce, pendingTx.FirstSent.Format(time.RFC1123), pendingTx.MaxFeeLimit.String())
+		pendingTx.LastSent = time.Now()
+		return true, nil
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = feeCap
+	}
+	bumpedTx, err := m.w.signNodeTransaction(copyTransaction(tx, pendingTx.Nonce, feeCap, tipCap))
+	if err != nil {
+		return false, fmt.Errorf("error signing replacement transaction: %w", err)
+	}
+	rawTx, err := bumpedTx.MarshalBinary()
+	if err != nil {
+		return false, fmt.Errorf("error serializing replacement transaction: %w", err)
+	}
+	printTxMessage(logger, "Transaction %s with nonce %d has been pending since %s, replacing it with %s (max fee %s wei, priority fee %s wei)...", tx.Hash().Hex(), pendingTx.Nonce, pendingTx.FirstSent.Format(time.RFC1123), bumpedTx.Hash().Hex(), feeCap.String(), tipCap.String())
+	pendingTx.Hashes = append(pendingTx.Hashes, bumpedTx.Hash())
+	pendingTx.RawTx = rawTx
+	pendingTx.Bumps++
+	return m.send(pendingTx, bumpedTx, logger), nil
+
+}
+
+// Send a tracked transaction, returning false if it should stop being tracked
+func (m *TxManager) send(pendingTx *PendingTransaction, tx *types.Transaction, logger *log.ColorLogger) bool {
+	pendingTx.LastSent = time.Now()
+	err := m.ec.SendTransaction(context.Background(), tx)
+	if err == nil {
+		pendingTx.Failures = 0
+		return true
+	}
+
+	// Errors that mean the transaction is already taken care of
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "nonce too low") {
+		printTxMessage(logger, "Nonce %d has already been used, so the Smartnode will stop tracking %s.", pendingTx.Nonce, tx.Hash().Hex())
+		return false
+	}
+	if strings.Contains(message, "already known") || strings.Contains(message, "known transaction") {
+		return true
+	}
+
+	pendingTx.Failures++
+	if pendingTx.Failures >= pendingTxMaxFailures {
+		printTxMessage(logger, "WARNING: Transaction %s with nonce %d failed to send %d times in a row, giving up on it: %s", tx.Hash().Hex(), pendingTx.Nonce, pendingTx.Failures, err.Error())
+		return false
+	}
+	printTxMessage(logger, "WARNING: Couldn't send transaction %s with nonce %d: %s", tx.Hash().Hex(), pendingTx.Nonce, err.Error())
+	return true
+}
+
+// Get the max fee limit for this process
+func (m *TxManager) getMaxFeeLimit() *big.Int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.maxFeeLimit
+}
+
+// Load the pending transaction file while holding its lock, and save it afterwards if the function changed it
+func (m *TxManager) withLock(function func(file *pendingTransactionFile) (bool, error)) error {
+	return withPendingTxFile(m.path, function)
+}
+
+// Wait for a transaction to be mined, following any replacements the transaction manager makes for it.
+// Transactions the manager isn't tracking are waited for by their hash alone.
+func WaitForTransaction(ec rocketpool.ExecutionClient, pendingTxPath string, hash common.Hash) (*types.Receipt, error) {
+	hashes := []common.Hash{hash}
+	var pendingTx *PendingTransaction
+	for {
+		// Pick up any replacements
+		err := withPendingTxFile(pendingTxPath, func(file *pendingTransactionFile) (bool, error) {
+			for _, candidate := range file.Transactions {
+				if candidate.hasHash(hash) {
+					pendingTx = candidate
+					for _, replacement := range candidate.Hashes {
+						if !containsHash(hashes, replacement) {
+							hashes = append(hashes, replacement)
+						}
+					}
+				}
+			}
+			return false, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if pendingTx == nil {
+			return utils.WaitForTransaction(ec, hash)
+		}
+
+		// Check each version of the transaction
+		receipt, err := getReceipt(ec, hashes)
+		if receipt != nil || err != nil {
+			return receipt, err
+		}
+
+		// Stop if the nonce was used by some other transaction
+		latestNonce, err := ec.NonceAt(context.Background(), pendingTx.From, nil)
+		if err == nil && latestNonce > pendingTx.Nonce {
+			receipt, err := getReceipt(ec, hashes)
+			if receipt != nil || err != nil {
+				return receipt, err
+			}
+			return nil, fmt.Errorf("Nonce %d was used by a transaction other than %s.", pendingTx.Nonce, hash.Hex())
+		}
+		time.Sleep(pendingTxPollInterval)
+	}
+}
+
+// Get the receipt for whichever of the given transactions was mined, or nil if none of them were
+func getReceipt(ec rocketpool.ExecutionClient, hashes []common.Hash) (*types.Receipt, error) {
+	for _, hash := range hashes {
+		receipt, err := ec.TransactionReceipt(context.Background(), hash)
+		if err != nil || receipt == nil {
+			continue
+		}
+		if receipt.Status == 0 {
+			return receipt, fmt.Errorf("Transaction %s failed with status 0", hash.Hex())
+		}
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+// Load a pending transaction file while holding its lock, and save it afterwards if the function changed it
+func withPendingTxFile(path string, function func(file *pendingTransactionFile) (bool, error)) error {
+
+	// Take the lock, which is shared with the other processes using the file
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating folder for pending transaction file: %w", err)
+	}
+	lockFile, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, FileMode)
+	if err != nil {
+		return fmt.Errorf("error opening pending transaction lock file: %w", err)
+	}
+	defer lockFile.Close()
+	deadline := time.Now().Add(txLockTimeout)
+	for {
+		err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			return fmt.Errorf("error locking pending transaction file: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for another process to release the pending transaction file")
+		}
+		time.Sleep(txLockRetryDelay)
+	}
+	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+
+	// Load the file
+	file := &pendingTransactionFile{}
+	bytes, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading pending transaction file: %w", err)
+	}
+	if err == nil {
+		err = json.Unmarshal(bytes, file)
+		if err != nil {
+			return fmt.Errorf("error deserializing pending transaction file: %w", err)
+		}
+	}
+
+	// Run the function
+	changed, err := function(file)
+	if err != nil || !changed {
+		return err
+	}
+
+	// Save the file
+	sort.Slice(file.Transactions, func(i, j int) bool {
+		return file.Transactions[i].Nonce < file.Transactions[j].Nonce
+	})
+	bytes, err = json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing pending transaction file: %w", err)
+	}
+	// Write it to a temporary file and move that into place, so an interrupted write can't leave a corrupt file behind
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, FileMode)
+	if err != nil {
+		return fmt.Errorf("error writing pending transaction file: %w", err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error replacing pending transaction file: %w", err)
+	}
+	return nil
+
+}
+
+// Get the tracked transaction for an account and nonce, or nil if there isn't one
+func (f *pendingTransactionFile) get(from common.Address, nonce uint64) *PendingTransaction {
+	for _, pendingTx := range f.Transactions {
+		if pendingTx.From == from && pendingTx.Nonce == nonce {
+			return pendingTx
+		}
+	}
+	return nil
+}
+
+// Stop tracking a transaction
+func (f *pendingTransactionFile) remove(pendingTx *PendingTransaction) {
+	remaining := []*PendingTransaction{}
+	for _, candidate := range f.Transactions {
+		if candidate != pendingTx {
+			remaining = append(remaining, candidate)
+		}
+	}
+	f.Transactions = remaining
+}
+
+// Check if a hash belongs to any version of a transaction
+func (t *PendingTransaction) hasHash(hash common.Hash) bool {
+	return containsHash(t.Hashes, hash)
+}
+
+// Check if a list of hashes contains a hash
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, candidate := range hashes {
+		if candidate == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the hash of the latest version of a transaction
+func (t *PendingTransaction) latestHash() common.Hash {
+	if len(t.Hashes) == 0 {
+		return common.Hash{}
+	}
+	return t.Hashes[len(t.Hashes)-1]
+}
+
+// Sign a transaction with the node key
+func (w *Wallet) signNodeTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	return w.getNodeSigner().SignTransaction(tx, w.chainID)
+}
+
+// Create an unsigned copy of a transaction with a different nonce and fees
+func copyTransaction(tx *types.Transaction, nonce uint64, feeCap *big.Int, tipCap *big.Int) *types.Transaction {
+	if tx.Type() == types.DynamicFeeTxType {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasTipCap:  tipCap,
+			GasFeeCap:  feeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: feeCap,
+		Gas:      tx.Gas(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	})
+}
+
+// Raise a fee by the bump percentage, by at least 1 wei
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := big.NewInt(0).Mul(fee, big.NewInt(100+pendingTxBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
+
+// Log a message if there's a logger
+func printTxMessage(logger *log.ColorLogger, format string, v ...interface{}) {
+	if logger != nil {
+		logger.Printlnf(format, v...)
+	}
+}
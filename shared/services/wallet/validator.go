@@ -63,7 +63,22 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2t
 	}
 
 	// Load the key from the wallet's keystores
-	return w.LoadValidatorKey(pubkey)
+	key, err := w.LoadValidatorKey(pubkey)
+	if err == nil || !w.remoteValidatorKeys {
+		return key, err
+	}
+
+	// Keys held by a remote signer can't be read back, so derive the key from the seed instead
+	for index := uint(0); index < w.ws.NextAccount; index++ {
+		key, _, err := w.getValidatorPrivateKey(index)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(pubkey.Bytes(), key.PublicKey().Marshal()) {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("the key for validator %s is held by the remote signer and was not derived from this wallet", pubkey.Hex())
 
 }
 
@@ -96,19 +111,9 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 }
 
-// Stores a validator key into all of the wallet's keystores
+// Stores a validator key into all of the wallet's keystores, or holds it back if new keys are being held
 func (w *Wallet) StoreValidatorKey(key *eth2types.BLSPrivateKey, path string) error {
-
-	for name := range w.keystores {
-		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
-		if err := w.keystores[name].StoreValidatorKey(key, path); err != nil {
-			return fmt.Errorf("Could not store %s validator key: %w", name, err)
-		}
-	}
-
-	// Return validator key
-	return nil
-
+	return w.storeValidatorKey(key, path)
 }
 
 // Loads a validator key from the wallet's keystores
@@ -209,11 +214,8 @@ func (w *Wallet) SaveValidatorKey(key ValidatorKey) error {
 	}
 
 	// Update keystores
-	for name := range w.keystores {
-		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
-		if err := w.keystores[name].StoreValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
-			return fmt.Errorf("could not store validator key %s in %s keystore: %w", key.PublicKey.Hex(), name, err)
-		}
+	if err := w.storeValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
+		return fmt.Errorf("could not store validator key %s: %w", key.PublicKey.Hex(), err)
 	}
 
 	// Return
@@ -255,11 +257,8 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey, startIndex
 	}
 
 	// Update keystores
-	for name := range w.keystores {
-		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
-		if err := w.keystores[name].StoreValidatorKey(validatorKey, derivationPath); err != nil {
-			return 0, fmt.Errorf("Could not store %s validator key: %w", name, err)
-		}
+	if err := w.storeValidatorKey(validatorKey, derivationPath); err != nil {
+		return 0, err
 	}
 
 	// Return
@@ -10,16 +10,16 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/signer"
 )
 
 // Config
@@ -57,10 +57,24 @@ type Wallet struct {
 	// Keystores
 	keystores map[string]keystore.Keystore
 
+	// The external signer holding the node account's key, if it isn't derived from the mnemonic
+	nodeSigner signer.NodeSigner
+
+	// Whether the validator keys are held by a remote signer instead of the keystores
+	remoteValidatorKeys bool
+
+	// Validator keys held back from the keystores until they're released
+	heldKeysPath           string
+	holdNewValidatorKeys   bool
+	newlyHeldValidatorKeys []rptypes.ValidatorPubkey
+
 	// Desired gas price & limit from config
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Transaction manager
+	txManager *TxManager
 }
 
 // Encrypted wallet store
@@ -100,6 +114,16 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 
 }
 
+// Set the transaction manager that assigns nonces to and tracks the node account's transactions
+func (w *Wallet) SetTxManager(txManager *TxManager) {
+	w.txManager = txManager
+}
+
+// Get the transaction manager, or nil if there isn't one
+func (w *Wallet) GetTxManager() *TxManager {
+	return w.txManager
+}
+
 // Gets the wallet's chain ID
 func (w *Wallet) GetChainID() *big.Int {
 	copy := big.NewInt(0).Set(w.chainID)
@@ -111,6 +135,11 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Mark the validator keys as held by a remote signer, so they're derived from the seed when they're needed locally
+func (w *Wallet) SetRemoteValidatorKeys(remote bool) {
+	w.remoteValidatorKeys = remote
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -270,22 +299,15 @@ func (w *Wallet) Delete() error {
 
 }
 
-// Signs a serialized TX using the wallet's private key
+// Signs a serialized TX using the node account's key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
 	tx := types.Transaction{}
-	err = tx.UnmarshalBinary(serializedTx)
+	err := tx.UnmarshalBinary(serializedTx)
 	if err != nil {
 		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
 	}
 
-	signer := types.NewLondonSigner(w.chainID)
-	signedTx, err := types.SignTx(&tx, signer, privateKey)
+	signedTx, err := w.getNodeSigner().SignTransaction(&tx, w.chainID)
 	if err != nil {
 		return nil, fmt.Errorf("Error signing TX: %w", err)
 	}
@@ -298,23 +320,9 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 	return signedData, nil
 }
 
-// Signs an arbitrary message using the wallet's private key
+// Signs an arbitrary message using the node account's key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
-	// Get the wallet's private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
-	messageHash := accounts.TextHash([]byte(message))
-	signedMessage, err := crypto.Sign(messageHash, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("Error signing message: %w", err)
-	}
-
-	// fix the ECDSA 'v' (see https://medium.com/mycrypto/the-magic-of-digital-signatures-on-ethereum-98fe184dc9c7#:~:text=The%20version%20number,2%E2%80%9D%20was%20introduced)
-	signedMessage[crypto.RecoveryIDOffset] += 27
-	return signedMessage, nil
+	return w.getNodeSigner().SignMessage([]byte(message))
 }
 
 // Reloads wallet from disk
@@ -0,0 +1,135 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	SignPathFormat     string        = "/api/v1/eth2/sign/%s"
+	RequestContentType string        = "application/json"
+	RequestTimeout     time.Duration = 60 * time.Second
+
+	signType_VoluntaryExit string = "VOLUNTARY_EXIT"
+)
+
+// Client for a Web3Signer remote signer's signing API; its keys are managed through the Keymanager API
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// Create a new Web3Signer client
+func NewClient(url string) *Client {
+	return &Client{
+		url: strings.TrimSuffix(url, "/"),
+		client: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}
+}
+
+// Get a voluntary exit message signature from the signer.
+// The signer computes the signing domain itself from the fork info, picking the fork version for the exit epoch.
+func (c *Client) SignVoluntaryExit(pubkey types.ValidatorPubkey, validatorIndex uint64, epoch uint64, currentFork beacon.Fork, genesisValidatorsRoot []byte) (types.ValidatorSignature, error) {
+	return c.sign(pubkey, signRequest{
+		Type: signType_VoluntaryExit,
+		ForkInfo: &forkInfo{
+			Fork: fork{
+				PreviousVersion: encodeBytes(currentFork.PreviousVersion),
+				CurrentVersion:  encodeBytes(currentFork.CurrentVersion),
+				Epoch:           strconv.FormatUint(currentFork.Epoch, 10),
+			},
+			GenesisValidatorsRoot: encodeBytes(genesisValidatorsRoot),
+		},
+		VoluntaryExit: &voluntaryExit{
+			Epoch:          strconv.FormatUint(epoch, 10),
+			ValidatorIndex: strconv.FormatUint(validatorIndex, 10),
+		},
+	})
+}
+
+// Have the signer sign a message with a validator's key
+func (c *Client) sign(pubkey types.ValidatorPubkey, request signRequest) (types.ValidatorSignature, error) {
+
+	// Send the request
+	responseBody, status, err := c.sendRequest(http.MethodPost, fmt.Sprintf(SignPathFormat, hexutil.AddPrefix(pubkey.Hex())), request)
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Could not get %s signature for validator %s from Web3Signer: %w", request.Type, pubkey.Hex(), err)
+	}
+	if status == http.StatusNotFound {
+		return types.ValidatorSignature{}, fmt.Errorf("Web3Signer does not have the key for validator %s", pubkey.Hex())
+	}
+	if status != http.StatusOK {
+		return types.ValidatorSignature{}, fmt.Errorf("Could not get %s signature for validator %s from Web3Signer: HTTP status %d; response body: '%s'", request.Type, pubkey.Hex(), status, string(responseBody))
+	}
+
+	// Decode the signature
+	var response signResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Could not decode Web3Signer signature response: %w", err)
+	}
+	signature, err := types.HexToValidatorSignature(hexutil.RemovePrefix(response.Signature))
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Web3Signer returned invalid signature %s: %w", response.Signature, err)
+	}
+	return signature, nil
+
+}
+
+// Send a request to the signer, returning the response body and status code
+func (c *Client) sendRequest(method string, requestPath string, requestBody interface{}) ([]byte, int, error) {
+
+	// Build the request
+	var bodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return []byte{}, 0, err
+		}
+		bodyReader = bytes.NewReader(requestBodyBytes)
+	}
+	request, err := http.NewRequest(method, c.url+requestPath, bodyReader)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	request.Header.Set("Accept", RequestContentType)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+
+	// Send the request
+	response, err := c.client.Do(request)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get the response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	return body, response.StatusCode, nil
+
+}
+
+// Encode bytes as a 0x-prefixed hex string
+func encodeBytes(value []byte) string {
+	return hexutil.AddPrefix(hex.EncodeToString(value))
+}
@@ -0,0 +1,24 @@
+package web3signer
+
+// Signing API request and response types
+type signRequest struct {
+	Type          string         `json:"type"`
+	ForkInfo      *forkInfo      `json:"fork_info,omitempty"`
+	VoluntaryExit *voluntaryExit `json:"voluntary_exit,omitempty"`
+}
+type forkInfo struct {
+	Fork                  fork   `json:"fork"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+type fork struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+type voluntaryExit struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+type signResponse struct {
+	Signature string `json:"signature"`
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type RecordRewardsFixtureResponse struct {
+	Status                 string      `json:"status"`
+	Error                  string      `json:"error"`
+	Index                  uint64      `json:"index"`
+	RulesetVersion         uint64      `json:"rulesetVersion"`
+	CanonicalMerkleRoot    common.Hash `json:"canonicalMerkleRoot"`
+	GoldenMerkleRoot       common.Hash `json:"goldenMerkleRoot"`
+	BeaconResponseCount    int         `json:"beaconResponseCount"`
+	ExecutionResponseCount int         `json:"executionResponseCount"`
+	Path                   string      `json:"path"`
+}
+
+type ReplayRewardsFixtureResponse struct {
+	Status              string      `json:"status"`
+	Error               string      `json:"error"`
+	Index               uint64      `json:"index"`
+	RulesetVersion      uint64      `json:"rulesetVersion"`
+	GoldenMerkleRoot    common.Hash `json:"goldenMerkleRoot"`
+	GeneratedMerkleRoot common.Hash `json:"generatedMerkleRoot"`
+	Matches             bool        `json:"matches"`
+}
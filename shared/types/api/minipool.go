@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
 )
 
 type MinipoolStatusResponse struct {
@@ -20,6 +21,14 @@ type MinipoolStatusResponse struct {
 	LatestDelegate  common.Address    `json:"latestDelegate"`
 	IsAtlasDeployed bool              `json:"isAtlasDeployed"`
 }
+type MinipoolCheckScrubResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	StateBlockTime time.Time      `json:"stateBlockTime"`
+	ScrubPeriod    time.Duration  `json:"scrubPeriod"`
+	SafetyPeriod   time.Duration  `json:"safetyPeriod"`
+	Results        []scrub.Result `json:"results"`
+}
 type MinipoolDetails struct {
 	Address               common.Address         `json:"address"`
 	ValidatorPubkey       types.ValidatorPubkey  `json:"validatorPubkey"`
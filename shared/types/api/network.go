@@ -2,8 +2,11 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/balances"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 )
 
 type NodeFeeResponse struct {
@@ -70,6 +73,45 @@ type NetworkGenerateRewardsTreeResponse struct {
 	Error  string `json:"error"`
 }
 
+type NetworkVerifyRewardsTreeResponse struct {
+	Status                     string                            `json:"status"`
+	Error                      string                            `json:"error"`
+	Index                      uint64                            `json:"index"`
+	RulesetVersion             uint64                            `json:"rulesetVersion"`
+	Matches                    bool                              `json:"matches"`
+	CanonicalMerkleRoot        common.Hash                       `json:"canonicalMerkleRoot"`
+	GeneratedMerkleRoot        common.Hash                       `json:"generatedMerkleRoot"`
+	MinipoolPerformanceChecked bool                              `json:"minipoolPerformanceChecked"`
+	NodeDiffs                  []rewards.NodeRewardsDiff         `json:"nodeDiffs"`
+	MinipoolDiffs              []rewards.MinipoolPerformanceDiff `json:"minipoolDiffs"`
+}
+
+type NetworkBalancesAtResponse struct {
+	Status    string                    `json:"status"`
+	Error     string                    `json:"error"`
+	Snapshots []NetworkBalancesSnapshot `json:"snapshots"`
+}
+type NetworkBalancesSnapshot struct {
+	Block                 uint64                            `json:"block"`
+	Slot                  uint64                            `json:"slot"`
+	Time                  time.Time                         `json:"time"`
+	UsesLegacyCalculation bool                              `json:"usesLegacyCalculation"`
+	Balances              balances.NetworkBalances          `json:"balances"`
+	TotalEth              *big.Int                          `json:"totalEth"`
+	Minipools             []balances.MinipoolBalanceDetails `json:"minipools"`
+	Consensus             *NetworkBalancesSubmission        `json:"consensus"`
+	MemberSubmissions     []NetworkBalancesSubmission       `json:"memberSubmissions"`
+	Matches               bool                              `json:"matches"`
+}
+type NetworkBalancesSubmission struct {
+	Member         common.Address `json:"member"`
+	SubmittedBlock uint64         `json:"submittedBlock"`
+	TxHash         common.Hash    `json:"txHash"`
+	TotalEth       *big.Int       `json:"totalEth"`
+	StakingEth     *big.Int       `json:"stakingEth"`
+	RethSupply     *big.Int       `json:"rethSupply"`
+}
+
 type NetworkDAOProposalsResponse struct {
 	Status                  string                 `json:"status"`
 	Error                   string                 `json:"error"`
@@ -515,3 +515,21 @@ type NodeEthBalanceResponse struct {
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type RewardsLedgerEntry struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Block     uint64      `json:"block"`
+	TxHash    common.Hash `json:"txHash"`
+	Asset     string      `json:"asset"`
+	Amount    *big.Int    `json:"amount"`
+	Source    string      `json:"source"`
+	Interval  *uint64     `json:"interval,omitempty"`
+}
+type NodeRewardsHistoryResponse struct {
+	Status     string               `json:"status"`
+	Error      string               `json:"error"`
+	Registered bool                 `json:"registered"`
+	StartBlock uint64               `json:"startBlock"`
+	EndBlock   uint64               `json:"endBlock"`
+	Entries    []RewardsLedgerEntry `json:"entries"`
+}
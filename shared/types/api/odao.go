@@ -7,6 +7,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/dao"
 	tn "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 )
 
 type TNDAOStatusResponse struct {
@@ -314,3 +316,11 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	BondReductionWindowStart  uint64 `json:"bondReductionWindowStart"`
 	BondReductionWindowLength uint64 `json:"bondReductionWindowLength"`
 }
+
+type TNDAOAuditLogResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	Path           string         `json:"path"`
+	Records        []audit.Record `json:"records"`
+	MalformedLines []int          `json:"malformedLines"`
+}
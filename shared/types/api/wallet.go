@@ -60,6 +60,12 @@ type RebuildWalletResponse struct {
 	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
 }
 
+type ReleaseHeldKeysResponse struct {
+	Status        string                  `json:"status"`
+	Error         string                  `json:"error"`
+	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+}
+
 type ExportWalletResponse struct {
 	Status            string `json:"status"`
 	Error             string `json:"error"`
@@ -7,9 +7,12 @@ type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
 type RewardsMode string
+type RewardsUploader string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type NodeSignerType string
+type PasswordStoreType string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -83,6 +86,13 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe the services the Oracle DAO can use to publish rewards files
+const (
+	RewardsUploader_Unknown     RewardsUploader = ""
+	RewardsUploader_Web3Storage RewardsUploader = "web3storage"
+	RewardsUploader_Ipfs        RewardsUploader = "ipfs"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
@@ -108,6 +118,22 @@ const (
 	NimbusPruningMode_Prune   NimbusPruningMode = "prune"
 )
 
+// Enum to describe where the node account's key is held
+const (
+	NodeSignerType_Local NodeSignerType = "local"
+	NodeSignerType_Clef  NodeSignerType = "clef"
+	NodeSignerType_Http  NodeSignerType = "http"
+)
+
+// Enum to describe where the node wallet's password is kept
+const (
+	PasswordStoreType_File              PasswordStoreType = "file"
+	PasswordStoreType_KernelKeyring     PasswordStoreType = "kernel-keyring"
+	PasswordStoreType_SecretService     PasswordStoreType = "secret-service"
+	PasswordStoreType_SystemdCredential PasswordStoreType = "systemd-credential"
+	PasswordStoreType_Encrypted         PasswordStoreType = "encrypted"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
@@ -0,0 +1,275 @@
+package eth2
+
+// Beacon chain block types, used to decode SSZ-encoded blocks from the Beacon API.
+// Sizes use the mainnet preset, which all of the supported networks share.
+
+type Checkpoint struct {
+	Epoch uint64 `json:"epoch"`
+	Root  []byte `json:"root" ssz-size:"32"`
+}
+
+type AttestationData struct {
+	Slot            uint64      `json:"slot"`
+	Index           uint64      `json:"index"`
+	BeaconBlockRoot []byte      `json:"beacon_block_root" ssz-size:"32"`
+	Source          *Checkpoint `json:"source"`
+	Target          *Checkpoint `json:"target"`
+}
+
+type Attestation struct {
+	AggregationBits []byte           `json:"aggregation_bits" ssz:"bitlist" ssz-max:"2048"`
+	Data            *AttestationData `json:"data"`
+	Signature       []byte           `json:"signature" ssz-size:"96"`
+}
+
+type IndexedAttestation struct {
+	AttestingIndices []uint64         `json:"attesting_indices" ssz-max:"2048"`
+	Data             *AttestationData `json:"data"`
+	Signature        []byte           `json:"signature" ssz-size:"96"`
+}
+
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation `json:"attestation_1"`
+	Attestation2 *IndexedAttestation `json:"attestation_2"`
+}
+
+type BeaconBlockHeader struct {
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	ParentRoot    []byte `json:"parent_root" ssz-size:"32"`
+	StateRoot     []byte `json:"state_root" ssz-size:"32"`
+	BodyRoot      []byte `json:"body_root" ssz-size:"32"`
+}
+
+type SignedBeaconBlockHeader struct {
+	Header    *BeaconBlockHeader `json:"message"`
+	Signature []byte             `json:"signature" ssz-size:"96"`
+}
+
+type ProposerSlashing struct {
+	Header1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+	Header2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+}
+
+type Deposit struct {
+	Proof [][]byte     `json:"proof" ssz-size:"33,32"`
+	Data  *DepositData `json:"data"`
+}
+
+type SignedVoluntaryExit struct {
+	Exit      *VoluntaryExit `json:"message"`
+	Signature []byte         `json:"signature" ssz-size:"96"`
+}
+
+type SignedBLSToExecutionChange struct {
+	Message   *WithdrawalCredentialsChange `json:"message"`
+	Signature []byte                       `json:"signature" ssz-size:"96"`
+}
+
+type Eth1Data struct {
+	DepositRoot  []byte `json:"deposit_root" ssz-size:"32"`
+	DepositCount uint64 `json:"deposit_count"`
+	BlockHash    []byte `json:"block_hash" ssz-size:"32"`
+}
+
+type SyncAggregate struct {
+	SyncCommitteeBits      []byte `json:"sync_committee_bits" ssz-size:"64"`
+	SyncCommitteeSignature []byte `json:"sync_committee_signature" ssz-size:"96"`
+}
+
+type Withdrawal struct {
+	Index          uint64 `json:"index"`
+	ValidatorIndex uint64 `json:"validator_index"`
+	Address        []byte `json:"address" ssz-size:"20"`
+	Amount         uint64 `json:"amount"`
+}
+
+// Phase 0
+type BeaconBlockBodyPhase0 struct {
+	RandaoReveal      []byte                 `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data          *Eth1Data              `json:"eth1_data"`
+	Graffiti          []byte                 `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings []*ProposerSlashing    `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings []*AttesterSlashing    `json:"attester_slashings" ssz-max:"2"`
+	Attestations      []*Attestation         `json:"attestations" ssz-max:"128"`
+	Deposits          []*Deposit             `json:"deposits" ssz-max:"16"`
+	VoluntaryExits    []*SignedVoluntaryExit `json:"voluntary_exits" ssz-max:"16"`
+}
+
+type BeaconBlockPhase0 struct {
+	Slot          uint64                 `json:"slot"`
+	ProposerIndex uint64                 `json:"proposer_index"`
+	ParentRoot    []byte                 `json:"parent_root" ssz-size:"32"`
+	StateRoot     []byte                 `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyPhase0 `json:"body"`
+}
+
+type SignedBeaconBlockPhase0 struct {
+	Block     *BeaconBlockPhase0 `json:"message"`
+	Signature []byte             `json:"signature" ssz-size:"96"`
+}
+
+// Altair
+type BeaconBlockBodyAltair struct {
+	RandaoReveal      []byte                 `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data          *Eth1Data              `json:"eth1_data"`
+	Graffiti          []byte                 `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings []*ProposerSlashing    `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings []*AttesterSlashing    `json:"attester_slashings" ssz-max:"2"`
+	Attestations      []*Attestation         `json:"attestations" ssz-max:"128"`
+	Deposits          []*Deposit             `json:"deposits" ssz-max:"16"`
+	VoluntaryExits    []*SignedVoluntaryExit `json:"voluntary_exits" ssz-max:"16"`
+	SyncAggregate     *SyncAggregate         `json:"sync_aggregate"`
+}
+
+type BeaconBlockAltair struct {
+	Slot          uint64                 `json:"slot"`
+	ProposerIndex uint64                 `json:"proposer_index"`
+	ParentRoot    []byte                 `json:"parent_root" ssz-size:"32"`
+	StateRoot     []byte                 `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyAltair `json:"body"`
+}
+
+type SignedBeaconBlockAltair struct {
+	Block     *BeaconBlockAltair `json:"message"`
+	Signature []byte             `json:"signature" ssz-size:"96"`
+}
+
+// Bellatrix
+type ExecutionPayloadBellatrix struct {
+	ParentHash    []byte   `json:"parent_hash" ssz-size:"32"`
+	FeeRecipient  []byte   `json:"fee_recipient" ssz-size:"20"`
+	StateRoot     []byte   `json:"state_root" ssz-size:"32"`
+	ReceiptsRoot  []byte   `json:"receipts_root" ssz-size:"32"`
+	LogsBloom     []byte   `json:"logs_bloom" ssz-size:"256"`
+	PrevRandao    []byte   `json:"prev_randao" ssz-size:"32"`
+	BlockNumber   uint64   `json:"block_number"`
+	GasLimit      uint64   `json:"gas_limit"`
+	GasUsed       uint64   `json:"gas_used"`
+	Timestamp     uint64   `json:"timestamp"`
+	ExtraData     []byte   `json:"extra_data" ssz-max:"32"`
+	BaseFeePerGas []byte   `json:"base_fee_per_gas" ssz-size:"32"`
+	BlockHash     []byte   `json:"block_hash" ssz-size:"32"`
+	Transactions  [][]byte `json:"transactions" ssz-max:"1048576,1073741824" ssz-size:"?,?"`
+}
+
+type BeaconBlockBodyBellatrix struct {
+	RandaoReveal      []byte                     `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data          *Eth1Data                  `json:"eth1_data"`
+	Graffiti          []byte                     `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings []*ProposerSlashing        `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings []*AttesterSlashing        `json:"attester_slashings" ssz-max:"2"`
+	Attestations      []*Attestation             `json:"attestations" ssz-max:"128"`
+	Deposits          []*Deposit                 `json:"deposits" ssz-max:"16"`
+	VoluntaryExits    []*SignedVoluntaryExit     `json:"voluntary_exits" ssz-max:"16"`
+	SyncAggregate     *SyncAggregate             `json:"sync_aggregate"`
+	ExecutionPayload  *ExecutionPayloadBellatrix `json:"execution_payload"`
+}
+
+type BeaconBlockBellatrix struct {
+	Slot          uint64                    `json:"slot"`
+	ProposerIndex uint64                    `json:"proposer_index"`
+	ParentRoot    []byte                    `json:"parent_root" ssz-size:"32"`
+	StateRoot     []byte                    `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyBellatrix `json:"body"`
+}
+
+type SignedBeaconBlockBellatrix struct {
+	Block     *BeaconBlockBellatrix `json:"message"`
+	Signature []byte                `json:"signature" ssz-size:"96"`
+}
+
+// Capella
+type ExecutionPayloadCapella struct {
+	ParentHash    []byte        `json:"parent_hash" ssz-size:"32"`
+	FeeRecipient  []byte        `json:"fee_recipient" ssz-size:"20"`
+	StateRoot     []byte        `json:"state_root" ssz-size:"32"`
+	ReceiptsRoot  []byte        `json:"receipts_root" ssz-size:"32"`
+	LogsBloom     []byte        `json:"logs_bloom" ssz-size:"256"`
+	PrevRandao    []byte        `json:"prev_randao" ssz-size:"32"`
+	BlockNumber   uint64        `json:"block_number"`
+	GasLimit      uint64        `json:"gas_limit"`
+	GasUsed       uint64        `json:"gas_used"`
+	Timestamp     uint64        `json:"timestamp"`
+	ExtraData     []byte        `json:"extra_data" ssz-max:"32"`
+	BaseFeePerGas []byte        `json:"base_fee_per_gas" ssz-size:"32"`
+	BlockHash     []byte        `json:"block_hash" ssz-size:"32"`
+	Transactions  [][]byte      `json:"transactions" ssz-max:"1048576,1073741824" ssz-size:"?,?"`
+	Withdrawals   []*Withdrawal `json:"withdrawals" ssz-max:"16"`
+}
+
+type BeaconBlockBodyCapella struct {
+	RandaoReveal          []byte                        `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data              *Eth1Data                     `json:"eth1_data"`
+	Graffiti              []byte                        `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings     []*ProposerSlashing           `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing           `json:"attester_slashings" ssz-max:"2"`
+	Attestations          []*Attestation                `json:"attestations" ssz-max:"128"`
+	Deposits              []*Deposit                    `json:"deposits" ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit        `json:"voluntary_exits" ssz-max:"16"`
+	SyncAggregate         *SyncAggregate                `json:"sync_aggregate"`
+	ExecutionPayload      *ExecutionPayloadCapella      `json:"execution_payload"`
+	BlsToExecutionChanges []*SignedBLSToExecutionChange `json:"bls_to_execution_changes" ssz-max:"16"`
+}
+
+type BeaconBlockCapella struct {
+	Slot          uint64                  `json:"slot"`
+	ProposerIndex uint64                  `json:"proposer_index"`
+	ParentRoot    []byte                  `json:"parent_root" ssz-size:"32"`
+	StateRoot     []byte                  `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyCapella `json:"body"`
+}
+
+type SignedBeaconBlockCapella struct {
+	Block     *BeaconBlockCapella `json:"message"`
+	Signature []byte              `json:"signature" ssz-size:"96"`
+}
+
+// Deneb
+type ExecutionPayloadDeneb struct {
+	ParentHash    []byte        `json:"parent_hash" ssz-size:"32"`
+	FeeRecipient  []byte        `json:"fee_recipient" ssz-size:"20"`
+	StateRoot     []byte        `json:"state_root" ssz-size:"32"`
+	ReceiptsRoot  []byte        `json:"receipts_root" ssz-size:"32"`
+	LogsBloom     []byte        `json:"logs_bloom" ssz-size:"256"`
+	PrevRandao    []byte        `json:"prev_randao" ssz-size:"32"`
+	BlockNumber   uint64        `json:"block_number"`
+	GasLimit      uint64        `json:"gas_limit"`
+	GasUsed       uint64        `json:"gas_used"`
+	Timestamp     uint64        `json:"timestamp"`
+	ExtraData     []byte        `json:"extra_data" ssz-max:"32"`
+	BaseFeePerGas []byte        `json:"base_fee_per_gas" ssz-size:"32"`
+	BlockHash     []byte        `json:"block_hash" ssz-size:"32"`
+	Transactions  [][]byte      `json:"transactions" ssz-max:"1048576,1073741824" ssz-size:"?,?"`
+	Withdrawals   []*Withdrawal `json:"withdrawals" ssz-max:"16"`
+	BlobGasUsed   uint64        `json:"blob_gas_used"`
+	ExcessBlobGas uint64        `json:"excess_blob_gas"`
+}
+
+type BeaconBlockBodyDeneb struct {
+	RandaoReveal          []byte                        `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data              *Eth1Data                     `json:"eth1_data"`
+	Graffiti              []byte                        `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings     []*ProposerSlashing           `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing           `json:"attester_slashings" ssz-max:"2"`
+	Attestations          []*Attestation                `json:"attestations" ssz-max:"128"`
+	Deposits              []*Deposit                    `json:"deposits" ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit        `json:"voluntary_exits" ssz-max:"16"`
+	SyncAggregate         *SyncAggregate                `json:"sync_aggregate"`
+	ExecutionPayload      *ExecutionPayloadDeneb        `json:"execution_payload"`
+	BlsToExecutionChanges []*SignedBLSToExecutionChange `json:"bls_to_execution_changes" ssz-max:"16"`
+	BlobKzgCommitments    [][]byte                      `json:"blob_kzg_commitments" ssz-max:"4096" ssz-size:"?,48"`
+}
+
+type BeaconBlockDeneb struct {
+	Slot          uint64                `json:"slot"`
+	ProposerIndex uint64                `json:"proposer_index"`
+	ParentRoot    []byte                `json:"parent_root" ssz-size:"32"`
+	StateRoot     []byte                `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyDeneb `json:"body"`
+}
+
+type SignedBeaconBlockDeneb struct {
+	Block     *BeaconBlockDeneb `json:"message"`
+	Signature []byte            `json:"signature" ssz-size:"96"`
+}
@@ -0,0 +1,7 @@
+#!/bin/sh
+# Regenerates the SSZ encoding files for this package.
+# Requires sszgen from github.com/ferranbt/fastssz (go install github.com/ferranbt/fastssz/sszgen@latest).
+cd "$(dirname "$0")" || exit 1
+rm -f types_encoding.go blocks_encoding.go
+sszgen --path types.go
+sszgen --path blocks.go --include types.go
@@ -3,14 +3,16 @@ package api
 import (
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -56,6 +58,13 @@ func PrintAndCheckGasInfo(gasInfo rocketpool.GasInfo, checkThreshold bool, gasTh
 
 // Print a TX's details to the logger and waits for it to validated.
 func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash, ec rocketpool.ExecutionClient, logger log.ColorLogger) error {
+	_, err := PrintAndWaitForTransactionReceipt(cfg, hash, ec, logger)
+	return err
+}
+
+// Print a TX's details to the logger and waits for it to validated, returning the receipt of whichever version of it was mined.
+// The receipt is returned along with the error if the transaction was mined but failed.
+func PrintAndWaitForTransactionReceipt(cfg *config.RocketPoolConfig, hash common.Hash, ec rocketpool.ExecutionClient, logger log.ColorLogger) (*types.Receipt, error) {
 
 	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
 	hashString := hash.String()
@@ -67,12 +76,13 @@ func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash,
 	}
 	logger.Println("Waiting for the transaction to be validated...")
 
-	// Wait for the TX to be included in a block
-	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
-		return fmt.Errorf("Error waiting for transaction: %w", err)
+	// Wait for the TX to be included in a block, following any replacements made for it if it gets stuck
+	receipt, err := wallet.WaitForTransaction(ec, os.ExpandEnv(cfg.Smartnode.GetPendingTransactionsPath()), hash)
+	if err != nil {
+		return receipt, fmt.Errorf("Error waiting for transaction: %w", err)
 	}
 
-	return nil
+	return receipt, nil
 
 }
 
@@ -26,6 +26,25 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 		return false
 	}
 
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		fmt.Printf("error loading config: %s\n", err.Error())
+		return false
+	}
+
+	// Check for slashing protection data
+	protectionImport, proceed, err := wallet.PrepareSlashingProtection(c, cfg)
+	if err != nil {
+		fmt.Printf("error loading slashing protection data: %s\n", err.Error())
+		return false
+	}
+	if !proceed {
+		fmt.Println("Cancelled.")
+		return false
+	}
+	defer protectionImport.Cleanup()
+
 	// Get the mnemonic
 	if mnemonic == "" {
 		mnemonic = wallet.PromptMnemonic()
@@ -33,21 +52,47 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 
 	// Import the key
 	fmt.Printf("Importing validator key... ")
-	_, err := rp.ImportKey(minipoolAddress, mnemonic)
+	_, err = rp.ImportKey(minipoolAddress, mnemonic)
 	if err != nil {
 		fmt.Printf("error importing validator key: %s\n", err.Error())
 		return false
 	}
 	fmt.Println("done!")
 
-	// Restart the VC if necessary
+	// Import the slashing protection data for it
+	err = protectionImport.Finish(rp, cfg)
+	if err != nil {
+		fmt.Printf("%sWARNING: error importing slashing protection data: %s\n\nPlease import it with `rocketpool wallet import-slashing-protection` before your Validator Client loads the new validator key for your minipool.%s\n", colorYellow, err.Error(), colorReset)
+		return false
+	}
+
+	// Restart the VC if necessary; with the Keymanager API, the key was loaded into it while importing
 	if c.Bool("no-restart") {
 		return true
 	}
+	if cfg.Smartnode.IsKeymanagerApiEnabled() {
+		fmt.Println("Your Validator Client loaded the key through its Keymanager API, so it doesn't need to be restarted.")
+		return true
+	}
+	if protectionImport.ValidatorRestarted() {
+		fmt.Println("Your Validator Client was restarted while importing the slashing protection data, so it has loaded your validator's key.")
+		return true
+	}
 	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the Smartnode's Validator Client now so it loads your validator's key?") {
+		// The key is held back from the VC until its slashing protection data is handled, so have the user release it first
+		released, err := cliutils.ConfirmReleaseHeldKeys(rp, cfg)
+		if err != nil {
+			fmt.Printf("%sWARNING: error releasing validator keys waiting for slashing protection data: %s%s\n", colorYellow, err.Error(), colorReset)
+			return false
+		}
+		if released == nil {
+			fmt.Println("Your Validator Client was not restarted. Import the key's slashing protection data with `rocketpool wallet import-slashing-protection` first.")
+			return true
+		}
+
 		// Restart the VC
 		fmt.Print("Restarting Validator Client... ")
-		_, err := rp.RestartVc()
+		_, err = rp.RestartVc()
 		if err != nil {
 			fmt.Printf("failed!\n%sWARNING: error restarting validator client: %s\n\nPlease restart it manually so it picks up the new validator key for your minipool.%s", colorYellow, err.Error(), colorReset)
 			return false
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+)
+
+// Get the validator keys the daemon is holding back from the Validator client until their slashing protection data is imported
+func GetHeldValidatorKeys(cfg *config.RocketPoolConfig) ([]types.ValidatorPubkey, error) {
+	pendingPath, err := homedir.Expand(cfg.Smartnode.GetSlashingProtectionPendingPathInCLI())
+	if err != nil {
+		return nil, fmt.Errorf("error expanding pending slashing protection path: %w", err)
+	}
+	return slashing.LoadPendingKeys(pendingPath)
+}
+
+// Have the user accept going without slashing protection data for the held validator keys, then release them to the Validator client.
+// It only loads them the next time it starts. Returns the keys that were released, or nil if the user didn't accept.
+func ConfirmReleaseHeldKeys(rp *rocketpool.Client, cfg *config.RocketPoolConfig) ([]types.ValidatorPubkey, error) {
+	heldKeys, err := GetHeldValidatorKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(heldKeys) == 0 {
+		return []types.ValidatorPubkey{}, nil
+	}
+
+	fmt.Printf("%sThe following validator keys are being held back from your Validator client until their slashing protection data is imported:%s\n", colorYellow, colorReset)
+	for _, pubkey := range heldKeys {
+		fmt.Println(pubkey.Hex())
+	}
+	fmt.Println("\nIf you have the data from the machine that ran them before, import it with `rocketpool wallet import-slashing-protection` instead.")
+	if !ConfirmDoppelgangerWait(cfg) {
+		return nil, nil
+	}
+
+	response, err := rp.ReleaseHeldKeys(heldKeys)
+	if err != nil {
+		return nil, err
+	}
+	return response.ValidatorKeys, nil
+}
+
+// Have the user accept relying on the doppelganger wait instead of slashing protection data
+func ConfirmDoppelgangerWait(cfg *config.RocketPoolConfig) bool {
+	fmt.Printf("%sWithout slashing protection data, your Validator client can't tell which duties these validators already performed elsewhere.\nYou **MUST** make sure they have stopped on every other machine and have missed at least two attestations (at least 15 minutes) before it loads them, or they **WILL BE SLASHED**.%s\n", colorRed, colorReset)
+	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
+	if err == nil && doppelgangerEnabled {
+		fmt.Println("Doppelganger Protection is enabled, so your Validator client will also wait 2-3 epochs and refuse to start these validators if it sees them attesting elsewhere.")
+	} else {
+		fmt.Printf("%sDoppelganger Protection is not enabled, so nothing will stop your Validator client from signing with these validators right away.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+	return Confirm("Have you confirmed these validators haven't attested for at least 15 minutes, and do you accept relying on that wait instead of slashing protection data?")
+}
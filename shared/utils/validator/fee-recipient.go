@@ -12,8 +12,10 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -105,6 +107,40 @@ func RestartValidator(cfg *config.RocketPoolConfig, bc beacon.Client, log *log.C
 
 }
 
+// Set the fee recipient of every validator the validator client has loaded through its Keymanager API, so it takes effect without a restart.
+// Returns the number of validators whose fee recipient was changed.
+func SetValidatorFeeRecipients(km *keymanager.Client, feeRecipient common.Address) (int, error) {
+
+	// Get the validators the client has loaded
+	pubkeys, err := km.ListKeystores()
+	if err != nil {
+		return 0, err
+	}
+	remoteKeys, err := km.ListRemoteKeys()
+	if err != nil {
+		return 0, err
+	}
+	pubkeys = append(pubkeys, remoteKeys...)
+
+	// Update the ones that aren't using the fee recipient yet
+	updated := 0
+	for _, pubkey := range pubkeys {
+		currentFeeRecipient, err := km.GetFeeRecipient(pubkey)
+		if err != nil {
+			return updated, err
+		}
+		if currentFeeRecipient == feeRecipient {
+			continue
+		}
+		if err := km.SetFeeRecipient(pubkey, feeRecipient); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+
+}
+
 // Stops the validator process
 func StopValidator(cfg *config.RocketPoolConfig, bc beacon.Client, log *log.ColorLogger, d *client.Client) error {
 
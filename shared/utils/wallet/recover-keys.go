@@ -56,6 +56,13 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 		pubkeyMap[pubkey] = true
 	}
 
+	// Hold keys that are new to this node back from the VC until their slashing protection data is imported
+	if !testOnly {
+		if err := HoldNewValidatorKeys(cfg, w); err != nil {
+			return nil, err
+		}
+	}
+
 	pubkeyMap, err = CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
 	if err != nil {
 		return nil, fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
@@ -100,6 +107,12 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 		bucketStart = bucketEnd
 	}
 
+	if !testOnly {
+		if err := RecordHeldValidatorKeys(cfg, w); err != nil {
+			return nil, err
+		}
+	}
+
 	return pubkeys, nil
 
 }
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+)
+
+// Hold validator keys written by a recovery or migration back from the VC until their slashing protection data is imported.
+// Keys imported into a client through the Keymanager API aren't held, since their data is imported along with them or the user already accepted going without it.
+func HoldNewValidatorKeys(cfg *config.RocketPoolConfig, w *wallet.Wallet) error {
+	if cfg.Smartnode.IsWeb3SignerEnabled() || cfg.Smartnode.IsKeymanagerApiEnabled() {
+		return nil
+	}
+	return w.HoldNewValidatorKeys()
+}
+
+// Record the validator keys the wallet held back as waiting for their slashing protection data
+func RecordHeldValidatorKeys(cfg *config.RocketPoolConfig, w *wallet.Wallet) error {
+	pubkeys := w.GetNewlyHeldValidatorKeys()
+	if len(pubkeys) == 0 {
+		return nil
+	}
+	if err := slashing.AddPendingKeys(os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionPendingPath()), pubkeys); err != nil {
+		return fmt.Errorf("error recording validator keys waiting for slashing protection data: %w", err)
+	}
+	return nil
+}
+
+// Move held validator keys into the keystores so the VC loads them the next time it starts.
+// If no keys are given, every held key is released. Returns the keys that were released.
+func ReleaseHeldValidatorKeys(cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkeys []types.ValidatorPubkey) ([]types.ValidatorPubkey, error) {
+	pendingPath := os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionPendingPath())
+	pending, err := slashing.LoadPendingKeys(pendingPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(pubkeys) == 0 {
+		pubkeys = pending
+	}
+
+	released := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if !slashing.ContainsPubkey(pending, pubkey) {
+			continue
+		}
+		if err := w.ReleaseHeldValidatorKey(pubkey); err != nil {
+			// Keep the ones that made it so the pending list matches the held keys
+			if _, clearErr := slashing.ClearPendingKeys(pendingPath, released); clearErr != nil {
+				return nil, fmt.Errorf("%w (and the pending list couldn't be updated: %s)", err, clearErr.Error())
+			}
+			return nil, err
+		}
+		released = append(released, pubkey)
+	}
+
+	if _, err := slashing.ClearPendingKeys(pendingPath, released); err != nil {
+		return nil, err
+	}
+	return released, nil
+}